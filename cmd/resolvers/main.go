@@ -17,8 +17,10 @@ limitations under the License.
 package main
 
 import (
+	"errors"
 	"flag"
 	"log"
+	nethttp "net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -26,10 +28,14 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/bundle"
 	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/cluster"
+	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/external"
 	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/framework"
+	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/framework/cache"
 	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/git"
 	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/http"
 	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/hub"
+	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/s3"
+	"github.com/tektoncd/pipeline/pkg/remoteresolution/retention"
 	hubresolution "github.com/tektoncd/pipeline/pkg/resolution/resolver/hub"
 	"k8s.io/client-go/rest"
 	filteredinformerfactory "knative.dev/pkg/client/injection/kube/informers/factory/filtered"
@@ -66,12 +72,31 @@ func main() {
 	cfg.QPS = 5 * cfg.QPS
 	cfg.Burst = 5 * cfg.Burst
 
+	if addr := os.Getenv(cache.AdminAddressEnv); addr != "" {
+		server, err := cache.NewAdminServer(ctx, addr)
+		if err != nil {
+			log.Fatalf("failed to parse value %q of %s: %v\n", addr, cache.AdminAddressEnv, err)
+		}
+		go func() {
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, nethttp.ErrServerClosed) {
+				log.Printf("cache admin endpoint stopped: %v\n", err)
+			}
+		}()
+		go func() {
+			<-ctx.Done()
+			_ = server.Close()
+		}()
+	}
+
 	sharedmain.MainWithConfig(ctx, "controller", cfg,
 		framework.NewController(ctx, &git.Resolver{}),
 		framework.NewController(ctx, &hub.Resolver{TektonHubURL: tektonHubURL, ArtifactHubURL: artifactHubURL}),
 		framework.NewController(ctx, &bundle.Resolver{}),
 		framework.NewController(ctx, &cluster.Resolver{}),
-		framework.NewController(ctx, &http.Resolver{}))
+		framework.NewController(ctx, &http.Resolver{}),
+		framework.NewController(ctx, &s3.Resolver{}),
+		framework.NewController(ctx, &external.Resolver{}),
+		retention.NewController())
 }
 
 func buildHubURL(configAPI, defaultURL string) string {
@@ -44,6 +44,8 @@ const (
 	DefaultCloudEventSinkValue = ""
 	// DefaultMaxMatrixCombinationsCount is used when no max matrix combinations count is specified.
 	DefaultMaxMatrixCombinationsCount = 256
+	// DefaultMatrixMaxParallel is used when no matrix max parallel is specified, 0 means no limit.
+	DefaultMatrixMaxParallel = 0
 	// DefaultResolverTypeValue is used when no default resolver type is specified
 	DefaultResolverTypeValue = ""
 	// default resource requirements, will be applied to all the containers, which has empty resource requirements
@@ -67,6 +69,7 @@ const (
 	defaultCloudEventsSinkKey               = "default-cloud-events-sink"
 	defaultTaskRunWorkspaceBinding          = "default-task-run-workspace-binding"
 	defaultMaxMatrixCombinationsCountKey    = "default-max-matrix-combinations-count"
+	defaultMatrixMaxParallelKey             = "default-matrix-max-parallel"
 	defaultForbiddenEnv                     = "default-forbidden-env"
 	defaultResolverTypeKey                  = "default-resolver-type"
 	defaultContainerResourceRequirementsKey = "default-container-resource-requirements"
@@ -90,6 +93,7 @@ type Defaults struct {
 	DefaultCloudEventsSink               string // Deprecated. Use the events package instead
 	DefaultTaskRunWorkspaceBinding       string
 	DefaultMaxMatrixCombinationsCount    int
+	DefaultMatrixMaxParallel             int
 	DefaultForbiddenEnv                  []string
 	DefaultResolverType                  string
 	DefaultContainerResourceRequirements map[string]corev1.ResourceRequirements
@@ -129,6 +133,7 @@ func (cfg *Defaults) Equals(other *Defaults) bool {
 		other.DefaultCloudEventsSink == cfg.DefaultCloudEventsSink &&
 		other.DefaultTaskRunWorkspaceBinding == cfg.DefaultTaskRunWorkspaceBinding &&
 		other.DefaultMaxMatrixCombinationsCount == cfg.DefaultMaxMatrixCombinationsCount &&
+		other.DefaultMatrixMaxParallel == cfg.DefaultMatrixMaxParallel &&
 		other.DefaultResolverType == cfg.DefaultResolverType &&
 		other.DefaultImagePullBackOffTimeout == cfg.DefaultImagePullBackOffTimeout &&
 		other.DefaultMaximumResolutionTimeout == cfg.DefaultMaximumResolutionTimeout &&
@@ -145,6 +150,7 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		DefaultManagedByLabelValue:        DefaultManagedByLabelValue,
 		DefaultCloudEventsSink:            DefaultCloudEventSinkValue,
 		DefaultMaxMatrixCombinationsCount: DefaultMaxMatrixCombinationsCount,
+		DefaultMatrixMaxParallel:          DefaultMatrixMaxParallel,
 		DefaultResolverType:               DefaultResolverTypeValue,
 		DefaultImagePullBackOffTimeout:    DefaultImagePullBackOffTimeout,
 		DefaultMaximumResolutionTimeout:   DefaultMaximumResolutionTimeout,
@@ -199,6 +205,15 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		}
 		tc.DefaultMaxMatrixCombinationsCount = int(matrixCombinationsCount)
 	}
+
+	if defaultMatrixMaxParallel, ok := cfgMap[defaultMatrixMaxParallelKey]; ok {
+		matrixMaxParallel, err := strconv.ParseInt(defaultMatrixMaxParallel, 10, 0)
+		if err != nil || matrixMaxParallel < 0 {
+			return nil, fmt.Errorf("failed parsing default config %q", defaultMatrixMaxParallelKey)
+		}
+		tc.DefaultMatrixMaxParallel = int(matrixMaxParallel)
+	}
+
 	if defaultForbiddenEnvString, ok := cfgMap[defaultForbiddenEnv]; ok {
 		tmpString := sets.NewString()
 		fEnvs := strings.Split(defaultForbiddenEnvString, ",")
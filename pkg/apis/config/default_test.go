@@ -231,6 +231,25 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultSidecarLogPollingInterval:  100 * time.Millisecond,
 			},
 		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-matrix-max-parallel-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-matrix-max-parallel",
+			expectedConfig: &config.Defaults{
+				DefaultMatrixMaxParallel:          4,
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultImagePullBackOffTimeout:    0,
+				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultSidecarLogPollingInterval:  100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:    5,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -459,6 +478,15 @@ func TestEquals(t *testing.T) {
 				DefaultStepRefConcurrencyLimit: 5,
 			},
 			expected: true,
+		}, {
+			name: "different default matrix max parallel",
+			left: &config.Defaults{
+				DefaultMatrixMaxParallel: 2,
+			},
+			right: &config.Defaults{
+				DefaultMatrixMaxParallel: 4,
+			},
+			expected: false,
 		},
 	}
 
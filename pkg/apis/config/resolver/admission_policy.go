@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/google/cel-go/cel"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// admissionRuleCostLimit bounds the cost of evaluating a single rule, so
+// that a rule cannot stall the resolution of requests.
+const admissionRuleCostLimit = 1000000
+
+// AdmissionRule is a named CEL expression that a ResolutionRequest must
+// satisfy to be resolved.
+// +k8s:deepcopy-gen=false
+type AdmissionRule struct {
+	Name       string
+	Expression string
+	program    cel.Program
+}
+
+// AdmissionPolicy holds the rules deciding which resolvers and params the
+// ResolutionRequests of a namespace may use. A request is admitted if every
+// rule evaluates to true. A policy without rules admits every request.
+// +k8s:deepcopy-gen=false
+type AdmissionPolicy struct {
+	Rules []AdmissionRule
+}
+
+// GetAdmissionPolicyConfigName returns the name of the configmap containing
+// the admission rules of ResolutionRequests.
+func GetAdmissionPolicyConfigName() string {
+	if e := os.Getenv("CONFIG_RESOLVERS_ADMISSION_POLICY_NAME"); e != "" {
+		return e
+	}
+	return "resolvers-admission-policy"
+}
+
+// NewAdmissionPolicyFromMap returns an AdmissionPolicy with a rule for every
+// entry of cfgMap, named by its key. The rules are CEL expressions returning
+// a bool, with a request variable holding:
+//   - namespace: the namespace of the request
+//   - resolver: the type of the resolver, e.g. "git"
+//   - params: the params of the request by name, with string, list or map
+//     values
+func NewAdmissionPolicyFromMap(cfgMap map[string]string) (*AdmissionPolicy, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("request", cel.MapType(cel.StringType, cel.DynType)),
+		cel.OptionalTypes(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(cfgMap))
+	for name := range cfgMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	policy := &AdmissionPolicy{}
+	for _, name := range names {
+		ast, iss := env.Compile(cfgMap[name])
+		if iss.Err() != nil {
+			return nil, fmt.Errorf("invalid admission rule %q: %w", name, iss.Err())
+		}
+		if t := ast.OutputType(); t != cel.BoolType && t != cel.DynType {
+			return nil, fmt.Errorf("invalid admission rule %q: must evaluate to a bool, not %s", name, ast.OutputType())
+		}
+		prg, err := env.Program(ast, cel.CostLimit(admissionRuleCostLimit))
+		if err != nil {
+			return nil, fmt.Errorf("invalid admission rule %q: %w", name, err)
+		}
+		policy.Rules = append(policy.Rules, AdmissionRule{Name: name, Expression: cfgMap[name], program: prg})
+	}
+	return policy, nil
+}
+
+// NewAdmissionPolicyFromConfigMap returns an AdmissionPolicy for a given ConfigMap
+func NewAdmissionPolicyFromConfigMap(config *corev1.ConfigMap) (*AdmissionPolicy, error) {
+	return NewAdmissionPolicyFromMap(config.Data)
+}
+
+// Admit returns an error naming the first rule that the request of the given
+// resolver type with the given params in namespace does not satisfy. Rules
+// that fail to evaluate, for example because they access a missing param,
+// reject the request.
+func (p *AdmissionPolicy) Admit(namespace, resolver string, params []pipelinev1.Param) error {
+	if p == nil || len(p.Rules) == 0 {
+		return nil
+	}
+	paramValues := make(map[string]any, len(params))
+	for _, param := range params {
+		switch param.Value.Type {
+		case pipelinev1.ParamTypeArray:
+			paramValues[param.Name] = param.Value.ArrayVal
+		case pipelinev1.ParamTypeObject:
+			paramValues[param.Name] = param.Value.ObjectVal
+		default:
+			paramValues[param.Name] = param.Value.StringVal
+		}
+	}
+	vars := map[string]any{
+		"request": map[string]any{
+			"namespace": namespace,
+			"resolver":  resolver,
+			"params":    paramValues,
+		},
+	}
+	for _, rule := range p.Rules {
+		out, _, err := rule.program.Eval(vars)
+		if err != nil {
+			return fmt.Errorf("request denied by admission rule %q: %w", rule.Name, err)
+		}
+		if admitted, ok := out.Value().(bool); !ok || !admitted {
+			return fmt.Errorf("request denied by admission rule %q: %s", rule.Name, rule.Expression)
+		}
+	}
+	return nil
+}
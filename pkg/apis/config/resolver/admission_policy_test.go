@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver_test
+
+import (
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config/resolver"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+)
+
+func TestNewAdmissionPolicyFromMapErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		rules       map[string]string
+		expectedErr string
+	}{{
+		name:        "invalid expression",
+		rules:       map[string]string{"broken": `request.namespace ==`},
+		expectedErr: `invalid admission rule "broken"`,
+	}, {
+		name:        "unknown variable",
+		rules:       map[string]string{"unknown": `name == "foo"`},
+		expectedErr: `invalid admission rule "unknown"`,
+	}, {
+		name:        "not a bool",
+		rules:       map[string]string{"int": `size(request.params)`},
+		expectedErr: `invalid admission rule "int": must evaluate to a bool, not int`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := resolver.NewAdmissionPolicyFromMap(tc.rules)
+			if err == nil || len(err.Error()) < len(tc.expectedErr) || err.Error()[:len(tc.expectedErr)] != tc.expectedErr {
+				t.Fatalf("expected error starting with %q but got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestAdmissionPolicyAdmit(t *testing.T) {
+	policy, err := resolver.NewAdmissionPolicyFromConfigMap(test.ConfigMapFromTestFile(t, "admission-policy"))
+	if err != nil {
+		t.Fatalf("unexpected error parsing admission policy: %v", err)
+	}
+	listPolicy, err := resolver.NewAdmissionPolicyFromMap(map[string]string{
+		"known-paths": `!("paths" in request.params) || request.params.paths.all(p, p.startsWith("tasks/"))`,
+		"no-hub":      `request.resolver != "hub"`,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error parsing admission policy: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name        string
+		policy      *resolver.AdmissionPolicy
+		namespace   string
+		resolver    string
+		params      []v1.Param
+		expectedErr string
+	}{{
+		name:      "nil policy",
+		namespace: "restricted",
+		resolver:  "bundles",
+	}, {
+		name:      "empty policy",
+		policy:    &resolver.AdmissionPolicy{},
+		namespace: "restricted",
+		resolver:  "bundles",
+	}, {
+		name:      "other namespace",
+		policy:    policy,
+		namespace: "default",
+		resolver:  "bundles",
+	}, {
+		name:      "allowed git url",
+		policy:    policy,
+		namespace: "restricted",
+		resolver:  "git",
+		params:    []v1.Param{{Name: "url", Value: *v1.NewStructuredValues("https://github.com/our-org/catalog")}},
+	}, {
+		name:        "denied git url",
+		policy:      policy,
+		namespace:   "restricted",
+		resolver:    "git",
+		params:      []v1.Param{{Name: "url", Value: *v1.NewStructuredValues("https://github.com/other/catalog")}},
+		expectedErr: `request denied by admission rule "restricted-git"`,
+	}, {
+		name:        "denied without url",
+		policy:      policy,
+		namespace:   "restricted",
+		resolver:    "git",
+		params:      []v1.Param{{Name: "repo", Value: *v1.NewStructuredValues("catalog")}},
+		expectedErr: `request denied by admission rule "restricted-git"`,
+	}, {
+		name:        "denied resolver",
+		policy:      policy,
+		namespace:   "restricted",
+		resolver:    "bundles",
+		expectedErr: `request denied by admission rule "restricted-git"`,
+	}, {
+		name:      "allowed array param",
+		policy:    listPolicy,
+		namespace: "default",
+		resolver:  "git",
+		params:    []v1.Param{{Name: "paths", Value: *v1.NewStructuredValues("tasks/a.yaml", "tasks/b.yaml")}},
+	}, {
+		name:        "denied array param",
+		policy:      listPolicy,
+		namespace:   "default",
+		resolver:    "git",
+		params:      []v1.Param{{Name: "paths", Value: *v1.NewStructuredValues("tasks/a.yaml", "secrets/b.yaml")}},
+		expectedErr: `request denied by admission rule "known-paths"`,
+	}, {
+		name:        "first denying rule is reported",
+		policy:      listPolicy,
+		namespace:   "default",
+		resolver:    "hub",
+		expectedErr: `request denied by admission rule "no-hub"`,
+	}, {
+		name:        "evaluation error denies",
+		policy:      mustPolicy(t, map[string]string{"needs-url": `request.params.url != ""`}),
+		namespace:   "default",
+		resolver:    "git",
+		expectedErr: `request denied by admission rule "needs-url": no such key: url`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.Admit(tc.namespace, tc.resolver, tc.params)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || len(err.Error()) < len(tc.expectedErr) || err.Error()[:len(tc.expectedErr)] != tc.expectedErr {
+				t.Fatalf("expected error starting with %q but got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func mustPolicy(t *testing.T, rules map[string]string) *resolver.AdmissionPolicy {
+	t.Helper()
+	policy, err := resolver.NewAdmissionPolicyFromMap(rules)
+	if err != nil {
+		t.Fatalf("unexpected error parsing admission policy: %v", err)
+	}
+	return policy
+}
@@ -35,6 +35,10 @@ const (
 	DefaultEnableClusterResolver = true
 	// DefaultEnableHttpResolver is the default value for "enable-http-resolver".
 	DefaultEnableHttpResolver = true
+	// DefaultEnableS3Resolver is the default value for "enable-s3-resolver".
+	DefaultEnableS3Resolver = false
+	// DefaultEnableExternalResolver is the default value for "enable-external-resolver".
+	DefaultEnableExternalResolver = false
 
 	// EnableGitResolver is the flag used to enable the git remote resolver
 	EnableGitResolver = "enable-git-resolver"
@@ -46,16 +50,22 @@ const (
 	EnableClusterResolver = "enable-cluster-resolver"
 	// EnableHttpResolver is the flag used to enable the http remote resolver
 	EnableHttpResolver = "enable-http-resolver"
+	// EnableS3Resolver is the flag used to enable the s3 remote resolver
+	EnableS3Resolver = "enable-s3-resolver"
+	// EnableExternalResolver is the flag used to enable the external remote resolver
+	EnableExternalResolver = "enable-external-resolver"
 )
 
 // FeatureFlags holds the features configurations
 // +k8s:deepcopy-gen=true
 type FeatureFlags struct {
-	EnableGitResolver     bool
-	EnableHubResolver     bool
-	EnableBundleResolver  bool
-	EnableClusterResolver bool
-	EnableHttpResolver    bool
+	EnableGitResolver      bool
+	EnableHubResolver      bool
+	EnableBundleResolver   bool
+	EnableClusterResolver  bool
+	EnableHttpResolver     bool
+	EnableS3Resolver       bool
+	EnableExternalResolver bool
 }
 
 // GetFeatureFlagsConfigName returns the name of the configmap containing all
@@ -98,6 +108,12 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setFeature(EnableHttpResolver, DefaultEnableHttpResolver, &tc.EnableHttpResolver); err != nil {
 		return nil, err
 	}
+	if err := setFeature(EnableS3Resolver, DefaultEnableS3Resolver, &tc.EnableS3Resolver); err != nil {
+		return nil, err
+	}
+	if err := setFeature(EnableExternalResolver, DefaultEnableExternalResolver, &tc.EnableExternalResolver); err != nil {
+		return nil, err
+	}
 	return &tc, nil
 }
 
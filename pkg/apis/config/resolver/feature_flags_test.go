@@ -34,21 +34,25 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 	testCases := []testCase{
 		{
 			expectedConfig: &resolver.FeatureFlags{
-				EnableGitResolver:     true,
-				EnableHubResolver:     true,
-				EnableBundleResolver:  true,
-				EnableClusterResolver: true,
-				EnableHttpResolver:    true,
+				EnableGitResolver:      true,
+				EnableHubResolver:      true,
+				EnableBundleResolver:   true,
+				EnableClusterResolver:  true,
+				EnableHttpResolver:     true,
+				EnableS3Resolver:       false,
+				EnableExternalResolver: false,
 			},
 			fileName: "feature-flags-empty",
 		},
 		{
 			expectedConfig: &resolver.FeatureFlags{
-				EnableGitResolver:     false,
-				EnableHubResolver:     false,
-				EnableBundleResolver:  false,
-				EnableClusterResolver: false,
-				EnableHttpResolver:    false,
+				EnableGitResolver:      false,
+				EnableHubResolver:      false,
+				EnableBundleResolver:   false,
+				EnableClusterResolver:  false,
+				EnableHttpResolver:     false,
+				EnableS3Resolver:       false,
+				EnableExternalResolver: false,
 			},
 			fileName: "feature-flags-all-flags-set",
 		},
@@ -66,11 +70,13 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 func TestNewFeatureFlagsFromEmptyConfigMap(t *testing.T) {
 	FeatureFlagsConfigEmptyName := "feature-flags-empty"
 	expectedConfig := &resolver.FeatureFlags{
-		EnableGitResolver:     resolver.DefaultEnableGitResolver,
-		EnableHubResolver:     resolver.DefaultEnableHubResolver,
-		EnableBundleResolver:  resolver.DefaultEnableBundlesResolver,
-		EnableClusterResolver: resolver.DefaultEnableClusterResolver,
-		EnableHttpResolver:    resolver.DefaultEnableHttpResolver,
+		EnableGitResolver:      resolver.DefaultEnableGitResolver,
+		EnableHubResolver:      resolver.DefaultEnableHubResolver,
+		EnableBundleResolver:   resolver.DefaultEnableBundlesResolver,
+		EnableClusterResolver:  resolver.DefaultEnableClusterResolver,
+		EnableHttpResolver:     resolver.DefaultEnableHttpResolver,
+		EnableS3Resolver:       resolver.DefaultEnableS3Resolver,
+		EnableExternalResolver: resolver.DefaultEnableExternalResolver,
 	}
 	verifyConfigFileWithExpectedFeatureFlagsConfig(t, FeatureFlagsConfigEmptyName, expectedConfig)
 }
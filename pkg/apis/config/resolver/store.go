@@ -21,6 +21,8 @@ package resolver
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/configmap"
 )
 
@@ -29,7 +31,8 @@ type cfgKey struct{}
 // Config holds the collection of configurations that we attach to contexts.
 // +k8s:deepcopy-gen=false
 type Config struct {
-	FeatureFlags *FeatureFlags
+	FeatureFlags    *FeatureFlags
+	AdmissionPolicy *AdmissionPolicy
 }
 
 // ResolversNamespace takes the pipelines namespace and appends "-resolvers" to it.
@@ -54,7 +57,8 @@ func FromContextOrDefaults(ctx context.Context) *Config {
 	}
 	featureFlags, _ := NewFeatureFlagsFromMap(map[string]string{})
 	return &Config{
-		FeatureFlags: featureFlags,
+		FeatureFlags:    featureFlags,
+		AdmissionPolicy: &AdmissionPolicy{},
 	}
 }
 
@@ -68,6 +72,8 @@ func ToContext(ctx context.Context, c *Config) context.Context {
 // +k8s:deepcopy-gen=false
 type Store struct {
 	*configmap.UntypedStore
+	// admission stores the admission policy, whose ConfigMap is optional.
+	admission *configmap.UntypedStore
 }
 
 // NewStore creates a new store of Configs and optionally calls functions when ConfigMaps are updated.
@@ -81,11 +87,42 @@ func NewStore(logger configmap.Logger, onAfterStore ...func(name string, value i
 			},
 			onAfterStore...,
 		),
+		admission: configmap.NewUntypedStore(
+			"admission",
+			logger,
+			configmap.Constructors{
+				GetAdmissionPolicyConfigName(): NewAdmissionPolicyFromConfigMap,
+			},
+			onAfterStore...,
+		),
 	}
 
 	return store
 }
 
+// WatchConfigs uses the provided configmap.Watcher to setup watches for
+// the ConfigMaps of the store. An absent admission policy ConfigMap admits
+// every request, if the watcher supports defaults.
+func (s *Store) WatchConfigs(w configmap.Watcher) {
+	s.UntypedStore.WatchConfigs(w)
+	if dw, ok := w.(configmap.DefaultingWatcher); ok {
+		dw.WatchWithDefault(corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: GetAdmissionPolicyConfigName()},
+		}, s.admission.OnConfigChanged)
+		return
+	}
+	s.admission.WatchConfigs(w)
+}
+
+// OnConfigChanged updates the Config stored for the given ConfigMap.
+func (s *Store) OnConfigChanged(c *corev1.ConfigMap) {
+	if c.Name == GetAdmissionPolicyConfigName() {
+		s.admission.OnConfigChanged(c)
+		return
+	}
+	s.UntypedStore.OnConfigChanged(c)
+}
+
 // ToContext attaches the current Config state to the provided context.
 func (s *Store) ToContext(ctx context.Context) context.Context {
 	return ToContext(ctx, s.Load())
@@ -97,7 +134,14 @@ func (s *Store) Load() *Config {
 	if featureFlags == nil {
 		featureFlags, _ = NewFeatureFlagsFromMap(map[string]string{})
 	}
+	// Admission policies are never modified once loaded, so they are
+	// shared instead of copied.
+	admissionPolicy, _ := s.admission.UntypedLoad(GetAdmissionPolicyConfigName()).(*AdmissionPolicy)
+	if admissionPolicy == nil {
+		admissionPolicy = &AdmissionPolicy{}
+	}
 	return &Config{
-		FeatureFlags: featureFlags.(*FeatureFlags).DeepCopy(),
+		FeatureFlags:    featureFlags.(*FeatureFlags).DeepCopy(),
+		AdmissionPolicy: admissionPolicy,
 	}
 }
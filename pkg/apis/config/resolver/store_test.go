@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tektoncd/pipeline/pkg/apis/config/resolver"
 	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/test/diff"
@@ -28,19 +29,33 @@ import (
 
 func TestStoreLoadWithContext(t *testing.T) {
 	featuresConfig := test.ConfigMapFromTestFile(t, "feature-flags-all-flags-set")
+	admissionConfig := test.ConfigMapFromTestFile(t, "admission-policy")
 
 	expectedFeatures, _ := resolver.NewFeatureFlagsFromConfigMap(featuresConfig)
+	expectedAdmission, _ := resolver.NewAdmissionPolicyFromConfigMap(admissionConfig)
 
 	expected := &resolver.Config{
-		FeatureFlags: expectedFeatures,
+		FeatureFlags:    expectedFeatures,
+		AdmissionPolicy: expectedAdmission,
 	}
 
 	store := resolver.NewStore(logtesting.TestLogger(t))
 	store.OnConfigChanged(featuresConfig)
+	store.OnConfigChanged(admissionConfig)
 
 	cfg := resolver.FromContext(store.ToContext(t.Context()))
 
-	if d := cmp.Diff(expected, cfg); d != "" {
+	if d := cmp.Diff(expected, cfg, cmpopts.IgnoreUnexported(resolver.AdmissionRule{})); d != "" {
 		t.Errorf("Unexpected config %s", diff.PrintWantGot(d))
 	}
 }
+
+func TestStoreLoadWithoutAdmissionPolicy(t *testing.T) {
+	store := resolver.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(test.ConfigMapFromTestFile(t, "feature-flags-all-flags-set"))
+
+	cfg := resolver.FromContext(store.ToContext(t.Context()))
+	if d := cmp.Diff(&resolver.AdmissionPolicy{}, cfg.AdmissionPolicy); d != "" {
+		t.Errorf("Unexpected admission policy %s", diff.PrintWantGot(d))
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	maxRunningTaskRunsKey             = "max-running-taskruns"
+	maxRunningTaskRunsPerNamespaceKey = "max-running-taskruns-per-namespace"
+	namespaceMaxRunningTaskRunsPrefix = "namespace-max-running-taskruns."
+	namespaceWeightPrefix             = "namespace-weight."
+
+	// DefaultNamespaceWeight is the weight of namespaces without a configured weight
+	DefaultNamespaceWeight = 1
+)
+
+// DefaultScheduling holds all the default configurations for scheduling
+var DefaultScheduling, _ = NewSchedulingFromMap(map[string]string{})
+
+// Scheduling holds the limits on the number of TaskRuns running at the same
+// time. A limit of 0 means that the number of TaskRuns is not limited.
+// +k8s:deepcopy-gen=true
+type Scheduling struct {
+	// MaxRunningTaskRuns limits the TaskRuns running in the cluster, which are
+	// shared between the namespaces according to their weights.
+	MaxRunningTaskRuns int
+	// MaxRunningTaskRunsPerNamespace limits the TaskRuns running in each
+	// namespace without a limit of its own.
+	MaxRunningTaskRunsPerNamespace int
+	// NamespaceMaxRunningTaskRuns limits the TaskRuns running in a namespace.
+	NamespaceMaxRunningTaskRuns map[string]int
+	// NamespaceWeights are the weights of the namespaces for the fair share of
+	// MaxRunningTaskRuns.
+	NamespaceWeights map[string]int
+}
+
+// GetSchedulingConfigName returns the name of the configmap containing all
+// customizations for scheduling.
+func GetSchedulingConfigName() string {
+	if e := os.Getenv("CONFIG_SCHEDULING_NAME"); e != "" {
+		return e
+	}
+	return "config-scheduling"
+}
+
+// IsEnabled returns true if the number of running TaskRuns is limited.
+func (cfg *Scheduling) IsEnabled() bool {
+	return cfg.MaxRunningTaskRuns > 0 || cfg.MaxRunningTaskRunsPerNamespace > 0 || len(cfg.NamespaceMaxRunningTaskRuns) > 0
+}
+
+// NamespaceLimit returns the maximum number of TaskRuns running in the
+// namespace, or 0 if it is not limited.
+func (cfg *Scheduling) NamespaceLimit(namespace string) int {
+	if limit, ok := cfg.NamespaceMaxRunningTaskRuns[namespace]; ok {
+		return limit
+	}
+	return cfg.MaxRunningTaskRunsPerNamespace
+}
+
+// NamespaceWeight returns the weight of the namespace for the fair share of
+// MaxRunningTaskRuns.
+func (cfg *Scheduling) NamespaceWeight(namespace string) int {
+	if weight, ok := cfg.NamespaceWeights[namespace]; ok {
+		return weight
+	}
+	return DefaultNamespaceWeight
+}
+
+// Equals returns true if two Configs are identical
+func (cfg *Scheduling) Equals(other *Scheduling) bool {
+	if cfg == nil && other == nil {
+		return true
+	}
+	if cfg == nil || other == nil {
+		return false
+	}
+	return other.MaxRunningTaskRuns == cfg.MaxRunningTaskRuns &&
+		other.MaxRunningTaskRunsPerNamespace == cfg.MaxRunningTaskRunsPerNamespace &&
+		maps.Equal(other.NamespaceMaxRunningTaskRuns, cfg.NamespaceMaxRunningTaskRuns) &&
+		maps.Equal(other.NamespaceWeights, cfg.NamespaceWeights)
+}
+
+// NewSchedulingFromMap returns a Config given a map corresponding to a ConfigMap
+func NewSchedulingFromMap(cfgMap map[string]string) (*Scheduling, error) {
+	s := Scheduling{}
+	for key, value := range cfgMap {
+		var err error
+		switch {
+		case key == maxRunningTaskRunsKey:
+			s.MaxRunningTaskRuns, err = parseTaskRunLimit(key, value)
+		case key == maxRunningTaskRunsPerNamespaceKey:
+			s.MaxRunningTaskRunsPerNamespace, err = parseTaskRunLimit(key, value)
+		case strings.HasPrefix(key, namespaceMaxRunningTaskRunsPrefix):
+			if s.NamespaceMaxRunningTaskRuns == nil {
+				s.NamespaceMaxRunningTaskRuns = map[string]int{}
+			}
+			s.NamespaceMaxRunningTaskRuns[strings.TrimPrefix(key, namespaceMaxRunningTaskRunsPrefix)], err = parseTaskRunLimit(key, value)
+		case strings.HasPrefix(key, namespaceWeightPrefix):
+			weight, perr := strconv.Atoi(value)
+			if perr != nil || weight < 1 {
+				return nil, fmt.Errorf("failed parsing %s %q: must be a positive integer", key, value)
+			}
+			if s.NamespaceWeights == nil {
+				s.NamespaceWeights = map[string]int{}
+			}
+			s.NamespaceWeights[strings.TrimPrefix(key, namespaceWeightPrefix)] = weight
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &s, nil
+}
+
+func parseTaskRunLimit(key, value string) (int, error) {
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		return 0, fmt.Errorf("failed parsing %s %q: must be a non-negative integer", key, value)
+	}
+	return limit, nil
+}
+
+// NewSchedulingFromConfigMap returns a Config for the given configmap
+func NewSchedulingFromConfigMap(config *corev1.ConfigMap) (*Scheduling, error) {
+	return NewSchedulingFromMap(config.Data)
+}
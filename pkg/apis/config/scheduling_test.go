@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewSchedulingFromConfigMap(t *testing.T) {
+	for _, tc := range []struct {
+		description    string
+		expectedConfig *config.Scheduling
+		expectedError  bool
+		fileName       string
+	}{{
+		description: "custom values",
+		expectedConfig: &config.Scheduling{
+			MaxRunningTaskRuns:             100,
+			MaxRunningTaskRunsPerNamespace: 20,
+			NamespaceMaxRunningTaskRuns:    map[string]int{"nightly": 50},
+			NamespaceWeights:               map[string]int{"release": 3},
+		},
+		fileName: config.GetSchedulingConfigName(),
+	}, {
+		description:    "test defaults",
+		expectedConfig: &config.Scheduling{},
+		fileName:       "config-scheduling-empty",
+	}, {
+		description:   "invalid weight",
+		expectedError: true,
+		fileName:      "config-scheduling-error",
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, tc.fileName)
+			scheduling, err := config.NewSchedulingFromConfigMap(cm)
+			if d := cmp.Diff(tc.expectedError, err != nil); d != "" {
+				t.Errorf("Diff(-want,+got):\n%s", d)
+			}
+			if d := cmp.Diff(tc.expectedConfig, scheduling); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestNewSchedulingFromMapInvalidLimits(t *testing.T) {
+	for _, data := range []map[string]string{
+		{"max-running-taskruns": "-1"},
+		{"max-running-taskruns-per-namespace": "many"},
+		{"namespace-max-running-taskruns.nightly": "-5"},
+		{"namespace-weight.release": "heavy"},
+	} {
+		if _, err := config.NewSchedulingFromMap(data); err == nil {
+			t.Errorf("NewSchedulingFromMap(%v) expected an error", data)
+		}
+	}
+}
+
+func TestSchedulingNamespaceLimitAndWeight(t *testing.T) {
+	cfg := &config.Scheduling{
+		MaxRunningTaskRunsPerNamespace: 20,
+		NamespaceMaxRunningTaskRuns:    map[string]int{"nightly": 50, "unlimited": 0},
+		NamespaceWeights:               map[string]int{"release": 3},
+	}
+	for _, tc := range []struct {
+		namespace  string
+		wantLimit  int
+		wantWeight int
+	}{{
+		namespace:  "default",
+		wantLimit:  20,
+		wantWeight: config.DefaultNamespaceWeight,
+	}, {
+		namespace:  "nightly",
+		wantLimit:  50,
+		wantWeight: config.DefaultNamespaceWeight,
+	}, {
+		namespace:  "unlimited",
+		wantLimit:  0,
+		wantWeight: config.DefaultNamespaceWeight,
+	}, {
+		namespace:  "release",
+		wantLimit:  20,
+		wantWeight: 3,
+	}} {
+		t.Run(tc.namespace, func(t *testing.T) {
+			if got := cfg.NamespaceLimit(tc.namespace); got != tc.wantLimit {
+				t.Errorf("NamespaceLimit() = %d, want %d", got, tc.wantLimit)
+			}
+			if got := cfg.NamespaceWeight(tc.namespace); got != tc.wantWeight {
+				t.Errorf("NamespaceWeight() = %d, want %d", got, tc.wantWeight)
+			}
+		})
+	}
+}
+
+func TestSchedulingEquals(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		left     *config.Scheduling
+		right    *config.Scheduling
+		expected bool
+	}{{
+		name:     "left and right nil",
+		expected: true,
+	}, {
+		name:     "left nil",
+		right:    &config.Scheduling{},
+		expected: false,
+	}, {
+		name:     "right nil",
+		left:     &config.Scheduling{},
+		expected: false,
+	}, {
+		name:     "different limits",
+		left:     &config.Scheduling{MaxRunningTaskRuns: 10},
+		right:    &config.Scheduling{MaxRunningTaskRuns: 20},
+		expected: false,
+	}, {
+		name:     "different weights",
+		left:     &config.Scheduling{NamespaceWeights: map[string]int{"release": 2}},
+		right:    &config.Scheduling{NamespaceWeights: map[string]int{"release": 3}},
+		expected: false,
+	}, {
+		name: "same all fields",
+		left: &config.Scheduling{
+			MaxRunningTaskRuns:          10,
+			NamespaceMaxRunningTaskRuns: map[string]int{"nightly": 5},
+			NamespaceWeights:            map[string]int{"release": 2},
+		},
+		right: &config.Scheduling{
+			MaxRunningTaskRuns:          10,
+			NamespaceMaxRunningTaskRuns: map[string]int{"nightly": 5},
+			NamespaceWeights:            map[string]int{"release": 2},
+		},
+		expected: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.left.Equals(tc.right); got != tc.expected {
+				t.Errorf("Comparison failed expected: %t, actual: %t", tc.expected, got)
+			}
+		})
+	}
+}
@@ -34,6 +34,7 @@ func TestStoreLoadWithContext(t *testing.T) {
 	eventsConfig := test.ConfigMapFromTestFile(t, "config-events")
 	tracingConfig := test.ConfigMapFromTestFile(t, "config-tracing")
 	waitExponentialBackoffConfig := test.ConfigMapFromTestFile(t, "config-wait-exponential-backoff")
+	schedulingConfig := test.ConfigMapFromTestFile(t, "config-scheduling")
 
 	expectedDefaults, _ := config.NewDefaultsFromConfigMap(defaultConfig)
 	expectedFeatures, _ := config.NewFeatureFlagsFromConfigMap(featuresConfig)
@@ -42,6 +43,7 @@ func TestStoreLoadWithContext(t *testing.T) {
 	expectedEventsConfig, _ := config.NewEventsFromConfigMap(eventsConfig)
 	expectedTracingConfig, _ := config.NewTracingFromConfigMap(tracingConfig)
 	expectedWaitExponentialBackoffConfig, _ := config.NewWaitExponentialBackoffFromConfigMap(waitExponentialBackoffConfig)
+	expectedSchedulingConfig, _ := config.NewSchedulingFromConfigMap(schedulingConfig)
 
 	expected := &config.Config{
 		Defaults:               expectedDefaults,
@@ -51,6 +53,7 @@ func TestStoreLoadWithContext(t *testing.T) {
 		Events:                 expectedEventsConfig,
 		Tracing:                expectedTracingConfig,
 		WaitExponentialBackoff: expectedWaitExponentialBackoffConfig,
+		Scheduling:             expectedSchedulingConfig,
 	}
 
 	store := config.NewStore(logtesting.TestLogger(t))
@@ -61,6 +64,7 @@ func TestStoreLoadWithContext(t *testing.T) {
 	store.OnConfigChanged(eventsConfig)
 	store.OnConfigChanged(tracingConfig)
 	store.OnConfigChanged(waitExponentialBackoffConfig)
+	store.OnConfigChanged(schedulingConfig)
 
 	cfg := config.FromContext(store.ToContext(t.Context()))
 
@@ -78,6 +82,7 @@ func TestStoreLoadWithContext_Empty(t *testing.T) {
 		Events:                 config.DefaultEvents.DeepCopy(),
 		Tracing:                config.DefaultTracing.DeepCopy(),
 		WaitExponentialBackoff: config.DefaultWaitExponentialBackoff.DeepCopy(),
+		Scheduling:             config.DefaultScheduling.DeepCopy(),
 	}
 
 	store := config.NewStore(logtesting.TestLogger(t))
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"strings"
+)
+
+// ConcurrencyStrategy is what happens to a PipelineRun when the maximum
+// number of PipelineRuns with its concurrency key are already running.
+type ConcurrencyStrategy string
+
+const (
+	// ConcurrencyStrategyQueue holds the PipelineRun until one of the running
+	// PipelineRuns completes.
+	ConcurrencyStrategyQueue ConcurrencyStrategy = "queue"
+	// ConcurrencyStrategyCancelOldest cancels the running PipelineRuns that
+	// started first and starts the PipelineRun.
+	ConcurrencyStrategyCancelOldest ConcurrencyStrategy = "cancel-oldest"
+	// ConcurrencyStrategyCancelNewest cancels the PipelineRun instead of
+	// starting it.
+	ConcurrencyStrategyCancelNewest ConcurrencyStrategy = "cancel-newest"
+)
+
+// defaultConcurrencyMaxParallel is the MaxParallel of a PipelineRunConcurrency
+// without one.
+const defaultConcurrencyMaxParallel = 1
+
+// PipelineRunConcurrency limits how many PipelineRuns sharing a concurrency
+// key run at the same time in a namespace.
+type PipelineRunConcurrency struct {
+	// Key groups the PipelineRuns limited together. It may reference the
+	// params of the PipelineRun with $(params.<name>) and its labels with
+	// $(labels.<name>), e.g. "deploy-$(params.environment)".
+	Key string `json:"key"`
+	// MaxParallel is the maximum number of PipelineRuns with the same key
+	// running at the same time. Defaults to 1.
+	// +optional
+	MaxParallel int `json:"maxParallel,omitempty"`
+	// Strategy is what happens to a PipelineRun when MaxParallel PipelineRuns
+	// with its key are already running: "queue" (the default), "cancel-oldest"
+	// or "cancel-newest".
+	// +optional
+	Strategy ConcurrencyStrategy `json:"strategy,omitempty"`
+}
+
+// GetMaxParallel returns the maximum number of PipelineRuns with the same key
+// running at the same time.
+func (c *PipelineRunConcurrency) GetMaxParallel() int {
+	if c.MaxParallel == 0 {
+		return defaultConcurrencyMaxParallel
+	}
+	return c.MaxParallel
+}
+
+// GetStrategy returns the strategy of the concurrency limit.
+func (c *PipelineRunConcurrency) GetStrategy() ConcurrencyStrategy {
+	if c.Strategy == "" {
+		return ConcurrencyStrategyQueue
+	}
+	return c.Strategy
+}
+
+// ConcurrencyKey returns the concurrency key of the PipelineRun with its
+// params and labels substituted, or an empty string if it has no concurrency
+// limit.
+func (pr *PipelineRun) ConcurrencyKey() string {
+	if pr.Spec.Concurrency == nil {
+		return ""
+	}
+	replacements := make([]string, 0, 2*(len(pr.Spec.Params)+len(pr.Labels)))
+	for _, p := range pr.Spec.Params {
+		if p.Value.Type == ParamTypeString {
+			replacements = append(replacements, "$(params."+p.Name+")", p.Value.StringVal)
+		}
+	}
+	for k, v := range pr.Labels {
+		replacements = append(replacements, "$(labels."+k+")", v)
+	}
+	return strings.NewReplacer(replacements...).Replace(pr.Spec.Concurrency.Key)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"testing"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPipelineRunConcurrencyKey(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		concurrency *v1.PipelineRunConcurrency
+		want        string
+	}{{
+		name: "no concurrency",
+		want: "",
+	}, {
+		name:        "constant key",
+		concurrency: &v1.PipelineRunConcurrency{Key: "deploy"},
+		want:        "deploy",
+	}, {
+		name:        "params and labels",
+		concurrency: &v1.PipelineRunConcurrency{Key: "deploy-$(params.environment)-$(labels.app)"},
+		want:        "deploy-prod-shop",
+	}, {
+		name:        "array params are not substituted",
+		concurrency: &v1.PipelineRunConcurrency{Key: "deploy-$(params.regions)"},
+		want:        "deploy-$(params.regions)",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "shop"}},
+				Spec: v1.PipelineRunSpec{
+					Params: v1.Params{{
+						Name: "environment", Value: *v1.NewStructuredValues("prod"),
+					}, {
+						Name: "regions", Value: *v1.NewStructuredValues("eu", "us"),
+					}},
+					Concurrency: tc.concurrency,
+				},
+			}
+			if got := pr.ConcurrencyKey(); got != tc.want {
+				t.Errorf("ConcurrencyKey() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPipelineRunConcurrencyDefaults(t *testing.T) {
+	c := &v1.PipelineRunConcurrency{Key: "deploy"}
+	if got := c.GetMaxParallel(); got != 1 {
+		t.Errorf("GetMaxParallel() = %d, want 1", got)
+	}
+	if got := c.GetStrategy(); got != v1.ConcurrencyStrategyQueue {
+		t.Errorf("GetStrategy() = %q, want %q", got, v1.ConcurrencyStrategyQueue)
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+// concurrencyKeyReference matches the variables referenced by a concurrency key.
+var concurrencyKeyReference = regexp.MustCompile(`\$\(([^)]*)\)`)
+
+// Validate validates the concurrency limit of a PipelineRun with params of the
+// given names.
+func (c *PipelineRunConcurrency) Validate(ctx context.Context, paramNames sets.String) (errs *apis.FieldError) {
+	if c == nil {
+		return nil
+	}
+	errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "concurrency", config.AlphaAPIFields))
+	if c.Key == "" {
+		errs = errs.Also(apis.ErrMissingField("key"))
+	}
+	for _, match := range concurrencyKeyReference.FindAllStringSubmatch(c.Key, -1) {
+		switch variable := match[1]; {
+		case strings.HasPrefix(variable, "params."):
+			if name := strings.TrimPrefix(variable, "params."); !paramNames.Has(name) {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%q references the param %q, which is not provided by the PipelineRun", c.Key, name), "key"))
+			}
+		case strings.HasPrefix(variable, "labels.") && variable != "labels.":
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%q may only reference $(params.<name>) and $(labels.<name>), not %q", c.Key, match[0]), "key"))
+		}
+	}
+	if c.MaxParallel < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 1", c.MaxParallel), "maxParallel"))
+	}
+	switch c.Strategy {
+	case "", ConcurrencyStrategyQueue, ConcurrencyStrategyCancelOldest, ConcurrencyStrategyCancelNewest:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %s, %s or %s", c.Strategy,
+			ConcurrencyStrategyQueue, ConcurrencyStrategyCancelOldest, ConcurrencyStrategyCancelNewest), "strategy"))
+	}
+	return errs
+}
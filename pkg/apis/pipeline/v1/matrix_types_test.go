@@ -19,6 +19,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/test/diff"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestMatrix_FanOut(t *testing.T) {
@@ -934,3 +935,39 @@ func TestPipelineTask_CountCombinations(t *testing.T) {
 		})
 	}
 }
+
+func TestMatrixStrategy_AllowedFailures(t *testing.T) {
+	oneFailure := intstr.FromInt32(1)
+	quarterFailures := intstr.FromString("25%")
+	invalidFailures := intstr.FromString("half")
+	for _, tc := range []struct {
+		name     string
+		strategy *v1.MatrixStrategy
+		want     int
+	}{{
+		name: "no strategy",
+		want: 0,
+	}, {
+		name:     "no allowed failures",
+		strategy: &v1.MatrixStrategy{FailFast: true},
+		want:     0,
+	}, {
+		name:     "number",
+		strategy: &v1.MatrixStrategy{AllowFailures: &oneFailure},
+		want:     1,
+	}, {
+		name:     "percentage rounded down",
+		strategy: &v1.MatrixStrategy{AllowFailures: &quarterFailures},
+		want:     2,
+	}, {
+		name:     "invalid percentage",
+		strategy: &v1.MatrixStrategy{AllowFailures: &invalidFailures},
+		want:     0,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.strategy.AllowedFailures(10); got != tc.want {
+				t.Errorf("AllowedFailures(10) = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
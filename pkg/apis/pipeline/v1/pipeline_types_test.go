@@ -452,6 +452,13 @@ func TestPipelineTask_ValidateCustomTask(t *testing.T) {
 			Message: `invalid value: custom task ref must specify kind`,
 			Paths:   []string{"taskRef.kind"},
 		},
+	}, {
+		name: "custom task - retryPolicy",
+		task: PipelineTask{Name: "foo", TaskRef: &TaskRef{APIVersion: "example/v0", Kind: "Example"}, Retries: 1, RetryPolicy: &RetryPolicy{}},
+		expectedError: apis.FieldError{
+			Message: `retryPolicy is not supported for Custom Tasks`,
+			Paths:   []string{"retryPolicy"},
+		},
 	}, {
 		name: "custom task - taskSpec without kind",
 		task: PipelineTask{Name: "foo", TaskSpec: &EmbeddedTask{
@@ -620,6 +627,17 @@ func TestPipelineTask_ValidateRegularTask_Success(t *testing.T) {
 			TaskRef: &TaskRef{ResolverRef: ResolverRef{Resolver: "bar", Params: Params{{}}}},
 		},
 		configMap: map[string]string{"enable-api-field": "beta"},
+	}, {
+		name: "pipeline task - retryPolicy",
+		tasks: PipelineTask{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Retries: 2,
+			RetryPolicy: &RetryPolicy{RetryOn: []RetryCondition{{
+				Reasons: []string{TaskRunReasonPodEvicted.String()},
+			}}},
+		},
+		configMap: map[string]string{"enable-api-fields": "alpha"},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -721,6 +739,18 @@ func TestPipelineTask_ValidateRegularTask_Failure(t *testing.T) {
 			Paths:   []string{"taskRef.name", "taskRef.params"},
 		},
 		configMap: map[string]string{"enable-concise-resolver-syntax": "true"},
+	}, {
+		name: "pipeline task - retryPolicy without retries",
+		task: PipelineTask{
+			Name:        "foo",
+			TaskRef:     &TaskRef{Name: "foo-task"},
+			RetryPolicy: &RetryPolicy{},
+		},
+		expectedError: apis.FieldError{
+			Message: `retryPolicy requires retries to be greater than 0`,
+			Paths:   []string{"retryPolicy"},
+		},
+		configMap: map[string]string{"enable-api-fields": "alpha"},
 	}, {
 		name: "pipeline task - taskRef with resolver params but no resolver",
 		task: PipelineTask{
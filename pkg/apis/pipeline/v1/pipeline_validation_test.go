@@ -28,6 +28,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 )
@@ -4164,6 +4165,15 @@ func TestMatrixIncompatibleAPIVersions(t *testing.T) {
 			}},
 		},
 	}
+	maxParallelTask := *task.DeepCopy()
+	maxParallelTask.Matrix.MaxParallel = 1
+	negativeMaxParallelTask := *task.DeepCopy()
+	negativeMaxParallelTask.Matrix.MaxParallel = -1
+	strategyTask := func(allowFailures intstr.IntOrString) PipelineTask {
+		pt := *task.DeepCopy()
+		pt.Matrix.Strategy = &MatrixStrategy{FailFast: true, AllowFailures: &allowFailures}
+		return pt
+	}
 	tests := []struct {
 		name    string
 		pt      PipelineTask
@@ -4182,6 +4192,44 @@ func TestMatrixIncompatibleAPIVersions(t *testing.T) {
 		pt:      task,
 		version: config.StableAPIFields,
 		wantErr: apis.ErrGeneric("matrix requires \"enable-api-fields\" feature gate to be \"alpha\" or \"beta\" but it is \"stable\""),
+	}, {
+		name:    "matrix maxParallel can work with alpha",
+		pt:      maxParallelTask,
+		version: config.AlphaAPIFields,
+	}, {
+		name:    "matrix maxParallel requires alpha",
+		pt:      maxParallelTask,
+		version: config.BetaAPIFields,
+		wantErr: apis.ErrGeneric("matrix.maxParallel requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"beta\""),
+	}, {
+		name:    "matrix maxParallel must not be negative",
+		pt:      negativeMaxParallelTask,
+		version: config.AlphaAPIFields,
+		wantErr: apis.ErrInvalidValue(-1, "matrix.maxParallel", "must not be negative"),
+	}, {
+		name:    "matrix strategy can work with alpha",
+		pt:      strategyTask(intstr.FromString("25%")),
+		version: config.AlphaAPIFields,
+	}, {
+		name:    "matrix strategy requires alpha",
+		pt:      strategyTask(intstr.FromInt32(1)),
+		version: config.BetaAPIFields,
+		wantErr: apis.ErrGeneric("matrix.strategy requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"beta\""),
+	}, {
+		name:    "matrix strategy allowFailures must not be negative",
+		pt:      strategyTask(intstr.FromInt32(-1)),
+		version: config.AlphaAPIFields,
+		wantErr: apis.ErrInvalidValue(-1, "matrix.strategy.allowFailures", "must not be negative"),
+	}, {
+		name:    "matrix strategy allowFailures must be a percentage",
+		pt:      strategyTask(intstr.FromString("half")),
+		version: config.AlphaAPIFields,
+		wantErr: apis.ErrInvalidValue("half", "matrix.strategy.allowFailures", "must be a number or a percentage between 0% and 100%"),
+	}, {
+		name:    "matrix strategy allowFailures percentage must not exceed 100%",
+		pt:      strategyTask(intstr.FromString("150%")),
+		version: config.AlphaAPIFields,
+		wantErr: apis.ErrInvalidValue("150%", "matrix.strategy.allowFailures", "must be a number or a percentage between 0% and 100%"),
 	}}
 
 	for _, test := range tests {
@@ -293,6 +293,10 @@ type PipelineRunSpec struct {
 	// +optional
 	// +listType=atomic
 	TaskRunSpecs []PipelineTaskRunSpec `json:"taskRunSpecs,omitempty"`
+	// Concurrency limits how many PipelineRuns sharing a concurrency key
+	// run at the same time.
+	// +optional
+	Concurrency *PipelineRunConcurrency `json:"concurrency,omitempty"`
 	// ManagedBy indicates which controller is responsible for reconciling
 	// this resource. If unset or set to "tekton.dev/pipeline", the default
 	// Tekton controller will manage this resource.
@@ -361,6 +365,9 @@ const (
 	PipelineRunReasonCancelled PipelineRunReason = "Cancelled"
 	// PipelineRunReasonPending is the reason set when the PipelineRun is in the pending state
 	PipelineRunReasonPending PipelineRunReason = "PipelineRunPending"
+	// PipelineRunReasonQueued is the reason set when the PipelineRun is held in the pending
+	// state until fewer PipelineRuns with the same concurrency key are running
+	PipelineRunReasonQueued PipelineRunReason = "PipelineRunQueued"
 	// PipelineRunReasonTimedOut is the reason set when the PipelineRun has timed out
 	PipelineRunReasonTimedOut PipelineRunReason = "PipelineRunTimeout"
 	// PipelineRunReasonStopping indicates that no new Tasks will be scheduled by the controller, and the
@@ -515,6 +522,11 @@ type ChildStatusReference struct {
 	// +optional
 	// +listType=atomic
 	WhenExpressions []WhenExpression `json:"whenExpressions,omitempty"`
+
+	// Reason is the reason of the Succeeded condition of the TaskRun once it is done.
+	// It is only set for the TaskRuns of a PipelineTask whose Matrix has a strategy.
+	// +optional
+	Reason string `json:"reason,omitempty"`
 }
 
 // PipelineRunStatusFields holds the fields of PipelineRunStatus' status.
@@ -110,6 +110,7 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 		errs = errs.Also(validateTaskRunSpec(ctx, trs, ps.Timeouts).ViaIndex(idx).ViaField("taskRunSpecs"))
 	}
 	errs = errs.Also(validateSpecStatus(ps.Status))
+	errs = errs.Also(ps.Concurrency.Validate(ctx, ps.Params.ExtractNames()).ViaField("concurrency"))
 
 	if ps.Workspaces != nil {
 		wsNames := make(map[string]int)
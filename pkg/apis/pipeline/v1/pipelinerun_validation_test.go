@@ -1119,6 +1119,50 @@ func TestPipelineRunSpec_Invalidate(t *testing.T) {
 		},
 		withContext: cfgtesting.EnableStableAPIFields,
 		wantErr:     apis.ErrGeneric("computeResources requires \"enable-api-fields\" feature gate to be \"alpha\" or \"beta\" but it is \"stable\"").ViaIndex(0).ViaField("taskRunSpecs"),
+	}, {
+		name: "concurrency disallowed without alpha feature gate",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "foo"},
+			Concurrency: &v1.PipelineRunConcurrency{Key: "deploy"},
+		},
+		withContext: cfgtesting.EnableBetaAPIFields,
+		wantErr:     apis.ErrGeneric("concurrency requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"beta\"").ViaField("concurrency"),
+	}, {
+		name: "concurrency without key",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "foo"},
+			Concurrency: &v1.PipelineRunConcurrency{MaxParallel: 2},
+		},
+		withContext: cfgtesting.EnableAlphaAPIFields,
+		wantErr:     apis.ErrMissingField("concurrency.key"),
+	}, {
+		name: "concurrency key referencing a missing param",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "foo"},
+			Params: v1.Params{{
+				Name: "environment", Value: *v1.NewStructuredValues("prod"),
+			}},
+			Concurrency: &v1.PipelineRunConcurrency{Key: "deploy-$(params.target)"},
+		},
+		withContext: cfgtesting.EnableAlphaAPIFields,
+		wantErr:     apis.ErrInvalidValue(`"deploy-$(params.target)" references the param "target", which is not provided by the PipelineRun`, "concurrency.key"),
+	}, {
+		name: "concurrency key referencing other variables",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "foo"},
+			Concurrency: &v1.PipelineRunConcurrency{Key: "deploy-$(context.pipelineRun.name)"},
+		},
+		withContext: cfgtesting.EnableAlphaAPIFields,
+		wantErr:     apis.ErrInvalidValue(`"deploy-$(context.pipelineRun.name)" may only reference $(params.<name>) and $(labels.<name>), not "$(context.pipelineRun.name)"`, "concurrency.key"),
+	}, {
+		name: "concurrency with negative maxParallel and unknown strategy",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "foo"},
+			Concurrency: &v1.PipelineRunConcurrency{Key: "deploy", MaxParallel: -1, Strategy: "cancel-all"},
+		},
+		withContext: cfgtesting.EnableAlphaAPIFields,
+		wantErr: apis.ErrInvalidValue("-1 should be >= 1", "concurrency.maxParallel").Also(
+			apis.ErrInvalidValue("cancel-all should be queue, cancel-oldest or cancel-newest", "concurrency.strategy")),
 	}}
 
 	for _, ps := range tests {
@@ -1190,6 +1234,20 @@ func TestPipelineRunSpec_Validate(t *testing.T) {
 			}},
 		},
 		withContext: cfgtesting.EnableBetaAPIFields,
+	}, {
+		name: "valid concurrency",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "pipeline"},
+			Params: v1.Params{{
+				Name: "environment", Value: *v1.NewStructuredValues("prod"),
+			}},
+			Concurrency: &v1.PipelineRunConcurrency{
+				Key:         "deploy-$(params.environment)-$(labels.app)",
+				MaxParallel: 2,
+				Strategy:    v1.ConcurrencyStrategyCancelOldest,
+			},
+		},
+		withContext: cfgtesting.EnableAlphaAPIFields,
 	}}
 
 	for _, ps := range tests {
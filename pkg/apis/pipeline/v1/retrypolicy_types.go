@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// defaultRetryBackoffFactor is the factor of a RetryBackoff without one.
+const defaultRetryBackoffFactor = 2
+
+// RetryPolicy configures how soon and on which failures a TaskRun is
+// retried. It only applies to TaskRuns with retries.
+type RetryPolicy struct {
+	// Backoff delays the retries of failed attempts exponentially. Failed
+	// attempts are retried immediately when it is unset.
+	// +optional
+	Backoff *RetryBackoff `json:"backoff,omitempty"`
+	// RetryOn restricts retries to the failed attempts matching at least
+	// one of the conditions. Every failed attempt is retried when it is
+	// empty.
+	// +optional
+	// +listType=atomic
+	RetryOn []RetryCondition `json:"retryOn,omitempty"`
+}
+
+// RetryBackoff is an exponential backoff between the attempts of a TaskRun.
+type RetryBackoff struct {
+	// Delay is the time to wait before the first retry.
+	Delay *metav1.Duration `json:"delay"`
+	// Factor multiplies the delay after every retry. Defaults to 2.
+	// +optional
+	Factor int `json:"factor,omitempty"`
+	// MaxDelay is the longest time to wait between two attempts.
+	// +optional
+	MaxDelay *metav1.Duration `json:"maxDelay,omitempty"`
+}
+
+// RetryCondition matches the failed attempts of a TaskRun. An attempt
+// matches the condition if it matches all of its fields.
+type RetryCondition struct {
+	// Reasons matches attempts whose Succeeded condition has one of these
+	// reasons, such as TaskRunImagePullFailed or TaskRunPodEvicted.
+	// +optional
+	// +listType=atomic
+	Reasons []string `json:"reasons,omitempty"`
+	// ExitCodes matches attempts with a step that exited with one of these
+	// exit codes.
+	// +optional
+	// +listType=atomic
+	ExitCodes []int32 `json:"exitCodes,omitempty"`
+	// TerminationReasons matches attempts with a step that terminated with
+	// one of these reasons, such as OOMKilled or TimeoutExceeded.
+	// +optional
+	// +listType=atomic
+	TerminationReasons []string `json:"terminationReasons,omitempty"`
+}
+
+// ShouldRetry returns true if the failed attempt with the given status
+// matches one of the retry conditions of the policy.
+func (rp *RetryPolicy) ShouldRetry(status *TaskRunStatus) bool {
+	if rp == nil || len(rp.RetryOn) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(rp.RetryOn, func(rc RetryCondition) bool {
+		return rc.matches(status)
+	})
+}
+
+// Delay returns how long to wait before retrying a TaskRun whose given
+// number of attempts failed.
+func (rp *RetryPolicy) Delay(attempts int) time.Duration {
+	if rp == nil || rp.Backoff == nil || rp.Backoff.Delay == nil {
+		return 0
+	}
+	factor := rp.Backoff.Factor
+	if factor == 0 {
+		factor = defaultRetryBackoffFactor
+	}
+	maxDelay := time.Duration(0)
+	if rp.Backoff.MaxDelay != nil {
+		maxDelay = rp.Backoff.MaxDelay.Duration
+	}
+	d := rp.Backoff.Delay.Duration
+	for i := 1; i < attempts && factor > 1; i++ {
+		if maxDelay > 0 && d >= maxDelay {
+			break
+		}
+		d *= time.Duration(factor)
+	}
+	if maxDelay > 0 && d > maxDelay {
+		d = maxDelay
+	}
+	return d
+}
+
+func (rc RetryCondition) matches(status *TaskRunStatus) bool {
+	if len(rc.Reasons) > 0 {
+		cond := status.GetCondition(apis.ConditionSucceeded)
+		if cond == nil || !slices.Contains(rc.Reasons, cond.Reason) {
+			return false
+		}
+	}
+	if len(rc.ExitCodes) > 0 && !slices.ContainsFunc(status.Steps, func(s StepState) bool {
+		return s.Terminated != nil && slices.Contains(rc.ExitCodes, s.Terminated.ExitCode)
+	}) {
+		return false
+	}
+	if len(rc.TerminationReasons) > 0 && !slices.ContainsFunc(status.Steps, func(s StepState) bool {
+		if s.Terminated != nil && slices.Contains(rc.TerminationReasons, s.Terminated.Reason) {
+			return true
+		}
+		return slices.Contains(rc.TerminationReasons, s.TerminationReason)
+	}) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	status := v1.TaskRunStatus{
+		TaskRunStatusFields: v1.TaskRunStatusFields{
+			Steps: []v1.StepState{{
+				Name: "build",
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"},
+				},
+			}, {
+				Name:              "test",
+				TerminationReason: "TimeoutExceeded",
+			}},
+		},
+	}
+	status.SetCondition(&apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionFalse,
+		Reason: v1.TaskRunReasonPodEvicted.String(),
+	})
+
+	for _, tc := range []struct {
+		name   string
+		policy *v1.RetryPolicy
+		want   bool
+	}{{
+		name: "no policy",
+		want: true,
+	}, {
+		name:   "no conditions",
+		policy: &v1.RetryPolicy{},
+		want:   true,
+	}, {
+		name: "matching reason",
+		policy: &v1.RetryPolicy{RetryOn: []v1.RetryCondition{{
+			Reasons: []string{v1.TaskRunReasonImagePullFailed.String(), v1.TaskRunReasonPodEvicted.String()},
+		}}},
+		want: true,
+	}, {
+		name: "other reason",
+		policy: &v1.RetryPolicy{RetryOn: []v1.RetryCondition{{
+			Reasons: []string{v1.TaskRunReasonImagePullFailed.String()},
+		}}},
+		want: false,
+	}, {
+		name: "matching exit code",
+		policy: &v1.RetryPolicy{RetryOn: []v1.RetryCondition{{
+			ExitCodes: []int32{137},
+		}}},
+		want: true,
+	}, {
+		name: "matching container termination reason",
+		policy: &v1.RetryPolicy{RetryOn: []v1.RetryCondition{{
+			TerminationReasons: []string{"OOMKilled"},
+		}}},
+		want: true,
+	}, {
+		name: "matching step termination reason",
+		policy: &v1.RetryPolicy{RetryOn: []v1.RetryCondition{{
+			TerminationReasons: []string{"TimeoutExceeded"},
+		}}},
+		want: true,
+	}, {
+		name: "condition must match all of its fields",
+		policy: &v1.RetryPolicy{RetryOn: []v1.RetryCondition{{
+			Reasons:   []string{v1.TaskRunReasonPodEvicted.String()},
+			ExitCodes: []int32{1},
+		}}},
+		want: false,
+	}, {
+		name: "any condition may match",
+		policy: &v1.RetryPolicy{RetryOn: []v1.RetryCondition{{
+			ExitCodes: []int32{1},
+		}, {
+			ExitCodes: []int32{137},
+		}}},
+		want: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.policy.ShouldRetry(&status); got != tc.want {
+				t.Errorf("ShouldRetry() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		policy   *v1.RetryPolicy
+		attempts int
+		want     time.Duration
+	}{{
+		name:     "no policy",
+		attempts: 1,
+		want:     0,
+	}, {
+		name:     "no backoff",
+		policy:   &v1.RetryPolicy{},
+		attempts: 1,
+		want:     0,
+	}, {
+		name: "first retry",
+		policy: &v1.RetryPolicy{Backoff: &v1.RetryBackoff{
+			Delay: &metav1.Duration{Duration: 10 * time.Second},
+		}},
+		attempts: 1,
+		want:     10 * time.Second,
+	}, {
+		name: "default factor",
+		policy: &v1.RetryPolicy{Backoff: &v1.RetryBackoff{
+			Delay: &metav1.Duration{Duration: 10 * time.Second},
+		}},
+		attempts: 3,
+		want:     40 * time.Second,
+	}, {
+		name: "factor",
+		policy: &v1.RetryPolicy{Backoff: &v1.RetryBackoff{
+			Delay:  &metav1.Duration{Duration: 10 * time.Second},
+			Factor: 3,
+		}},
+		attempts: 3,
+		want:     90 * time.Second,
+	}, {
+		name: "constant delay",
+		policy: &v1.RetryPolicy{Backoff: &v1.RetryBackoff{
+			Delay:  &metav1.Duration{Duration: 10 * time.Second},
+			Factor: 1,
+		}},
+		attempts: 5,
+		want:     10 * time.Second,
+	}, {
+		name: "capped by maxDelay",
+		policy: &v1.RetryPolicy{Backoff: &v1.RetryBackoff{
+			Delay:    &metav1.Duration{Duration: 10 * time.Second},
+			MaxDelay: &metav1.Duration{Duration: time.Minute},
+		}},
+		attempts: 10,
+		want:     time.Minute,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.policy.Delay(tc.attempts); got != tc.want {
+				t.Errorf("Delay(%d) = %s, want %s", tc.attempts, got, tc.want)
+			}
+		})
+	}
+}
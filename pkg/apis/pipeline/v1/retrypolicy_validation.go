@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"knative.dev/pkg/apis"
+)
+
+// Validate validates the retry policy of a PipelineTask or TaskRun with the
+// given number of retries.
+func (rp *RetryPolicy) Validate(ctx context.Context, retries int) (errs *apis.FieldError) {
+	if rp == nil {
+		return nil
+	}
+	errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "retryPolicy", config.AlphaAPIFields))
+	if retries <= 0 {
+		errs = errs.Also(apis.ErrGeneric("retryPolicy requires retries to be greater than 0", apis.CurrentField))
+	}
+	if b := rp.Backoff; b != nil {
+		switch {
+		case b.Delay == nil:
+			errs = errs.Also(apis.ErrMissingField("backoff.delay"))
+		case b.Delay.Duration <= 0:
+			errs = errs.Also(apis.ErrInvalidValue(b.Delay.Duration.String()+" should be > 0", "backoff.delay"))
+		case b.MaxDelay != nil && b.MaxDelay.Duration < b.Delay.Duration:
+			errs = errs.Also(apis.ErrInvalidValue(b.MaxDelay.Duration.String()+" should be >= backoff.delay", "backoff.maxDelay"))
+		}
+		if b.Factor < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 1", b.Factor), "backoff.factor"))
+		}
+	}
+	for i, rc := range rp.RetryOn {
+		if len(rc.Reasons) == 0 && len(rc.ExitCodes) == 0 && len(rc.TerminationReasons) == 0 {
+			errs = errs.Also(apis.ErrMissingOneOf("reasons", "exitCodes", "terminationReasons").ViaFieldIndex("retryOn", i))
+		}
+	}
+	return errs
+}
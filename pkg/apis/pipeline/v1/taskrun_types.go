@@ -59,6 +59,9 @@ type TaskRunSpec struct {
 	// Retries represents how many times this TaskRun should be retried in the event of task failure.
 	// +optional
 	Retries int `json:"retries,omitempty"`
+	// RetryPolicy configures how soon and on which failures this TaskRun is retried.
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
 	// Time after which one retry attempt times out. Defaults to 1 hour.
 	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
 	// +optional
@@ -111,6 +114,9 @@ const (
 	TaskRunCancelledByPipelineMsg TaskRunSpecStatusMessage = "TaskRun cancelled as the PipelineRun it belongs to has been cancelled."
 	// TaskRunCancelledByPipelineTimeoutMsg indicates that the TaskRun was cancelled because the PipelineRun running it timed out.
 	TaskRunCancelledByPipelineTimeoutMsg TaskRunSpecStatusMessage = "TaskRun cancelled as the PipelineRun it belongs to has timed out."
+	// TaskRunCancelledByMatrixFailFastMsg indicates that the TaskRun was cancelled because other TaskRuns
+	// of its Matrix failed and the Matrix strategy is to fail fast.
+	TaskRunCancelledByMatrixFailFastMsg TaskRunSpecStatusMessage = "TaskRun cancelled as other TaskRuns of its Matrix failed."
 )
 
 const (
@@ -204,6 +210,9 @@ const (
 	TaskRunReasonCancelled TaskRunReason = "TaskRunCancelled"
 	// TaskRunReasonTimedOut is the reason set when one TaskRun execution has timed out
 	TaskRunReasonTimedOut TaskRunReason = "TaskRunTimeout"
+	// TaskRunReasonPending is the reason set when the TaskRun waits for the limit
+	// on the TaskRuns running in its namespace or in the cluster
+	TaskRunReasonPending TaskRunReason = "TaskRunPending"
 	// TaskRunReasonResolvingTaskRef indicates that the TaskRun is waiting for
 	// its taskRef to be asynchronously resolved.
 	TaskRunReasonResolvingTaskRef = "ResolvingTaskRef"
@@ -212,6 +221,8 @@ const (
 	TaskRunReasonResolvingStepActionRef = "ResolvingStepActionRef"
 	// TaskRunReasonImagePullFailed is the reason set when the step of a task fails due to image not being pulled
 	TaskRunReasonImagePullFailed TaskRunReason = "TaskRunImagePullFailed"
+	// TaskRunReasonPodEvicted is the reason set when the pod of a task fails because it was evicted
+	TaskRunReasonPodEvicted TaskRunReason = "TaskRunPodEvicted"
 	// TaskRunReasonCreateContainerConfigError is the reason set when the step of a task fails due to config error (e.g., missing ConfigMap or Secret)
 	TaskRunReasonCreateContainerConfigError TaskRunReason = "CreateContainerConfigError"
 	// TaskRunReasonPodCreationFailed is the reason set when the pod backing the TaskRun fails to be created (e.g., CreateContainerError)
@@ -491,9 +502,10 @@ func (tr *TaskRun) IsCancelled() bool {
 	return tr.Spec.Status == TaskRunSpecStatusCancelled
 }
 
-// IsRetriable returns true if the TaskRun's Retries is not exhausted.
+// IsRetriable returns true if the TaskRun's Retries is not exhausted and
+// its last attempt failed in a way that its RetryPolicy retries.
 func (tr *TaskRun) IsRetriable() bool {
-	return len(tr.Status.RetriesStatus) < tr.Spec.Retries
+	return len(tr.Status.RetriesStatus) < tr.Spec.Retries && tr.Spec.RetryPolicy.ShouldRetry(&tr.Status)
 }
 
 // HasTimedOut returns true if the TaskRun runtime is beyond the allowed timeout
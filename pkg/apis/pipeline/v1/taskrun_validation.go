@@ -109,6 +109,7 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	if ts.Retries < 0 {
 		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", ts.Retries), "retries"))
 	}
+	errs = errs.Also(ts.RetryPolicy.Validate(ctx, ts.Retries).ViaField("retryPolicy"))
 
 	if ts.PodTemplate != nil {
 		errs = errs.Also(validatePodTemplateEnv(ctx, *ts.PodTemplate))
@@ -606,6 +606,55 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 			Retries: -3,
 		},
 		wantErr: apis.ErrInvalidValue("-3 should be >= 0", "retries"),
+	}, {
+		name: "retryPolicy without retries",
+		spec: v1.TaskRunSpec{
+			TaskRef:     &v1.TaskRef{Name: "taskrefname"},
+			RetryPolicy: &v1.RetryPolicy{},
+		},
+		wc:      cfgtesting.EnableAlphaAPIFields,
+		wantErr: apis.ErrGeneric("retryPolicy requires retries to be greater than 0", "retryPolicy"),
+	}, {
+		name: "retryPolicy when not alpha",
+		spec: v1.TaskRunSpec{
+			TaskRef:     &v1.TaskRef{Name: "taskrefname"},
+			Retries:     1,
+			RetryPolicy: &v1.RetryPolicy{},
+		},
+		wc:      cfgtesting.EnableBetaAPIFields,
+		wantErr: apis.ErrGeneric("retryPolicy requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"beta\"").ViaField("retryPolicy"),
+	}, {
+		name: "invalid retryPolicy backoff",
+		spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{Name: "taskrefname"},
+			Retries: 1,
+			RetryPolicy: &v1.RetryPolicy{Backoff: &v1.RetryBackoff{
+				Delay:    &metav1.Duration{Duration: time.Minute},
+				Factor:   -1,
+				MaxDelay: &metav1.Duration{Duration: time.Second},
+			}},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+		wantErr: apis.ErrInvalidValue("1s should be >= backoff.delay", "retryPolicy.backoff.maxDelay").Also(
+			apis.ErrInvalidValue("-1 should be >= 1", "retryPolicy.backoff.factor")),
+	}, {
+		name: "retryPolicy backoff without delay",
+		spec: v1.TaskRunSpec{
+			TaskRef:     &v1.TaskRef{Name: "taskrefname"},
+			Retries:     1,
+			RetryPolicy: &v1.RetryPolicy{Backoff: &v1.RetryBackoff{}},
+		},
+		wc:      cfgtesting.EnableAlphaAPIFields,
+		wantErr: apis.ErrMissingField("retryPolicy.backoff.delay"),
+	}, {
+		name: "empty retryPolicy condition",
+		spec: v1.TaskRunSpec{
+			TaskRef:     &v1.TaskRef{Name: "taskrefname"},
+			Retries:     1,
+			RetryPolicy: &v1.RetryPolicy{RetryOn: []v1.RetryCondition{{ExitCodes: []int32{1}}, {}}},
+		},
+		wc:      cfgtesting.EnableAlphaAPIFields,
+		wantErr: apis.ErrMissingOneOf("reasons", "exitCodes", "terminationReasons").ViaFieldIndex("retryOn", 1).ViaField("retryPolicy"),
 	}, {
 		name: "wrong taskrun cancel",
 		spec: v1.TaskRunSpec{
@@ -973,6 +1022,24 @@ func TestTaskRunSpec_Validate(t *testing.T) {
 			}},
 		},
 		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "retryPolicy",
+		spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{Name: "task"},
+			Retries: 3,
+			RetryPolicy: &v1.RetryPolicy{
+				Backoff: &v1.RetryBackoff{
+					Delay:    &metav1.Duration{Duration: 10 * time.Second},
+					MaxDelay: &metav1.Duration{Duration: time.Minute},
+				},
+				RetryOn: []v1.RetryCondition{{
+					Reasons: []string{v1.TaskRunReasonPodEvicted.String()},
+				}, {
+					ExitCodes: []int32{137},
+				}},
+			},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
 	}}
 
 	for _, ts := range tests {
@@ -18,8 +18,11 @@ import (
 	"fmt"
 	"maps"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/strings/slices"
 	"knative.dev/pkg/apis"
@@ -36,6 +39,44 @@ type Matrix struct {
 	// Include is a list of IncludeParams which allows passing in specific combinations of Parameters into the Matrix.
 	// +optional
 	Include IncludeParamsList `json:"include,omitempty"`
+
+	// MaxParallel is the maximum number of TaskRuns of the Matrix that run at the same time.
+	// The remaining combinations are scheduled as the running TaskRuns complete.
+	// Defaults to the default-matrix-max-parallel configuration, 0 means no limit.
+	// +optional
+	MaxParallel int `json:"maxParallel,omitempty"`
+
+	// Strategy configures how the PipelineTask behaves when some of the TaskRuns of the Matrix fail.
+	// +optional
+	Strategy *MatrixStrategy `json:"strategy,omitempty"`
+}
+
+// MatrixStrategy configures how a PipelineTask with a Matrix behaves when some of its TaskRuns fail.
+type MatrixStrategy struct {
+	// FailFast cancels the running TaskRuns of the Matrix, and does not create the remaining ones,
+	// as soon as more TaskRuns failed than AllowFailures allows.
+	// By default, all the TaskRuns of the Matrix run to completion.
+	// +optional
+	FailFast bool `json:"failFast,omitempty"`
+
+	// AllowFailures is the number, or the percentage such as "25%", of the TaskRuns of the Matrix
+	// that may fail without failing the PipelineTask. Percentages are rounded down.
+	// Defaults to 0.
+	// +optional
+	AllowFailures *intstr.IntOrString `json:"allowFailures,omitempty"`
+}
+
+// AllowedFailures returns how many of the numberOfTaskRuns TaskRuns of the Matrix may fail without
+// failing the PipelineTask.
+func (s *MatrixStrategy) AllowedFailures(numberOfTaskRuns int) int {
+	if s == nil || s.AllowFailures == nil {
+		return 0
+	}
+	allowed, err := intstr.GetScaledValueFromIntOrPercent(s.AllowFailures, numberOfTaskRuns, false)
+	if err != nil {
+		return 0
+	}
+	return allowed
 }
 
 // IncludeParamsList is a list of IncludeParams which allows passing in specific combinations of Parameters into the Matrix.
@@ -298,6 +339,37 @@ func (m *Matrix) validateCombinationsCount(ctx context.Context) (errs *apis.Fiel
 	return errs
 }
 
+func (m *Matrix) validateMaxParallel(ctx context.Context) (errs *apis.FieldError) {
+	if m.MaxParallel != 0 {
+		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "matrix.maxParallel", config.AlphaAPIFields))
+	}
+	if m.MaxParallel < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(m.MaxParallel, "matrix.maxParallel", "must not be negative"))
+	}
+	return errs
+}
+
+func (m *Matrix) validateStrategy(ctx context.Context) (errs *apis.FieldError) {
+	if m.Strategy == nil {
+		return nil
+	}
+	errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "matrix.strategy", config.AlphaAPIFields))
+	if allowFailures := m.Strategy.AllowFailures; allowFailures != nil {
+		switch allowFailures.Type {
+		case intstr.Int:
+			if allowFailures.IntVal < 0 {
+				errs = errs.Also(apis.ErrInvalidValue(allowFailures.IntVal, "matrix.strategy.allowFailures", "must not be negative"))
+			}
+		case intstr.String:
+			percent, err := strconv.Atoi(strings.TrimSuffix(allowFailures.StrVal, "%"))
+			if !strings.HasSuffix(allowFailures.StrVal, "%") || err != nil || percent < 0 || percent > 100 {
+				errs = errs.Also(apis.ErrInvalidValue(allowFailures.StrVal, "matrix.strategy.allowFailures", "must be a number or a percentage between 0% and 100%"))
+			}
+		}
+	}
+	return errs
+}
+
 // validateUniqueParams validates Matrix.Params for a unique list of params
 // and a unique list of params in each Matrix.Include.Params specification
 func (m *Matrix) validateUniqueParams() (errs *apis.FieldError) {
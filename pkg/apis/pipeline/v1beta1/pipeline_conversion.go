@@ -169,6 +169,7 @@ func (pt PipelineTask) convertTo(ctx context.Context, sink *v1.PipelineTask, met
 	}
 	sink.OnError = (v1.PipelineTaskOnErrorType)(pt.OnError)
 	sink.Retries = pt.Retries
+	sink.RetryPolicy = pt.RetryPolicy
 	sink.RunAfter = pt.RunAfter
 	sink.Params = nil
 	for _, p := range pt.Params {
@@ -218,6 +219,7 @@ func (pt *PipelineTask) convertFrom(ctx context.Context, source v1.PipelineTask,
 	}
 	pt.OnError = (PipelineTaskOnErrorType)(source.OnError)
 	pt.Retries = source.Retries
+	pt.RetryPolicy = source.RetryPolicy
 	pt.RunAfter = source.RunAfter
 	pt.Params = nil
 	for _, p := range source.Params {
@@ -286,6 +288,13 @@ func (m *Matrix) convertTo(ctx context.Context, sink *v1.Matrix) {
 			sink.Include[i].Params = append(sink.Include[i].Params, newIncludeParam)
 		}
 	}
+	sink.MaxParallel = m.MaxParallel
+	if m.Strategy != nil {
+		sink.Strategy = &v1.MatrixStrategy{
+			FailFast:      m.Strategy.FailFast,
+			AllowFailures: m.Strategy.AllowFailures,
+		}
+	}
 }
 
 func (m *Matrix) convertFrom(ctx context.Context, source v1.Matrix) {
@@ -303,6 +312,13 @@ func (m *Matrix) convertFrom(ctx context.Context, source v1.Matrix) {
 			m.Include[i].Params = append(m.Include[i].Params, new)
 		}
 	}
+	m.MaxParallel = source.MaxParallel
+	if source.Strategy != nil {
+		m.Strategy = &MatrixStrategy{
+			FailFast:      source.Strategy.FailFast,
+			AllowFailures: source.Strategy.AllowFailures,
+		}
+	}
 }
 
 func (pr PipelineResult) convertTo(ctx context.Context, sink *v1.PipelineResult) {
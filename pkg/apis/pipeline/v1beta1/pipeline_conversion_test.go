@@ -28,6 +28,7 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"knative.dev/pkg/apis"
 )
 
@@ -44,6 +45,7 @@ func TestPipelineConversionBadType(t *testing.T) {
 }
 
 func TestPipelineConversion(t *testing.T) {
+	allowFailures := intstr.FromString("25%")
 	for _, test := range []struct {
 		name string
 		in   *v1beta1.Pipeline
@@ -168,7 +170,10 @@ func TestPipelineConversion(t *testing.T) {
 						Operator: selection.In,
 						Values:   []string{"foo", "bar"},
 					}},
-					Retries:  1,
+					Retries: 1,
+					RetryPolicy: &v1.RetryPolicy{
+						RetryOn: []v1.RetryCondition{{Reasons: []string{"TaskRunPodEvicted"}}},
+					},
 					RunAfter: []string{"task-1"},
 					Params: v1beta1.Params{{
 						Name: "param-task-1",
@@ -192,6 +197,11 @@ func TestPipelineConversion(t *testing.T) {
 							}, {
 								Name: "flags", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeString, StringVal: "-cover -v"}}},
 						}},
+						MaxParallel: 2,
+						Strategy: &v1beta1.MatrixStrategy{
+							FailFast:      true,
+							AllowFailures: &allowFailures,
+						},
 					},
 					Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
 						Name:      "my-task-workspace",
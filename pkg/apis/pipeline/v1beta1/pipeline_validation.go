@@ -252,6 +252,8 @@ func (pt *PipelineTask) validateMatrix(ctx context.Context) (errs *apis.FieldErr
 		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "matrix", config.BetaAPIFields))
 		errs = errs.Also(pt.Matrix.validateCombinationsCount(ctx))
 		errs = errs.Also(pt.Matrix.validateUniqueParams())
+		errs = errs.Also(pt.Matrix.validateMaxParallel(ctx))
+		errs = errs.Also(pt.Matrix.validateStrategy(ctx))
 	}
 	errs = errs.Also(pt.Matrix.validateParameterInOneOfMatrixOrParams(pt.Params))
 	return errs
@@ -356,6 +358,9 @@ func isValidAPIVersion(apiVersion string) bool {
 
 // validateCustomTask validates custom task specifications - checking kind and fail if not yet supported features specified
 func (pt PipelineTask) validateCustomTask() (errs *apis.FieldError) {
+	if pt.RetryPolicy != nil {
+		errs = errs.Also(apis.ErrGeneric("retryPolicy is not supported for Custom Tasks", "retryPolicy"))
+	}
 	if pt.TaskRef != nil && pt.TaskRef.Kind == "" {
 		errs = errs.Also(apis.ErrInvalidValue("custom task ref must specify kind", "taskRef.kind"))
 	}
@@ -382,6 +387,7 @@ func (pt PipelineTask) validateCustomTask() (errs *apis.FieldError) {
 
 // validateTask validates a pipeline task or a final task for taskRef and taskSpec
 func (pt PipelineTask) validateTask(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(pt.RetryPolicy.Validate(ctx, pt.Retries).ViaField("retryPolicy"))
 	if pt.TaskSpec != nil {
 		errs = errs.Also(pt.TaskSpec.Validate(ctx).ViaField("taskSpec"))
 	}
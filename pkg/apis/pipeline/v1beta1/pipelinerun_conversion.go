@@ -92,6 +92,7 @@ func (prs PipelineRunSpec) ConvertTo(ctx context.Context, sink *v1.PipelineRunSp
 		ptrs.convertTo(ctx, &new)
 		sink.TaskRunSpecs = append(sink.TaskRunSpecs, new)
 	}
+	sink.Concurrency = prs.Concurrency
 	return nil
 }
 
@@ -153,6 +154,7 @@ func (prs *PipelineRunSpec) ConvertFrom(ctx context.Context, source *v1.Pipeline
 		new.convertFrom(ctx, trs)
 		prs.TaskRunSpecs = append(prs.TaskRunSpecs, new)
 	}
+	prs.Concurrency = source.Concurrency
 	return nil
 }
 
@@ -336,6 +338,7 @@ func (csr ChildStatusReference) convertTo(ctx context.Context, sink *v1.ChildSta
 	sink.Name = csr.Name
 	sink.DisplayName = csr.DisplayName
 	sink.PipelineTaskName = csr.PipelineTaskName
+	sink.Reason = csr.Reason
 	sink.WhenExpressions = nil
 	for _, we := range csr.WhenExpressions {
 		new := v1.WhenExpression{}
@@ -349,6 +352,7 @@ func (csr *ChildStatusReference) convertFrom(ctx context.Context, source v1.Chil
 	csr.Name = source.Name
 	csr.DisplayName = source.DisplayName
 	csr.PipelineTaskName = source.PipelineTaskName
+	csr.Reason = source.Reason
 	csr.WhenExpressions = nil
 	for _, we := range source.WhenExpressions {
 		new := WhenExpression{}
@@ -45,6 +45,7 @@ var (
 		DisplayName:      "TR 0",
 		PipelineTaskName: "ptn",
 		WhenExpressions:  []v1beta1.WhenExpression{{Input: "default-value", Operator: "in", Values: []string{"val"}}},
+		Reason:           "Succeeded",
 	}}
 	childRefRuns = []v1beta1.ChildStatusReference{{
 		TypeMeta:         runtime.TypeMeta{Kind: "Run", APIVersion: "tekton.dev/v1alpha1"},
@@ -300,6 +301,11 @@ func TestPipelineRunConversion(t *testing.T) {
 						},
 					},
 				},
+				Concurrency: &v1.PipelineRunConcurrency{
+					Key:         "deploy-$(params.foo)",
+					MaxParallel: 2,
+					Strategy:    v1.ConcurrencyStrategyCancelOldest,
+				},
 			},
 			Status: v1beta1.PipelineRunStatus{
 				Status: duckv1.Status{
@@ -25,6 +25,7 @@ import (
 	apisconfig "github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -300,6 +301,10 @@ type PipelineRunSpec struct {
 	// +optional
 	// +listType=atomic
 	TaskRunSpecs []PipelineTaskRunSpec `json:"taskRunSpecs,omitempty"`
+	// Concurrency limits how many PipelineRuns sharing a concurrency key
+	// run at the same time.
+	// +optional
+	Concurrency *v1.PipelineRunConcurrency `json:"concurrency,omitempty"`
 	// ManagedBy indicates which controller is responsible for reconciling
 	// this resource. If unset or set to "tekton.dev/pipeline", the default
 	// Tekton controller will manage this resource.
@@ -452,6 +457,11 @@ type ChildStatusReference struct {
 	// +optional
 	// +listType=atomic
 	WhenExpressions []WhenExpression `json:"whenExpressions,omitempty"`
+
+	// Reason is the reason of the Succeeded condition of the TaskRun once it is done.
+	// It is only set for the TaskRuns of a PipelineTask whose Matrix has a strategy.
+	// +optional
+	Reason string `json:"reason,omitempty"`
 }
 
 // PipelineRunStatusFields holds the fields of PipelineRunStatus' status.
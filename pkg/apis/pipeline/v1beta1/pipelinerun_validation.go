@@ -125,6 +125,7 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 	}
 
 	errs = errs.Also(validateSpecStatus(ps.Status))
+	errs = errs.Also(ps.Concurrency.Validate(ctx, ps.Params.ExtractNames()).ViaField("concurrency"))
 
 	if ps.Workspaces != nil {
 		wsNames := make(map[string]int)
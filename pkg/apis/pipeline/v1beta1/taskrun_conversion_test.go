@@ -168,7 +168,12 @@ func TestTaskRunConversion(t *testing.T) {
 						},
 					}},
 					ServiceAccountName: "test-sa",
-					TaskRef:            &v1beta1.TaskRef{Name: "test-task"},
+					Retries:            2,
+					RetryPolicy: &v1.RetryPolicy{
+						Backoff: &v1.RetryBackoff{Delay: &metav1.Duration{Duration: 10 * time.Second}},
+						RetryOn: []v1.RetryCondition{{ExitCodes: []int32{137}}},
+					},
+					TaskRef: &v1beta1.TaskRef{Name: "test-task"},
 					TaskSpec: &v1beta1.TaskSpec{
 						Params: []v1beta1.ParamSpec{{
 							Name: "param-name",
@@ -24,6 +24,7 @@ import (
 	apisconfig "github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -64,6 +65,9 @@ type TaskRunSpec struct {
 	// Retries represents how many times this TaskRun should be retried in the event of Task failure.
 	// +optional
 	Retries int `json:"retries,omitempty"`
+	// RetryPolicy configures how soon and on which failures this TaskRun is retried.
+	// +optional
+	RetryPolicy *v1.RetryPolicy `json:"retryPolicy,omitempty"`
 	// Time after which one retry attempt times out. Defaults to 1 hour.
 	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
 	// +optional
@@ -116,6 +120,9 @@ const (
 	TaskRunCancelledByPipelineMsg TaskRunSpecStatusMessage = "TaskRun cancelled as the PipelineRun it belongs to has been cancelled."
 	// TaskRunCancelledByPipelineTimeoutMsg indicates that the TaskRun was cancelled because the PipelineRun running it timed out.
 	TaskRunCancelledByPipelineTimeoutMsg TaskRunSpecStatusMessage = "TaskRun cancelled as the PipelineRun it belongs to has timed out."
+	// TaskRunCancelledByMatrixFailFastMsg indicates that the TaskRun was cancelled because other TaskRuns
+	// of its Matrix failed and the Matrix strategy is to fail fast.
+	TaskRunCancelledByMatrixFailFastMsg TaskRunSpecStatusMessage = "TaskRun cancelled as other TaskRuns of its Matrix failed."
 )
 
 const (
@@ -220,6 +227,8 @@ const (
 	TaskRunReasonResolvingTaskRef = "ResolvingTaskRef"
 	// TaskRunReasonImagePullFailed is the reason set when the step of a task fails due to image not being pulled
 	TaskRunReasonImagePullFailed TaskRunReason = "TaskRunImagePullFailed"
+	// TaskRunReasonPodEvicted is the reason set when the pod of a task fails because it was evicted
+	TaskRunReasonPodEvicted TaskRunReason = "TaskRunPodEvicted"
 	// TaskRunReasonResultsVerified is the reason set when the TaskRun results are verified by spire
 	TaskRunReasonResultsVerified TaskRunReason = "TaskRunResultsVerified"
 	// TaskRunReasonsResultsVerificationFailed is the reason set when the TaskRun results are failed to verify by spire
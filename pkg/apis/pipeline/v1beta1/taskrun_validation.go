@@ -111,6 +111,8 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 		errs = errs.Also(validatePodTemplateEnv(ctx, *ts.PodTemplate))
 	}
 
+	errs = errs.Also(ts.RetryPolicy.Validate(ctx, ts.Retries).ViaField("retryPolicy"))
+
 	if ts.Timeout != nil && ts.Timeout.Duration < 0 {
 		errs = errs.Also(apis.ErrInvalidValue(ts.Timeout.Duration.String()+" should be >= 0", "timeout"))
 	}
@@ -69,3 +69,9 @@ func (s *ResolutionRequestStatus) MarkSucceeded() {
 func (s *ResolutionRequestStatus) MarkInProgress(message string) {
 	resolutionRequestCondSet.Manage(s).MarkUnknown(apis.ConditionSucceeded, resolutioncommon.ReasonResolutionInProgress, message)
 }
+
+// MarkRetrying updates the Succeeded condition to Unknown with a message
+// describing the failed attempt that will be retried.
+func (s *ResolutionRequestStatus) MarkRetrying(message string) {
+	resolutionRequestCondSet.Manage(s).MarkUnknown(apis.ConditionSucceeded, resolutioncommon.ReasonResolutionRetrying, message)
+}
@@ -137,9 +137,9 @@ func MakeTaskRunStatus(ctx context.Context, logger *zap.SugaredLogger, tr v1.Tas
 	if complete {
 		onError, ok := tr.Annotations[v1.PipelineTaskOnErrorAnnotation]
 		if ok {
-			updateCompletedTaskRunStatus(logger, trs, pod, v1.PipelineTaskOnErrorType(onError))
+			updateCompletedTaskRunStatus(ctx, logger, trs, pod, v1.PipelineTaskOnErrorType(onError))
 		} else {
-			updateCompletedTaskRunStatus(logger, trs, pod, "")
+			updateCompletedTaskRunStatus(ctx, logger, trs, pod, "")
 		}
 	} else {
 		updateIncompleteTaskRunStatus(trs, pod)
@@ -605,12 +605,17 @@ func getTerminationReason(terminatedStateReason string, terminationFromResults s
 	return terminatedStateReason
 }
 
-func updateCompletedTaskRunStatus(logger *zap.SugaredLogger, trs *v1.TaskRunStatus, pod *corev1.Pod, onError v1.PipelineTaskOnErrorType) {
+func updateCompletedTaskRunStatus(ctx context.Context, logger *zap.SugaredLogger, trs *v1.TaskRunStatus, pod *corev1.Pod, onError v1.PipelineTaskOnErrorType) {
 	if DidTaskRunFail(pod) {
 		msg := getFailureMessage(logger, pod)
-		if onError == v1.PipelineTaskContinue {
+		switch {
+		case onError == v1.PipelineTaskContinue:
 			markStatusFailure(trs, v1.TaskRunReasonFailureIgnored.String(), msg)
-		} else {
+		// Evictions have their own reason, for the retryOn conditions of
+		// retry policies, only while retry policies are in alpha.
+		case pod.Status.Reason == evicted && config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == config.AlphaAPIFields:
+			markStatusFailure(trs, v1.TaskRunReasonPodEvicted.String(), msg)
+		default:
 			markStatusFailure(trs, v1.TaskRunReasonFailed.String(), msg)
 		}
 	} else {
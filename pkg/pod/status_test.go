@@ -2131,6 +2131,62 @@ func TestMakeTaskRunStatus(t *testing.T) {
 	}
 }
 
+func TestMakeRunStatus_PodEvicted(t *testing.T) {
+	for _, c := range []struct {
+		name           string
+		enableAPIField string
+		want           v1.TaskRunStatus
+	}{{
+		name:           "stable API fields",
+		enableAPIField: config.StableAPIFields,
+		want: v1.TaskRunStatus{
+			Status: statusFailure(string(v1.TaskRunReasonFailed), "The node was low on resource: memory."),
+		},
+	}, {
+		name:           "alpha API fields",
+		enableAPIField: config.AlphaAPIFields,
+		want: v1.TaskRunStatus{
+			Status: statusFailure(string(v1.TaskRunReasonPodEvicted), "The node was low on resource: memory."),
+		},
+	}} {
+		t.Run(c.name, func(t *testing.T) {
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod",
+					Namespace: "foo",
+				},
+				Status: corev1.PodStatus{
+					Phase:   corev1.PodFailed,
+					Reason:  "Evicted",
+					Message: "The node was low on resource: memory.",
+				},
+			}
+			tr := v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "task-run",
+					Namespace: "foo",
+				},
+			}
+
+			logger, _ := logging.NewLogger("", "status")
+			kubeclient := fakek8s.NewSimpleClientset()
+			ctx := config.ToContext(t.Context(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{
+					EnableAPIFields: c.enableAPIField,
+				},
+			})
+			got, err := MakeTaskRunStatus(ctx, logger, tr, &pod, kubeclient, &v1.TaskSpec{})
+			if err != nil {
+				t.Errorf("Unexpected err in MakeTaskRunResult: %s", err)
+			}
+
+			if d := cmp.Diff(c.want.Status, got.Status, ignoreVolatileTime); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestMakeRunStatus_OnError(t *testing.T) {
 	for _, c := range []struct {
 		name      string
@@ -28,6 +28,7 @@ import (
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
 	"go.uber.org/zap"
 	jsonpatch "gomodules.xyz/jsonpatch/v2"
 	corev1 "k8s.io/api/core/v1"
@@ -38,7 +39,7 @@ import (
 	"knative.dev/pkg/apis"
 )
 
-var cancelTaskRunPatchBytes, cancelCustomRunPatchBytes []byte
+var cancelTaskRunPatchBytes, cancelCustomRunPatchBytes, failFastTaskRunPatchBytes []byte
 
 func init() {
 	var err error
@@ -70,6 +71,20 @@ func init() {
 	if err != nil {
 		log.Fatalf("failed to marshal CustomRun cancel patch bytes: %v", err)
 	}
+	failFastTaskRunPatchBytes, err = json.Marshal([]jsonpatch.JsonPatchOperation{
+		{
+			Operation: "add",
+			Path:      "/spec/status",
+			Value:     v1.TaskRunSpecStatusCancelled,
+		},
+		{
+			Operation: "add",
+			Path:      "/spec/statusMessage",
+			Value:     v1.TaskRunCancelledByMatrixFailFastMsg,
+		}})
+	if err != nil {
+		log.Fatalf("failed to marshal TaskRun fail fast patch bytes: %v", err)
+	}
 }
 
 func cancelCustomRun(ctx context.Context, runName string, namespace string, clientSet clientset.Interface) error {
@@ -96,6 +111,28 @@ func cancelTaskRun(ctx context.Context, taskRunName string, namespace string, cl
 	return err
 }
 
+// cancelFailingFastMatrixTaskRuns cancels the running TaskRuns of the PipelineTasks whose Matrix
+// fails fast because more of their TaskRuns failed than the Matrix strategy allows.
+func cancelFailingFastMatrixTaskRuns(ctx context.Context, logger *zap.SugaredLogger, pr *v1.PipelineRun, clientSet clientset.Interface, state resources.PipelineRunState) []string {
+	errs := []string{}
+	for _, rpt := range state {
+		if !rpt.IsMatrixFailingFast() {
+			continue
+		}
+		for _, taskRun := range rpt.TaskRuns {
+			if taskRun.IsDone() || taskRun.IsCancelled() {
+				continue
+			}
+			logger.Infof("cancelling TaskRun %s as other TaskRuns of its Matrix failed", taskRun.Name)
+			_, err := clientSet.TektonV1().TaskRuns(pr.Namespace).Patch(ctx, taskRun.Name, types.JSONPatchType, failFastTaskRunPatchBytes, metav1.PatchOptions{}, "")
+			if err != nil && !errors.IsNotFound(err) && !pipelineErrors.IsImmutableTaskRunSpecError(err) {
+				errs = append(errs, fmt.Errorf("failed to patch TaskRun `%s` with cancellation: %w", taskRun.Name, err).Error())
+			}
+		}
+	}
+	return errs
+}
+
 // cancelPipelineRun marks the PipelineRun as cancelled and any resolved TaskRun(s) too.
 func cancelPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *v1.PipelineRun, clientSet clientset.Interface) error {
 	errs := cancelPipelineTaskRuns(ctx, logger, pr, clientSet)
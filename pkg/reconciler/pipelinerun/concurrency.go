@@ -0,0 +1,253 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	jsonpatch "gomodules.xyz/jsonpatch/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+)
+
+var queuePipelineRunPatchBytes, dequeuePipelineRunPatchBytes, cancelPipelineRunPatchBytes []byte
+
+func init() {
+	var err error
+	queuePipelineRunPatchBytes, err = json.Marshal([]jsonpatch.JsonPatchOperation{{
+		Operation: "add",
+		Path:      "/spec/status",
+		Value:     v1.PipelineRunSpecStatusPending,
+	}})
+	if err != nil {
+		log.Fatalf("failed to marshal PipelineRun queue patch bytes: %v", err)
+	}
+	dequeuePipelineRunPatchBytes, err = json.Marshal(map[string]any{
+		"spec": map[string]any{"status": nil},
+	})
+	if err != nil {
+		log.Fatalf("failed to marshal PipelineRun dequeue patch bytes: %v", err)
+	}
+	cancelPipelineRunPatchBytes, err = json.Marshal([]jsonpatch.JsonPatchOperation{{
+		Operation: "add",
+		Path:      "/spec/status",
+		Value:     v1.PipelineRunSpecStatusCancelled,
+	}})
+	if err != nil {
+		log.Fatalf("failed to marshal PipelineRun cancel patch bytes: %v", err)
+	}
+}
+
+// concurrencyTracker remembers the PipelineRuns admitted by this controller
+// that the informer has not observed starting yet, so that PipelineRuns
+// reconciled at the same time cannot all be admitted. It is not shared between
+// replicas, so PipelineRuns admitted by different replicas at the same time
+// can exceed the limit of their key.
+type concurrencyTracker struct {
+	mu       sync.Mutex
+	admitted map[types.NamespacedName]bool
+}
+
+// needsAdmission returns true if the PipelineRun has a concurrency limit that
+// must be checked before it starts.
+func needsAdmission(pr *v1.PipelineRun) bool {
+	return pr.Spec.Concurrency != nil && !pr.HasStarted() && !pr.IsDone() && !isStopping(pr) &&
+		(!pr.IsPending() || isQueued(pr))
+}
+
+// isQueued returns true if the PipelineRun is held in the pending state by its
+// concurrency limit rather than by its user.
+func isQueued(pr *v1.PipelineRun) bool {
+	cond := pr.Status.GetCondition(apis.ConditionSucceeded)
+	return cond != nil && cond.Reason == v1.PipelineRunReasonQueued.String()
+}
+
+// isStopping returns true if the PipelineRun was cancelled or stopped.
+func isStopping(pr *v1.PipelineRun) bool {
+	return pr.IsCancelled() || pr.IsGracefullyCancelled() || pr.IsGracefullyStopped()
+}
+
+// admitPipelineRun returns true if the PipelineRun may start without exceeding
+// the concurrency limit of its key. Otherwise the PipelineRun is queued or
+// cancelled according to the strategy of the limit.
+func (c *Reconciler) admitPipelineRun(ctx context.Context, pr *v1.PipelineRun) (bool, error) {
+	logger := logging.FromContext(ctx)
+	concurrency := pr.Spec.Concurrency
+	key := pr.ConcurrencyKey()
+	maxParallel := concurrency.GetMaxParallel()
+
+	c.concurrency.mu.Lock()
+	defer c.concurrency.mu.Unlock()
+	running, waiting, err := c.concurrencyState(pr, key)
+	if err != nil {
+		return false, err
+	}
+
+	switch concurrency.GetStrategy() {
+	case v1.ConcurrencyStrategyCancelNewest:
+		if len(running) >= maxParallel {
+			pr.Status.MarkFailed(v1.PipelineRunReasonCancelled.String(),
+				"PipelineRun %q was cancelled because %d PipelineRuns with concurrency key %q are already running", pr.Name, len(running), key)
+			return false, nil
+		}
+	case v1.ConcurrencyStrategyCancelOldest:
+		sort.Slice(running, func(i, j int) bool { return startedBefore(running[i], running[j]) })
+		for _, oldest := range running[:max(len(running)-maxParallel+1, 0)] {
+			logger.Infof("Cancelling PipelineRun %s to start PipelineRun %s with concurrency key %q", oldest.Name, pr.Name, key)
+			if _, err := c.PipelineClientSet.TektonV1().PipelineRuns(pr.Namespace).Patch(ctx, oldest.Name, types.JSONPatchType, cancelPipelineRunPatchBytes, metav1.PatchOptions{}, ""); err != nil {
+				return false, fmt.Errorf("failed to cancel PipelineRun %s with concurrency key %q: %w", oldest.Name, key, err)
+			}
+		}
+	default:
+		ahead := 0
+		for _, other := range waiting {
+			if createdBefore(other, pr) {
+				ahead++
+			}
+		}
+		if position := len(running) + ahead - maxParallel + 1; position > 0 {
+			if !pr.IsPending() {
+				if _, err := c.PipelineClientSet.TektonV1().PipelineRuns(pr.Namespace).Patch(ctx, pr.Name, types.JSONPatchType, queuePipelineRunPatchBytes, metav1.PatchOptions{}, ""); err != nil {
+					return false, fmt.Errorf("failed to queue PipelineRun %s: %w", pr.Name, err)
+				}
+				pr.Spec.Status = v1.PipelineRunSpecStatusPending
+			}
+			pr.Status.MarkRunning(v1.PipelineRunReasonQueued.String(),
+				"PipelineRun %q is queued at position %d for concurrency key %q", pr.Name, position, key)
+			return false, nil
+		}
+		if pr.IsPending() {
+			// Start the PipelineRun once the informer observes it leaving the
+			// pending state, it counts against the limit until then.
+			if _, err := c.PipelineClientSet.TektonV1().PipelineRuns(pr.Namespace).Patch(ctx, pr.Name, types.MergePatchType, dequeuePipelineRunPatchBytes, metav1.PatchOptions{}, ""); err != nil {
+				return false, fmt.Errorf("failed to dequeue PipelineRun %s: %w", pr.Name, err)
+			}
+			pr.Spec.Status = ""
+			pr.Status.MarkRunning(v1.PipelineRunReasonQueued.String(),
+				"PipelineRun %q is starting, it left the queue for concurrency key %q", pr.Name, key)
+			c.concurrency.admit(pr)
+			return false, nil
+		}
+	}
+	c.concurrency.admit(pr)
+	return true, nil
+}
+
+// concurrencyState returns the other PipelineRuns with the concurrency key
+// that are running or were admitted, and those waiting to be admitted.
+// The caller must hold the lock of the concurrency tracker.
+func (c *Reconciler) concurrencyState(pr *v1.PipelineRun, key string) (running, waiting []*v1.PipelineRun, err error) {
+	prs, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list PipelineRuns in namespace %s: %w", pr.Namespace, err)
+	}
+	seen := map[types.NamespacedName]bool{}
+	for _, other := range prs {
+		name := other.GetNamespacedName()
+		seen[name] = true
+		if other.HasStarted() || other.IsDone() {
+			delete(c.concurrency.admitted, name)
+		}
+		if other.Name == pr.Name || other.IsDone() || isStopping(other) || other.ConcurrencyKey() != key {
+			continue
+		}
+		switch {
+		case other.HasStarted(), c.concurrency.admitted[name]:
+			running = append(running, other)
+		case !other.IsPending() || isQueued(other):
+			waiting = append(waiting, other)
+		}
+	}
+	for name := range c.concurrency.admitted {
+		if name.Namespace == pr.Namespace && !seen[name] {
+			delete(c.concurrency.admitted, name)
+		}
+	}
+	return running, waiting, nil
+}
+
+// admit records that the PipelineRun was admitted. The caller must hold the
+// lock of the concurrency tracker.
+func (t *concurrencyTracker) admit(pr *v1.PipelineRun) {
+	if t.admitted == nil {
+		t.admitted = map[types.NamespacedName]bool{}
+	}
+	t.admitted[pr.GetNamespacedName()] = true
+}
+
+// startedBefore orders PipelineRuns by the time they started. PipelineRuns
+// that were admitted but did not start yet are ordered last.
+func startedBefore(a, b *v1.PipelineRun) bool {
+	switch {
+	case a.Status.StartTime == nil || b.Status.StartTime == nil:
+		return a.Status.StartTime != nil || (b.Status.StartTime == nil && createdBefore(a, b))
+	case a.Status.StartTime.Equal(b.Status.StartTime):
+		return createdBefore(a, b)
+	}
+	return a.Status.StartTime.Before(b.Status.StartTime)
+}
+
+// createdBefore orders PipelineRuns by creation time, then by name.
+func createdBefore(a, b *v1.PipelineRun) bool {
+	if a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.Name < b.Name
+	}
+	return a.CreationTimestamp.Before(&b.CreationTimestamp)
+}
+
+// enqueueWaitingPipelineRuns returns an informer event handler enqueueing the
+// PipelineRuns waiting for the concurrency key of a PipelineRun that stopped
+// running.
+func (c *Reconciler) enqueueWaitingPipelineRuns(enqueue func(any)) cache.ResourceEventHandler {
+	enqueueWaiting := func(pr *v1.PipelineRun) {
+		key := pr.ConcurrencyKey()
+		prs, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).List(labels.Everything())
+		if err != nil {
+			return
+		}
+		for _, other := range prs {
+			if other.Name != pr.Name && !other.HasStarted() && !other.IsDone() && other.ConcurrencyKey() == key {
+				enqueue(other)
+			}
+		}
+	}
+	return cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(_, obj any) {
+			if pr, ok := obj.(*v1.PipelineRun); ok && pr.Spec.Concurrency != nil && (pr.IsDone() || isStopping(pr)) {
+				enqueueWaiting(pr)
+			}
+		},
+		DeleteFunc: func(obj any) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if pr, ok := obj.(*v1.PipelineRun); ok && pr.Spec.Concurrency != nil {
+				enqueueWaiting(pr)
+			}
+		},
+	}
+}
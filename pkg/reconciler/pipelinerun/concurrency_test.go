@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func concurrencyPipelineRun(name, environment string, created time.Duration, concurrency v1.PipelineRunConcurrency) *v1.PipelineRun {
+	concurrency.Key = "deploy-$(params.environment)"
+	return &v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "foo",
+			CreationTimestamp: metav1.NewTime(now.Add(-created)),
+		},
+		Spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "test-pipeline"},
+			Params: v1.Params{{
+				Name:  "environment",
+				Value: *v1.NewStructuredValues(environment),
+			}},
+			Concurrency: &concurrency,
+		},
+	}
+}
+
+func withStatus(pr *v1.PipelineRun, started bool, cond apis.Condition) *v1.PipelineRun {
+	cond.Type = apis.ConditionSucceeded
+	pr.Status.Status = duckv1.Status{Conditions: duckv1.Conditions{cond}}
+	if started {
+		pr.Status.StartTime = &metav1.Time{Time: pr.CreationTimestamp.Add(time.Second)}
+	}
+	return pr
+}
+
+func runningPipelineRun(pr *v1.PipelineRun) *v1.PipelineRun {
+	return withStatus(pr, true, apis.Condition{Status: corev1.ConditionUnknown, Reason: v1.PipelineRunReasonRunning.String()})
+}
+
+func queuedPipelineRun(pr *v1.PipelineRun) *v1.PipelineRun {
+	pr.Spec.Status = v1.PipelineRunSpecStatusPending
+	return withStatus(pr, false, apis.Condition{Status: corev1.ConditionUnknown, Reason: v1.PipelineRunReasonQueued.String()})
+}
+
+func TestReconcileConcurrency(t *testing.T) {
+	queue := v1.PipelineRunConcurrency{}
+	queuePatch := `[{"op":"add","path":"/spec/status","value":"PipelineRunPending"}]`
+	for _, tc := range []struct {
+		name        string
+		existing    []*v1.PipelineRun
+		pr          *v1.PipelineRun
+		wantReason  v1.PipelineRunReason
+		wantMessage string
+		wantStarted bool
+		wantPatches map[string]string
+	}{{
+		name:        "no other PipelineRun",
+		pr:          concurrencyPipelineRun("new", "prod", time.Minute, queue),
+		wantReason:  v1.PipelineRunReasonRunning,
+		wantStarted: true,
+	}, {
+		name: "other key running",
+		existing: []*v1.PipelineRun{
+			runningPipelineRun(concurrencyPipelineRun("running", "staging", time.Hour, queue)),
+		},
+		pr:          concurrencyPipelineRun("new", "prod", time.Minute, queue),
+		wantReason:  v1.PipelineRunReasonRunning,
+		wantStarted: true,
+	}, {
+		name: "same key running",
+		existing: []*v1.PipelineRun{
+			runningPipelineRun(concurrencyPipelineRun("running", "prod", time.Hour, queue)),
+		},
+		pr:          concurrencyPipelineRun("new", "prod", time.Minute, queue),
+		wantReason:  v1.PipelineRunReasonQueued,
+		wantMessage: `PipelineRun "new" is queued at position 1 for concurrency key "deploy-prod"`,
+		wantPatches: map[string]string{"new": queuePatch},
+	}, {
+		name: "same key completed",
+		existing: []*v1.PipelineRun{
+			withStatus(concurrencyPipelineRun("done", "prod", time.Hour, queue), true, apis.Condition{Status: corev1.ConditionTrue, Reason: v1.PipelineRunReasonSuccessful.String()}),
+		},
+		pr:          concurrencyPipelineRun("new", "prod", time.Minute, queue),
+		wantReason:  v1.PipelineRunReasonRunning,
+		wantStarted: true,
+	}, {
+		name: "queued behind earlier PipelineRuns",
+		existing: []*v1.PipelineRun{
+			runningPipelineRun(concurrencyPipelineRun("running", "prod", time.Hour, v1.PipelineRunConcurrency{MaxParallel: 2})),
+			queuedPipelineRun(concurrencyPipelineRun("first", "prod", 2*time.Minute, v1.PipelineRunConcurrency{MaxParallel: 2})),
+			queuedPipelineRun(concurrencyPipelineRun("second", "prod", 90*time.Second, v1.PipelineRunConcurrency{MaxParallel: 2})),
+			queuedPipelineRun(concurrencyPipelineRun("later", "prod", time.Second, v1.PipelineRunConcurrency{MaxParallel: 2})),
+		},
+		pr:          concurrencyPipelineRun("new", "prod", time.Minute, v1.PipelineRunConcurrency{MaxParallel: 2}),
+		wantReason:  v1.PipelineRunReasonQueued,
+		wantMessage: `PipelineRun "new" is queued at position 2 for concurrency key "deploy-prod"`,
+		wantPatches: map[string]string{"new": queuePatch},
+	}, {
+		name: "queued PipelineRun leaves the queue",
+		existing: []*v1.PipelineRun{
+			queuedPipelineRun(concurrencyPipelineRun("later", "prod", time.Second, queue)),
+		},
+		pr:          queuedPipelineRun(concurrencyPipelineRun("new", "prod", time.Minute, queue)),
+		wantReason:  v1.PipelineRunReasonQueued,
+		wantMessage: `PipelineRun "new" is starting, it left the queue for concurrency key "deploy-prod"`,
+		wantPatches: map[string]string{"new": `{"spec":{"status":null}}`},
+	}, {
+		name: "pending PipelineRun is left to its user",
+		existing: []*v1.PipelineRun{
+			runningPipelineRun(concurrencyPipelineRun("running", "prod", time.Hour, queue)),
+		},
+		pr: func() *v1.PipelineRun {
+			pr := concurrencyPipelineRun("new", "prod", time.Minute, queue)
+			pr.Spec.Status = v1.PipelineRunSpecStatusPending
+			return pr
+		}(),
+		wantReason: v1.PipelineRunReasonPending,
+	}, {
+		name: "cancel-newest",
+		existing: []*v1.PipelineRun{
+			runningPipelineRun(concurrencyPipelineRun("running", "prod", time.Hour, v1.PipelineRunConcurrency{Strategy: v1.ConcurrencyStrategyCancelNewest})),
+		},
+		pr:          concurrencyPipelineRun("new", "prod", time.Minute, v1.PipelineRunConcurrency{Strategy: v1.ConcurrencyStrategyCancelNewest}),
+		wantReason:  v1.PipelineRunReasonCancelled,
+		wantMessage: `PipelineRun "new" was cancelled because 1 PipelineRuns with concurrency key "deploy-prod" are already running`,
+	}, {
+		name: "cancel-oldest",
+		existing: []*v1.PipelineRun{
+			runningPipelineRun(concurrencyPipelineRun("oldest", "prod", 2*time.Hour, v1.PipelineRunConcurrency{MaxParallel: 2, Strategy: v1.ConcurrencyStrategyCancelOldest})),
+			runningPipelineRun(concurrencyPipelineRun("older", "prod", time.Hour, v1.PipelineRunConcurrency{MaxParallel: 2, Strategy: v1.ConcurrencyStrategyCancelOldest})),
+		},
+		pr:          concurrencyPipelineRun("new", "prod", time.Minute, v1.PipelineRunConcurrency{MaxParallel: 2, Strategy: v1.ConcurrencyStrategyCancelOldest}),
+		wantReason:  v1.PipelineRunReasonRunning,
+		wantStarted: true,
+		wantPatches: map[string]string{"oldest": `[{"op":"add","path":"/spec/status","value":"Cancelled"}]`},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{
+				PipelineRuns: append(tc.existing, tc.pr),
+				Pipelines:    []*v1.Pipeline{simpleHelloWorldPipeline},
+				Tasks:        []*v1.Task{simpleHelloWorldTask},
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", tc.pr.Name, nil, false)
+
+			cond := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+			if cond == nil || cond.Reason != tc.wantReason.String() {
+				t.Fatalf("expected reason %s, got condition %v", tc.wantReason, cond)
+			}
+			if tc.wantMessage != "" && cond.Message != tc.wantMessage {
+				t.Errorf("expected message %q, got %q", tc.wantMessage, cond.Message)
+			}
+			if started := reconciledRun.Status.StartTime != nil; started != tc.wantStarted {
+				t.Errorf("expected started %t, got %t", tc.wantStarted, started)
+			}
+			gotPatches := map[string]string{}
+			for _, a := range clients.Pipeline.Actions() {
+				if action, ok := a.(ktesting.PatchAction); ok && action.Matches("patch", "pipelineruns") {
+					gotPatches[action.GetName()] = string(action.GetPatch())
+				}
+			}
+			if d := cmp.Diff(tc.wantPatches, gotPatches, cmpopts.EquateEmpty()); d != "" {
+				t.Errorf("unexpected PipelineRun patches %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -171,6 +171,7 @@ type Reconciler struct {
 	pvcHandler               volumeclaim.PvcHandler
 	resolutionRequester      resolution.Requester
 	tracerProvider           trace.TracerProvider
+	concurrency              *concurrencyTracker
 }
 
 var (
@@ -210,6 +211,14 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1.PipelineRun) pkgr
 		return controller.NewPermanentError(errors.New("PipelineRun has timed out for a long time"))
 	}
 
+	// Hold back PipelineRuns that would exceed the concurrency limit of their key.
+	if needsAdmission(pr) {
+		admitted, err := c.admitPipelineRun(ctx, pr)
+		if err != nil || !admitted {
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+		}
+	}
+
 	if !pr.HasStarted() && !pr.IsPending() {
 		pr.Status.InitializeConditions(c.Clock)
 		// In case node time was not synchronized, when controller has been scheduled to other nodes.
@@ -844,6 +853,12 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		}
 	}
 
+	if errs := cancelFailingFastMatrixTaskRuns(ctx, logger, pr, c.PipelineClientSet, pipelineRunFacts.State); len(errs) > 0 {
+		errString := strings.Join(errs, "\n")
+		logger.Errorf("Failed to cancel the TaskRuns of failing Matrixes for PipelineRun %s/%s: %s", pr.Namespace, pr.Name, errString)
+		return fmt.Errorf("error(s) from cancelling TaskRun(s) from PipelineRun %s: %s", pr.Name, errString)
+	}
+
 	if err := c.runNextSchedulableTask(ctx, pr, pipelineRunFacts); err != nil {
 		return err
 	}
@@ -1098,8 +1113,13 @@ func (c *Reconciler) createTaskRuns(ctx context.Context, rpt *resources.Resolved
 	defer span.End()
 
 	var matrixCombinations []v1.Params
+	var maxParallel int
 	if rpt.PipelineTask.IsMatrixed() {
 		matrixCombinations = rpt.PipelineTask.Matrix.FanOut()
+		maxParallel = rpt.PipelineTask.Matrix.MaxParallel
+		if maxParallel == 0 {
+			maxParallel = config.FromContextOrDefaults(ctx).Defaults.DefaultMatrixMaxParallel
+		}
 	}
 
 	// validate the param values meet resolved Task Param Enum requirements before creating TaskRuns
@@ -1119,8 +1139,26 @@ func (c *Reconciler) createTaskRuns(ctx context.Context, rpt *resources.Resolved
 		}
 	}
 
+	// the TaskRuns of a Matrix limited by maxParallel are created over several
+	// reconciles, as the TaskRuns created before them complete
+	existingTaskRuns := make(map[string]*v1.TaskRun, len(rpt.TaskRuns))
+	running := 0
+	for _, taskRun := range rpt.TaskRuns {
+		existingTaskRuns[taskRun.Name] = taskRun
+		if !taskRun.IsDone() {
+			running++
+		}
+	}
+
 	var taskRuns []*v1.TaskRun
 	for i, taskRunName := range rpt.TaskRunNames {
+		if taskRun, ok := existingTaskRuns[taskRunName]; ok {
+			taskRuns = append(taskRuns, taskRun)
+			continue
+		}
+		if maxParallel > 0 && running >= maxParallel {
+			continue
+		}
 		var params v1.Params
 		if len(matrixCombinations) > i {
 			params = matrixCombinations[i]
@@ -1131,6 +1169,7 @@ func (c *Reconciler) createTaskRuns(ctx context.Context, rpt *resources.Resolved
 			return nil, err
 		}
 		taskRuns = append(taskRuns, taskRun)
+		running++
 	}
 
 	return taskRuns, nil
@@ -1153,6 +1192,7 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 		},
 		Spec: v1.TaskRunSpec{
 			Retries:            rpt.PipelineTask.Retries,
+			RetryPolicy:        rpt.PipelineTask.RetryPolicy,
 			Params:             params,
 			ServiceAccountName: taskRunSpec.ServiceAccountName,
 			PodTemplate:        taskRunSpec.PodTemplate,
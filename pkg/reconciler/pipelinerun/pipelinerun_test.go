@@ -13848,6 +13848,379 @@ status:
 	}
 }
 
+func TestReconciler_PipelineTaskMatrixMaxParallel(t *testing.T) {
+	names.TestingSeed()
+
+	task := parse.MustParseV1Task(t, `
+metadata:
+  name: mytask
+  namespace: foo
+spec:
+  params:
+    - name: platform
+  steps:
+    - name: echo
+      image: alpine
+      script: |
+        echo "$(params.platform)"
+`)
+	taskRun := func(name, platform, status string) *v1.TaskRun {
+		return parse.MustParseTaskRunWithObjectMeta(t,
+			taskRunObjectMeta(name, "foo", "pr", "p", "platforms", false),
+			fmt.Sprintf(`
+spec:
+  params:
+  - name: platform
+    value: %s
+  serviceAccountName: test-sa
+  taskRef:
+    name: mytask
+    kind: Task
+status:
+  conditions:
+  - type: Succeeded
+    status: %q
+`, platform, status))
+	}
+
+	for _, tc := range []struct {
+		name               string
+		maxParallel        int
+		defaultMaxParallel string
+		trs                []*v1.TaskRun
+		wantCreated        []string
+		wantChildRefs      []string
+	}{{
+		name:          "first taskruns",
+		maxParallel:   2,
+		wantCreated:   []string{"pr-platforms-0", "pr-platforms-1"},
+		wantChildRefs: []string{"pr-platforms-0", "pr-platforms-1"},
+	}, {
+		name:        "taskruns running",
+		maxParallel: 2,
+		trs: []*v1.TaskRun{
+			taskRun("pr-platforms-0", "linux", "Unknown"),
+			taskRun("pr-platforms-1", "mac", "Unknown"),
+		},
+		wantChildRefs: []string{"pr-platforms-0", "pr-platforms-1"},
+	}, {
+		name:        "taskrun succeeded",
+		maxParallel: 2,
+		trs: []*v1.TaskRun{
+			taskRun("pr-platforms-0", "linux", "True"),
+			taskRun("pr-platforms-1", "mac", "Unknown"),
+		},
+		wantCreated:   []string{"pr-platforms-2"},
+		wantChildRefs: []string{"pr-platforms-0", "pr-platforms-1", "pr-platforms-2"},
+	}, {
+		name:        "taskrun failed",
+		maxParallel: 2,
+		trs: []*v1.TaskRun{
+			taskRun("pr-platforms-0", "linux", "False"),
+			taskRun("pr-platforms-1", "mac", "Unknown"),
+		},
+		wantCreated:   []string{"pr-platforms-2"},
+		wantChildRefs: []string{"pr-platforms-0", "pr-platforms-1", "pr-platforms-2"},
+	}, {
+		name:               "default max parallel",
+		defaultMaxParallel: "1",
+		wantCreated:        []string{"pr-platforms-0"},
+		wantChildRefs:      []string{"pr-platforms-0"},
+	}, {
+		name:               "max parallel overrides the default",
+		maxParallel:        3,
+		defaultMaxParallel: "1",
+		wantCreated:        []string{"pr-platforms-0", "pr-platforms-1", "pr-platforms-2"},
+		wantChildRefs:      []string{"pr-platforms-0", "pr-platforms-1", "pr-platforms-2"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			status := ""
+			if len(tc.trs) > 0 {
+				status = `
+status:
+  conditions:
+  - type: Succeeded
+    status: "Unknown"
+    reason: "Running"
+  childReferences:`
+				for _, tr := range tc.trs {
+					status += fmt.Sprintf(`
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: %s
+    pipelineTaskName: platforms`, tr.Name)
+				}
+			}
+			pr := parse.MustParseV1PipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: pr
+  namespace: foo
+spec:
+  serviceAccountName: test-sa
+  pipelineSpec:
+    tasks:
+    - name: platforms
+      taskRef:
+        name: mytask
+      matrix:
+        maxParallel: %d
+        params:
+          - name: platform
+            value:
+              - linux
+              - mac
+              - windows
+              - freebsd
+%s`, tc.maxParallel, status))
+
+			cms := th.NewAlphaFeatureFlagsConfigMapInSlice()
+			if tc.defaultMaxParallel != "" {
+				cms = append(cms, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+					Data: map[string]string{
+						"default-matrix-max-parallel": tc.defaultMaxParallel,
+					},
+				})
+			}
+			d := test.Data{
+				PipelineRuns: []*v1.PipelineRun{pr},
+				Tasks:        []*v1.Task{task},
+				TaskRuns:     tc.trs,
+				ConfigMaps:   cms,
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "pr", nil, false)
+
+			var created []string
+			for _, a := range clients.Pipeline.Actions() {
+				if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+					created = append(created, a.(ktesting.CreateAction).GetObject().(*v1.TaskRun).Name)
+				}
+			}
+			if d := cmp.Diff(tc.wantCreated, created); d != "" {
+				t.Errorf("Unexpected TaskRuns created %s", diff.PrintWantGot(d))
+			}
+			var gotChildRefs []string
+			for _, cr := range reconciledRun.Status.ChildReferences {
+				gotChildRefs = append(gotChildRefs, cr.Name)
+			}
+			if d := cmp.Diff(tc.wantChildRefs, gotChildRefs); d != "" {
+				t.Errorf("Unexpected childReferences %s", diff.PrintWantGot(d))
+			}
+			th.CheckPipelineRunConditionStatusAndReason(t, reconciledRun.Status, corev1.ConditionUnknown, v1.PipelineRunReasonRunning.String())
+		})
+	}
+}
+
+func TestReconciler_PipelineTaskMatrixStrategy(t *testing.T) {
+	names.TestingSeed()
+
+	task := parse.MustParseV1Task(t, `
+metadata:
+  name: mytask
+  namespace: foo
+spec:
+  params:
+    - name: platform
+  steps:
+    - name: echo
+      image: alpine
+      script: |
+        echo "$(params.platform)"
+`)
+	taskRun := func(name, platform, status, reason string) *v1.TaskRun {
+		return parse.MustParseTaskRunWithObjectMeta(t,
+			taskRunObjectMeta(name, "foo", "pr", "p", "platforms", false),
+			fmt.Sprintf(`
+spec:
+  params:
+  - name: platform
+    value: %s
+  serviceAccountName: test-sa
+  taskRef:
+    name: mytask
+    kind: Task
+status:
+  conditions:
+  - type: Succeeded
+    status: %q
+    reason: %s
+`, platform, status, reason))
+	}
+	cancelledByFailFast := func(tr *v1.TaskRun) *v1.TaskRun {
+		tr.Spec.Status = v1.TaskRunSpecStatusCancelled
+		tr.Spec.StatusMessage = v1.TaskRunCancelledByMatrixFailFastMsg
+		return tr
+	}
+
+	for _, tc := range []struct {
+		name           string
+		strategy       string
+		trs            []*v1.TaskRun
+		wantCancelled  []string
+		wantReasons    []string
+		wantTaskStatus string
+		wantMessage    string
+	}{{
+		name: "fail fast cancels the running taskruns",
+		strategy: `
+          failFast: true`,
+		trs: []*v1.TaskRun{
+			taskRun("pr-platforms-0", "linux", "False", "Failed"),
+			taskRun("pr-platforms-1", "mac", "Unknown", "Running"),
+			taskRun("pr-platforms-2", "windows", "True", "Succeeded"),
+		},
+		wantCancelled: []string{"pr-platforms-1"},
+		wantReasons:   []string{"Failed", "", "Succeeded"},
+		wantMessage:   "Tasks Completed: 0 (Failed: 0, Cancelled 0), Incomplete: 2, Skipped: 0",
+	}, {
+		name: "fail fast fails the pipelinetask once the taskruns are cancelled",
+		strategy: `
+          failFast: true`,
+		trs: []*v1.TaskRun{
+			taskRun("pr-platforms-0", "linux", "False", "Failed"),
+			cancelledByFailFast(taskRun("pr-platforms-1", "mac", "False", "TaskRunCancelled")),
+			taskRun("pr-platforms-2", "windows", "True", "Succeeded"),
+		},
+		wantReasons:    []string{"Failed", "TaskRunCancelled", "Succeeded"},
+		wantMessage:    "Tasks Completed: 1 (Failed: 1, Cancelled 0), Incomplete: 1, Skipped: 0",
+		wantTaskStatus: v1.PipelineRunReasonFailed.String(),
+	}, {
+		name: "fail fast with allowed failures",
+		strategy: `
+          failFast: true
+          allowFailures: 1`,
+		trs: []*v1.TaskRun{
+			taskRun("pr-platforms-0", "linux", "False", "Failed"),
+			taskRun("pr-platforms-1", "mac", "Unknown", "Running"),
+			taskRun("pr-platforms-2", "windows", "True", "Succeeded"),
+		},
+		wantReasons: []string{"Failed", "", "Succeeded"},
+		wantMessage: "Tasks Completed: 0 (Failed: 0, Cancelled 0), Incomplete: 2, Skipped: 0",
+	}, {
+		name: "allowed failures not exceeded",
+		strategy: `
+          allowFailures: 1`,
+		trs: []*v1.TaskRun{
+			taskRun("pr-platforms-0", "linux", "False", "Failed"),
+			taskRun("pr-platforms-1", "mac", "True", "Succeeded"),
+			taskRun("pr-platforms-2", "windows", "True", "Succeeded"),
+		},
+		wantReasons:    []string{"Failed", "Succeeded", "Succeeded"},
+		wantMessage:    "Tasks Completed: 1 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0",
+		wantTaskStatus: v1.PipelineRunReasonSuccessful.String(),
+	}, {
+		name: "allowed failures percentage exceeded",
+		strategy: `
+          allowFailures: 50%`,
+		trs: []*v1.TaskRun{
+			taskRun("pr-platforms-0", "linux", "False", "Failed"),
+			taskRun("pr-platforms-1", "mac", "False", "Failed"),
+			taskRun("pr-platforms-2", "windows", "True", "Succeeded"),
+		},
+		wantReasons:    []string{"Failed", "Failed", "Succeeded"},
+		wantMessage:    "Tasks Completed: 1 (Failed: 1, Cancelled 0), Incomplete: 1, Skipped: 0",
+		wantTaskStatus: v1.PipelineRunReasonFailed.String(),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			status := `
+status:
+  conditions:
+  - type: Succeeded
+    status: "Unknown"
+    reason: "Running"
+  childReferences:`
+			for _, tr := range tc.trs {
+				status += fmt.Sprintf(`
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: %s
+    pipelineTaskName: platforms`, tr.Name)
+			}
+			pr := parse.MustParseV1PipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: pr
+  namespace: foo
+spec:
+  serviceAccountName: test-sa
+  pipelineSpec:
+    tasks:
+    - name: platforms
+      taskRef:
+        name: mytask
+      matrix:
+        params:
+          - name: platform
+            value:
+              - linux
+              - mac
+              - windows
+        strategy:%s
+    finally:
+    - name: report
+      params:
+      - name: platform
+        value: $(tasks.platforms.status)
+      taskRef:
+        name: mytask
+%s`, tc.strategy, status))
+
+			d := test.Data{
+				PipelineRuns: []*v1.PipelineRun{pr},
+				Tasks:        []*v1.Task{task},
+				TaskRuns:     tc.trs,
+				ConfigMaps:   th.NewAlphaFeatureFlagsConfigMapInSlice(),
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "pr", nil, false)
+
+			var cancelled []string
+			for _, a := range clients.Pipeline.Actions() {
+				if action, ok := a.(ktesting.PatchAction); ok && action.Matches("patch", "taskruns") {
+					if d := cmp.Diff(string(failFastTaskRunPatchBytes), string(action.GetPatch())); d != "" {
+						t.Errorf("Unexpected patch for TaskRun %s %s", action.GetName(), diff.PrintWantGot(d))
+					}
+					cancelled = append(cancelled, action.GetName())
+				}
+			}
+			if d := cmp.Diff(tc.wantCancelled, cancelled); d != "" {
+				t.Errorf("Unexpected TaskRuns cancelled %s", diff.PrintWantGot(d))
+			}
+			var gotReasons []string
+			for _, cr := range reconciledRun.Status.ChildReferences {
+				if cr.PipelineTaskName == "platforms" {
+					gotReasons = append(gotReasons, cr.Reason)
+				}
+			}
+			if d := cmp.Diff(tc.wantReasons, gotReasons); d != "" {
+				t.Errorf("Unexpected childReferences reasons %s", diff.PrintWantGot(d))
+			}
+			if tc.wantTaskStatus != "" {
+				var report *v1.TaskRun
+				for _, a := range clients.Pipeline.Actions() {
+					if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+						report = a.(ktesting.CreateAction).GetObject().(*v1.TaskRun)
+					}
+				}
+				if report == nil {
+					t.Fatal("Expected the finally task to be created")
+				}
+				if got := report.Spec.Params[0].Value.StringVal; got != tc.wantTaskStatus {
+					t.Errorf("Expected the status of the matrixed task to be %q but was %q", tc.wantTaskStatus, got)
+				}
+			}
+			th.CheckPipelineRunConditionStatusAndReason(t, reconciledRun.Status, corev1.ConditionUnknown, v1.PipelineRunReasonRunning.String())
+			if got := reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Message; got != tc.wantMessage {
+				t.Errorf("Expected the PipelineRun condition message to be %q but was %q", tc.wantMessage, got)
+			}
+		})
+	}
+}
+
 func TestReconciler_PipelineTaskMatrixWithCustomTask(t *testing.T) {
 	names.TestingSeed()
 
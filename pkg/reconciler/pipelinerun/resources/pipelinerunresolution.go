@@ -35,6 +35,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/resolution/resource"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmeta"
 )
@@ -129,9 +130,11 @@ func (t *ResolvedPipelineTask) EvaluateCEL() error {
 	return nil
 }
 
-// isDone returns true only if the task is skipped, succeeded or failed
+// isDone returns true only if the task is skipped, succeeded or failed,
+// or if it has a Matrix whose remaining TaskRuns will not be created anymore
 func (t ResolvedPipelineTask) isDone(facts *PipelineRunFacts) bool {
-	return t.Skip(facts).IsSkipped || t.isSuccessful() || t.isFailure() || t.isValidationFailed(facts.ValidationFailedTask)
+	return t.Skip(facts).IsSkipped || t.isSuccessful() || t.isFailure() || t.isValidationFailed(facts.ValidationFailedTask) ||
+		t.isStoppedBeforeAllRunsStarted(facts)
 }
 
 // IsRunning returns true only if the task is neither succeeded, cancelled nor failed
@@ -190,6 +193,14 @@ func (t ResolvedPipelineTask) getReason() string {
 	if len(t.TaskRuns) == 0 {
 		return ""
 	}
+	if t.isSuccessful() {
+		// the Matrix strategy may allow some of the TaskRuns to fail
+		for _, taskRun := range t.TaskRuns {
+			if taskRun.IsSuccessful() && len(taskRun.Status.Conditions) >= 1 {
+				return taskRun.Status.Conditions[0].Reason
+			}
+		}
+	}
 	for _, taskRun := range t.TaskRuns {
 		if !taskRun.IsSuccessful() && len(taskRun.Status.Conditions) >= 1 {
 			return taskRun.Status.Conditions[0].Reason
@@ -203,7 +214,8 @@ func (t ResolvedPipelineTask) getReason() string {
 }
 
 // isSuccessful returns true only if the run has completed successfully
-// If the PipelineTask has a Matrix, isSuccessful returns true if all runs have completed successfully
+// If the PipelineTask has a Matrix, isSuccessful returns true if all runs have completed successfully,
+// or if all TaskRuns are done and no more of them failed than its Matrix strategy allows
 func (t ResolvedPipelineTask) isSuccessful() bool {
 	if t.IsChildPipeline() {
 		if len(t.ChildPipelineRuns) == 0 {
@@ -231,19 +243,25 @@ func (t ResolvedPipelineTask) isSuccessful() bool {
 		return true
 	}
 
-	if len(t.TaskRuns) == 0 {
+	if len(t.TaskRuns) == 0 || t.hasUnscheduledTaskRuns() {
 		return false
 	}
+	unsuccessful := 0
 	for _, taskRun := range t.TaskRuns {
-		if !taskRun.IsSuccessful() {
+		if !taskRun.IsDone() {
 			return false
 		}
+		if !taskRun.IsSuccessful() {
+			unsuccessful++
+		}
 	}
-	return true
+	return unsuccessful <= t.allowedTaskRunFailures()
 }
 
 // isFailure returns true only if the run has failed (if it has ConditionSucceeded = False).
 // If the PipelineTask has a Matrix, isFailure returns true if any run has failed and all other runs are done.
+// For TaskRuns, more of them must have failed than its Matrix strategy allows, and the remaining TaskRuns
+// must have been created unless the Matrix fails fast.
 func (t ResolvedPipelineTask) isFailure() bool {
 	var isDone bool
 	if t.IsChildPipeline() {
@@ -275,7 +293,7 @@ func (t ResolvedPipelineTask) isFailure() bool {
 	for _, taskRun := range t.TaskRuns {
 		isDone = isDone && taskRun.IsDone()
 	}
-	return t.haveAnyTaskRunsFailed() && isDone
+	return t.hasTooManyFailedTaskRuns() && isDone && (!t.hasUnscheduledTaskRuns() || t.IsMatrixFailingFast())
 }
 
 // isValidationFailed return true if the task is failed at the validation step
@@ -325,6 +343,7 @@ func (t ResolvedPipelineTask) isCancelledForTimeOut() bool {
 
 // isCancelled returns true only if the run is cancelled
 // If the PipelineTask has a Matrix, isCancelled returns true if any run is cancelled and all other runs are done.
+// TaskRuns cancelled because their Matrix fails fast are failures rather than cancellations.
 func (t ResolvedPipelineTask) isCancelled() bool {
 	if t.IsCustomTask() {
 		if len(t.CustomRuns) == 0 {
@@ -348,7 +367,8 @@ func (t ResolvedPipelineTask) isCancelled() bool {
 	for _, taskRun := range t.TaskRuns {
 		isDone = isDone && taskRun.IsDone()
 		c := taskRun.Status.GetCondition(apis.ConditionSucceeded)
-		taskRunCancelled := c.IsFalse() && c.Reason == v1beta1.TaskRunReasonCancelled.String()
+		taskRunCancelled := c.IsFalse() && c.Reason == v1beta1.TaskRunReasonCancelled.String() &&
+			taskRun.Spec.StatusMessage != v1.TaskRunCancelledByMatrixFailFastMsg
 		atLeastOneCancelled = atLeastOneCancelled || taskRunCancelled
 	}
 	return atLeastOneCancelled && isDone
@@ -363,6 +383,51 @@ func (t ResolvedPipelineTask) isScheduled() bool {
 	return len(t.TaskRuns) > 0
 }
 
+// hasUnscheduledTaskRuns returns true when some but not all of the TaskRuns of a PipelineTask
+// with a Matrix have been created, because its Matrix limits how many of them run in parallel.
+func (t ResolvedPipelineTask) hasUnscheduledTaskRuns() bool {
+	if t.IsCustomTask() || t.IsChildPipeline() {
+		return false
+	}
+	return len(t.TaskRuns) > 0 && len(t.TaskRuns) < len(t.TaskRunNames)
+}
+
+// isMatrixSchedulingStopped returns true when no more TaskRuns will be created for a PipelineTask
+// with unscheduled TaskRuns, because its Matrix fails fast or the PipelineRun is stopping,
+// cancelled or timed out. Final tasks keep being scheduled while the PipelineRun is gracefully
+// cancelled or stopped.
+func (t *ResolvedPipelineTask) isMatrixSchedulingStopped(facts *PipelineRunFacts) bool {
+	if t.IsMatrixFailingFast() || facts.IsCancelled() || t.isMatrixSchedulingTimedOut(facts) {
+		return true
+	}
+	if t.IsFinalTask(facts) {
+		return false
+	}
+	return facts.IsStopping() || facts.IsGracefullyCancelled() || facts.IsGracefullyStopped()
+}
+
+// isMatrixSchedulingTimedOut returns true when no more TaskRuns will be created for a PipelineTask
+// with unscheduled TaskRuns because the pipeline, tasks or finally timeout of the PipelineRun was reached.
+func (t *ResolvedPipelineTask) isMatrixSchedulingTimedOut(facts *PipelineRunFacts) bool {
+	return t.skipBecausePipelineRunPipelineTimeoutReached(facts) ||
+		t.skipBecausePipelineRunTasksTimeoutReached(facts) ||
+		t.skipBecausePipelineRunFinallyTimeoutReached(facts)
+}
+
+// isStoppedBeforeAllRunsStarted returns true when all the created TaskRuns of a PipelineTask with
+// unscheduled TaskRuns are done and no more of them will be created.
+func (t ResolvedPipelineTask) isStoppedBeforeAllRunsStarted(facts *PipelineRunFacts) bool {
+	if !t.hasUnscheduledTaskRuns() {
+		return false
+	}
+	for _, taskRun := range t.TaskRuns {
+		if !taskRun.IsDone() {
+			return false
+		}
+	}
+	return t.isMatrixSchedulingStopped(facts)
+}
+
 // haveAnyRunsFailed returns true when any of the child PipelineRuns/TaskRuns/CustomRuns have succeeded condition with status set to false
 func (t ResolvedPipelineTask) haveAnyRunsFailed() bool {
 	if t.IsChildPipeline() {
@@ -396,6 +461,40 @@ func (t ResolvedPipelineTask) haveAnyTaskRunsFailed() bool {
 	return false
 }
 
+// failedTaskRunsCount returns the number of TaskRuns that have succeeded condition with status set to false
+func (t ResolvedPipelineTask) failedTaskRunsCount() int {
+	failed := 0
+	for _, taskRun := range t.TaskRuns {
+		if taskRun.IsFailure() {
+			failed++
+		}
+	}
+	return failed
+}
+
+// hasTooManyFailedTaskRuns returns true when more TaskRuns failed than the Matrix strategy
+// of the PipelineTask allows, which is none without a Matrix strategy
+func (t ResolvedPipelineTask) hasTooManyFailedTaskRuns() bool {
+	return t.failedTaskRunsCount() > t.allowedTaskRunFailures()
+}
+
+// allowedTaskRunFailures returns how many TaskRuns of the PipelineTask may fail without failing it
+func (t ResolvedPipelineTask) allowedTaskRunFailures() int {
+	if t.PipelineTask.Matrix == nil {
+		return 0
+	}
+	return t.PipelineTask.Matrix.Strategy.AllowedFailures(len(t.TaskRunNames))
+}
+
+// IsMatrixFailingFast returns true when more TaskRuns of the PipelineTask failed than its
+// Matrix strategy allows, and the strategy is to fail fast
+func (t ResolvedPipelineTask) IsMatrixFailingFast() bool {
+	if t.PipelineTask.Matrix == nil || t.PipelineTask.Matrix.Strategy == nil {
+		return false
+	}
+	return t.PipelineTask.Matrix.Strategy.FailFast && t.hasTooManyFailedTaskRuns()
+}
+
 // haveAnyCustomRunsFailed returns true when any of the CustomRuns have succeeded condition with status set to false
 func (t ResolvedPipelineTask) haveAnyCustomRunsFailed() bool {
 	for _, customRun := range t.CustomRuns {
@@ -859,6 +958,13 @@ func GetTaskRunName(childRefs []v1.ChildStatusReference, ptName, prName string)
 // GetNamesOfTaskRuns should return unique names for `TaskRuns` if one has not already been defined, and the existing one otherwise.
 func GetNamesOfTaskRuns(childRefs []v1.ChildStatusReference, ptName, prName string, numberOfTaskRuns int) []string {
 	if taskRunNames := getTaskRunNamesFromChildRefs(childRefs, ptName); taskRunNames != nil {
+		// The TaskRuns of a Matrix limited by maxParallel are created over several reconciles,
+		// so the childRefs may only hold some of them.
+		if len(taskRunNames) < numberOfTaskRuns {
+			if newRunNames := getNewRunNames(ptName, prName, numberOfTaskRuns); sets.NewString(newRunNames...).HasAll(taskRunNames...) {
+				return newRunNames
+			}
+		}
 		return taskRunNames
 	}
 	return getNewRunNames(ptName, prName, numberOfTaskRuns)
@@ -43,6 +43,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	logtesting "knative.dev/pkg/logging/testing"
@@ -418,6 +419,12 @@ func withCancelledForTimeout(tr *v1.TaskRun) *v1.TaskRun {
 	return tr
 }
 
+func withCancelledByMatrixFailFast(tr *v1.TaskRun) *v1.TaskRun {
+	tr.Spec.StatusMessage = v1.TaskRunCancelledByMatrixFailFastMsg
+	tr.Status.Conditions[0].Reason = v1.TaskRunSpecStatusCancelled
+	return tr
+}
+
 func withCustomRunCancelled(run *v1beta1.CustomRun) *v1beta1.CustomRun {
 	run.Status.Conditions[0].Reason = v1beta1.CustomRunReasonCancelled.String()
 	return run
@@ -1956,6 +1963,12 @@ func TestIsCancelled(t *testing.T) {
 			TaskRuns: []*v1.TaskRun{withCancelled(makeFailed(trs[0])), makeSucceeded(trs[1])},
 		},
 		want: true,
+	}, {
+		name: "taskrun cancelled by matrix fail fast",
+		rpt: ResolvedPipelineTask{
+			TaskRuns: []*v1.TaskRun{makeFailed(trs[0]), withCancelledByMatrixFailFast(makeFailed(trs[1]))},
+		},
+		want: false,
 	}, {
 		name: "customruns not started",
 		rpt: ResolvedPipelineTask{
@@ -2440,6 +2453,110 @@ func TestHaveAnyTaskRunsFailed(t *testing.T) {
 	}
 }
 
+func TestMatrixStrategy(t *testing.T) {
+	oneFailure := intstr.FromInt32(1)
+	halfFailures := intstr.FromString("50%")
+	matrixedTask := func(strategy *v1.MatrixStrategy, taskRuns ...*v1.TaskRun) ResolvedPipelineTask {
+		return ResolvedPipelineTask{
+			PipelineTask: &v1.PipelineTask{
+				Name:    "matrixedtask",
+				TaskRef: &v1.TaskRef{Name: "task"},
+				Matrix: &v1.Matrix{
+					Params: v1.Params{{
+						Name:  "platform",
+						Value: v1.ParamValue{Type: v1.ParamTypeArray, ArrayVal: []string{"linux", "mac", "windows"}},
+					}},
+					Strategy: strategy,
+				},
+			},
+			TaskRunNames: []string{"matrixedtask-0", "matrixedtask-1", "matrixedtask-2"},
+			TaskRuns:     taskRuns,
+		}
+	}
+
+	for _, tc := range []struct {
+		name           string
+		rpt            ResolvedPipelineTask
+		wantSuccessful bool
+		wantFailure    bool
+		wantFailFast   bool
+		wantReason     string
+	}{{
+		name:           "no strategy, taskruns succeeded",
+		rpt:            matrixedTask(nil, makeSucceeded(trs[0]), makeSucceeded(trs[1]), makeSucceeded(trs[2])),
+		wantSuccessful: true,
+		wantReason:     "Succeeded",
+	}, {
+		name:        "no strategy, one taskrun failed",
+		rpt:         matrixedTask(nil, makeSucceeded(trs[0]), makeFailed(trs[1]), makeSucceeded(trs[2])),
+		wantFailure: true,
+		wantReason:  "Failed",
+	}, {
+		name:           "allowed failures not exceeded",
+		rpt:            matrixedTask(&v1.MatrixStrategy{AllowFailures: &oneFailure}, makeFailed(trs[0]), makeSucceeded(trs[1]), makeSucceeded(trs[2])),
+		wantSuccessful: true,
+		wantReason:     "Succeeded",
+	}, {
+		name:       "allowed failures not exceeded, taskrun running",
+		rpt:        matrixedTask(&v1.MatrixStrategy{AllowFailures: &oneFailure}, makeFailed(trs[0]), makeSucceeded(trs[1]), makeStarted(trs[2])),
+		wantReason: "Failed",
+	}, {
+		name:        "allowed failures exceeded",
+		rpt:         matrixedTask(&v1.MatrixStrategy{AllowFailures: &oneFailure}, makeFailed(trs[0]), makeFailed(trs[1]), makeSucceeded(trs[2])),
+		wantFailure: true,
+		wantReason:  "Failed",
+	}, {
+		name:           "allowed failures percentage rounded down",
+		rpt:            matrixedTask(&v1.MatrixStrategy{AllowFailures: &halfFailures}, makeFailed(trs[0]), makeSucceeded(trs[1]), makeSucceeded(trs[2])),
+		wantSuccessful: true,
+		wantReason:     "Succeeded",
+	}, {
+		name:        "allowed failures percentage exceeded",
+		rpt:         matrixedTask(&v1.MatrixStrategy{AllowFailures: &halfFailures}, makeFailed(trs[0]), makeFailed(trs[1]), makeSucceeded(trs[2])),
+		wantFailure: true,
+		wantReason:  "Failed",
+	}, {
+		name:         "fail fast, taskruns running",
+		rpt:          matrixedTask(&v1.MatrixStrategy{FailFast: true}, makeFailed(trs[0]), makeStarted(trs[1]), makeStarted(trs[2])),
+		wantFailFast: true,
+		wantReason:   "Failed",
+	}, {
+		name:         "fail fast, taskruns cancelled",
+		rpt:          matrixedTask(&v1.MatrixStrategy{FailFast: true}, makeFailed(trs[0]), withCancelledByMatrixFailFast(makeFailed(trs[1])), makeSucceeded(trs[2])),
+		wantFailure:  true,
+		wantFailFast: true,
+		wantReason:   "Failed",
+	}, {
+		name:         "fail fast, taskruns not created",
+		rpt:          matrixedTask(&v1.MatrixStrategy{FailFast: true}, makeFailed(trs[0])),
+		wantFailure:  true,
+		wantFailFast: true,
+		wantReason:   "Failed",
+	}, {
+		name:       "fail fast, allowed failures not exceeded",
+		rpt:        matrixedTask(&v1.MatrixStrategy{FailFast: true, AllowFailures: &oneFailure}, makeFailed(trs[0]), makeStarted(trs[1]), makeStarted(trs[2])),
+		wantReason: "Failed",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.rpt.isSuccessful(); got != tc.wantSuccessful {
+				t.Errorf("expected isSuccessful: %t but got %t", tc.wantSuccessful, got)
+			}
+			if got := tc.rpt.isFailure(); got != tc.wantFailure {
+				t.Errorf("expected isFailure: %t but got %t", tc.wantFailure, got)
+			}
+			if got := tc.rpt.IsMatrixFailingFast(); got != tc.wantFailFast {
+				t.Errorf("expected IsMatrixFailingFast: %t but got %t", tc.wantFailFast, got)
+			}
+			if got := tc.rpt.isCancelled(); got {
+				t.Error("expected the matrixed task not to be cancelled")
+			}
+			if got := tc.rpt.getReason(); got != tc.wantReason {
+				t.Errorf("expected reason %q but got %q", tc.wantReason, got)
+			}
+		})
+	}
+}
+
 func TestSkipBecauseParentTaskWasSkipped(t *testing.T) {
 	for _, tc := range []struct {
 		name     string
@@ -3817,6 +3934,37 @@ func TestGetNamesOfTaskRuns(t *testing.T) {
 	}
 }
 
+func TestGetNamesOfTaskRuns_MatrixWithUnscheduledTaskRuns(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		childRefs   []v1.ChildStatusReference
+		wantTrNames []string
+	}{{
+		name: "some of the taskruns created",
+		childRefs: []v1.ChildStatusReference{{
+			TypeMeta:         runtime.TypeMeta{Kind: "TaskRun"},
+			Name:             "mypipelinerun-mytask-0",
+			PipelineTaskName: "mytask",
+		}},
+		wantTrNames: []string{"mypipelinerun-mytask-0", "mypipelinerun-mytask-1", "mypipelinerun-mytask-2"},
+	}, {
+		name: "taskruns with other names",
+		childRefs: []v1.ChildStatusReference{{
+			TypeMeta:         runtime.TypeMeta{Kind: "TaskRun"},
+			Name:             "mypipelinerun-mytask-abcde",
+			PipelineTaskName: "mytask",
+		}},
+		wantTrNames: []string{"mypipelinerun-mytask-abcde"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			names := GetNamesOfTaskRuns(tc.childRefs, "mytask", "mypipelinerun", 3)
+			if d := cmp.Diff(tc.wantTrNames, names); d != "" {
+				t.Errorf("GetNamesOfTaskRuns: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestGetNamesOfRuns(t *testing.T) {
 	prName := "mypipelinerun"
 	childRefs := []v1.ChildStatusReference{{
@@ -390,24 +390,44 @@ func (t *ResolvedPipelineTask) getChildRefForTaskRun(taskRun *v1.TaskRun) v1.Chi
 		PipelineTaskName: t.PipelineTask.Name,
 		WhenExpressions:  t.PipelineTask.When,
 	}
+	// show which TaskRuns of a Matrix with a strategy passed, as the PipelineTask may succeed with failed TaskRuns
+	if t.PipelineTask.Matrix != nil && t.PipelineTask.Matrix.Strategy != nil && taskRun.IsDone() {
+		c.Reason = taskRun.Status.GetCondition(apis.ConditionSucceeded).Reason
+	}
 	return t.getDisplayName(nil, nil, taskRun, c)
 }
 
 // getNextTasks returns a list of pipeline tasks which should be executed next i.e.
 // a list of tasks from candidateTasks which aren't yet indicated in state to be running and
 // a list of cancelled/failed tasks from candidateTasks which haven't exhausted their retries
+// and a list of tasks from candidateTasks with a Matrix whose remaining TaskRuns can still be created
 func (state PipelineRunState) getNextTasks(candidateTasks sets.String) []*ResolvedPipelineTask {
 	tasks := []*ResolvedPipelineTask{}
 	for _, t := range state {
 		if _, ok := candidateTasks[t.PipelineTask.Name]; ok {
 			if len(t.TaskRuns) == 0 && len(t.CustomRuns) == 0 && len(t.ChildPipelineRuns) == 0 {
 				tasks = append(tasks, t)
+			} else if t.hasUnscheduledTaskRuns() && !t.IsMatrixFailingFast() {
+				tasks = append(tasks, t)
 			}
 		}
 	}
 	return tasks
 }
 
+// withoutStoppedMatrixTasks removes the tasks with unscheduled TaskRuns from tasks
+// when no more of their TaskRuns will be created
+func (facts *PipelineRunFacts) withoutStoppedMatrixTasks(tasks []*ResolvedPipelineTask) []*ResolvedPipelineTask {
+	schedulable := []*ResolvedPipelineTask{}
+	for _, t := range tasks {
+		if t.hasUnscheduledTaskRuns() && t.isMatrixSchedulingStopped(facts) {
+			continue
+		}
+		schedulable = append(schedulable, t)
+	}
+	return schedulable
+}
+
 // IsStopping returns true if the PipelineRun won't be scheduling any new Task because
 // at least one task already failed (with onError: stopAndFail) or was cancelled in the specified dag
 func (facts *PipelineRunFacts) IsStopping() bool {
@@ -463,7 +483,7 @@ func (facts *PipelineRunFacts) DAGExecutionQueue() (PipelineRunState, error) {
 		return tasks, err
 	}
 	if !facts.IsStopping() && !facts.IsGracefullyStopped() {
-		tasks = facts.State.getNextTasks(candidateTasks)
+		tasks = facts.withoutStoppedMatrixTasks(facts.State.getNextTasks(candidateTasks))
 	}
 	return tasks, nil
 }
@@ -506,7 +526,7 @@ func (facts *PipelineRunFacts) GetFinalTasks() PipelineRunState {
 				finalCandidates.Insert(t.PipelineTask.Name)
 			}
 		}
-		tasks = facts.State.getNextTasks(finalCandidates)
+		tasks = facts.withoutStoppedMatrixTasks(facts.State.getNextTasks(finalCandidates))
 	}
 	return tasks
 }
@@ -711,8 +731,8 @@ func (facts *PipelineRunFacts) GetPipelineTaskStatus() map[string]string {
 				}
 
 				// if it's not a custom task or a child pipeline it's a task so we only
-				// need to check if any TaskRuns failed
-				if t.haveAnyTaskRunsFailed() {
+				// need to check if more TaskRuns failed than its Matrix strategy allows
+				if t.hasTooManyFailedTaskRuns() {
 					aggregateStatus = v1.PipelineRunReasonFailed.String()
 					break
 				}
@@ -822,6 +842,18 @@ func (facts *PipelineRunFacts) getPipelineTasksCount() pipelineRunStatusCount {
 			}
 		case t.isValidationFailed(facts.ValidationFailedTask):
 			s.ValidationFailed++
+		// increment failure counter if too many TaskRuns of the task's Matrix failed, otherwise increment
+		// skipped counter since the remaining TaskRuns of the task's Matrix will not be created,
+		// and skipped due to timeout counter if that is because of the pipeline, tasks, or finally timeout
+		case t.isStoppedBeforeAllRunsStarted(facts):
+			if t.hasTooManyFailedTaskRuns() {
+				s.Failed++
+				break
+			}
+			s.Skipped++
+			if t.isMatrixSchedulingTimedOut(facts) {
+				s.SkippedDueToTimeout++
+			}
 		// increment skipped and skipped due to timeout counters since the task was skipped due to the pipeline, tasks, or finally timeout being reached before the task was launched
 		case t.Skip(facts).SkippingReason == v1.PipelineTimedOutSkip ||
 			t.Skip(facts).SkippingReason == v1.TasksTimedOutSkip ||
@@ -33,6 +33,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	clock "k8s.io/utils/clock/testing"
 	"knative.dev/pkg/apis"
@@ -1398,6 +1399,159 @@ func TestDAGExecutionQueueSequentialChildPipelines(t *testing.T) {
 	}
 }
 
+// TestDAGExecutionQueueMatrixWithUnscheduledTaskRuns tests the DAGExecutionQueue function and the
+// status of a PipelineTask with a Matrix of which only some of the TaskRuns have been created.
+func TestDAGExecutionQueueMatrixWithUnscheduledTaskRuns(t *testing.T) {
+	tenMinutesAgo := now.Add(-10 * time.Minute)
+	fiveMinuteDuration := 5 * time.Minute
+	matrixedTask := func(taskRuns ...*v1.TaskRun) *ResolvedPipelineTask {
+		return &ResolvedPipelineTask{
+			PipelineTask: &v1.PipelineTask{
+				Name:    "matrixedtask",
+				TaskRef: &v1.TaskRef{Name: "task"},
+				Matrix: &v1.Matrix{
+					Params: v1.Params{{
+						Name:  "platform",
+						Value: v1.ParamValue{Type: v1.ParamTypeArray, ArrayVal: []string{"linux", "mac", "windows"}},
+					}},
+					MaxParallel: 2,
+				},
+			},
+			TaskRunNames: []string{"matrixedtask-0", "matrixedtask-1", "matrixedtask-2"},
+			TaskRuns:     taskRuns,
+			ResolvedTask: &resources.ResolvedTask{
+				TaskSpec: &task.Spec,
+			},
+		}
+	}
+	failedTask := ResolvedPipelineTask{
+		PipelineTask: &v1.PipelineTask{
+			Name:    "failedtask",
+			TaskRef: &v1.TaskRef{Name: "task"},
+		},
+		TaskRunNames: []string{"failedtask"},
+		TaskRuns:     []*v1.TaskRun{makeFailed(trs[0])},
+		ResolvedTask: &resources.ResolvedTask{
+			TaskSpec: &task.Spec,
+		},
+	}
+	oneFailure := intstr.FromInt32(1)
+
+	for _, tc := range []struct {
+		name          string
+		task          *ResolvedPipelineTask
+		strategy      *v1.MatrixStrategy
+		otherTasks    PipelineRunState
+		specStatus    v1.PipelineRunSpecStatus
+		timeoutsState PipelineRunTimeoutsState
+		wantQueued    bool
+		wantDone      bool
+		wantReason    string
+	}{{
+		name:       "taskruns running",
+		task:       matrixedTask(makeSucceeded(trs[0]), makeStarted(trs[1])),
+		wantQueued: true,
+		wantReason: v1.PipelineRunReasonRunning.String(),
+	}, {
+		name:       "taskruns succeeded",
+		task:       matrixedTask(makeSucceeded(trs[0]), makeSucceeded(trs[1])),
+		wantQueued: true,
+		wantReason: v1.PipelineRunReasonRunning.String(),
+	}, {
+		name:       "taskrun failed",
+		task:       matrixedTask(makeFailed(trs[0]), makeStarted(trs[1])),
+		wantQueued: true,
+		wantReason: v1.PipelineRunReasonRunning.String(),
+	}, {
+		name:       "taskrun failed and taskruns done",
+		task:       matrixedTask(makeFailed(trs[0]), makeSucceeded(trs[1])),
+		wantQueued: true,
+		wantReason: v1.PipelineRunReasonRunning.String(),
+	}, {
+		name:       "taskrun failed with fail fast",
+		task:       matrixedTask(makeFailed(trs[0]), makeStarted(trs[1])),
+		strategy:   &v1.MatrixStrategy{FailFast: true},
+		wantReason: v1.PipelineRunReasonRunning.String(),
+	}, {
+		name:       "taskrun failed and taskruns done with fail fast",
+		task:       matrixedTask(makeFailed(trs[0]), makeSucceeded(trs[1])),
+		strategy:   &v1.MatrixStrategy{FailFast: true},
+		wantDone:   true,
+		wantReason: v1.PipelineRunReasonFailed.String(),
+	}, {
+		name:       "allowed taskrun failure with fail fast",
+		task:       matrixedTask(makeFailed(trs[0]), makeSucceeded(trs[1])),
+		strategy:   &v1.MatrixStrategy{FailFast: true, AllowFailures: &oneFailure},
+		wantQueued: true,
+		wantReason: v1.PipelineRunReasonRunning.String(),
+	}, {
+		name:       "pipelinerun stopping",
+		task:       matrixedTask(makeSucceeded(trs[0]), makeSucceeded(trs[1])),
+		otherTasks: PipelineRunState{&failedTask},
+		wantDone:   true,
+		wantReason: v1.PipelineRunReasonFailed.String(),
+	}, {
+		name:       "pipelinerun gracefully cancelled",
+		task:       matrixedTask(makeSucceeded(trs[0]), makeSucceeded(trs[1])),
+		specStatus: v1.PipelineRunSpecStatusCancelledRunFinally,
+		wantDone:   true,
+		wantReason: v1.PipelineRunReasonCancelled.String(),
+	}, {
+		name:       "pipelinerun gracefully cancelled with taskruns running",
+		task:       matrixedTask(makeSucceeded(trs[0]), makeStarted(trs[1])),
+		specStatus: v1.PipelineRunSpecStatusCancelledRunFinally,
+		wantReason: v1.PipelineRunReasonCancelledRunningFinally.String(),
+	}, {
+		name: "pipelinerun tasks timeout reached",
+		task: matrixedTask(makeSucceeded(trs[0]), makeSucceeded(trs[1])),
+		timeoutsState: PipelineRunTimeoutsState{
+			StartTime:    &tenMinutesAgo,
+			TasksTimeout: &fiveMinuteDuration,
+		},
+		wantDone:   true,
+		wantReason: v1.PipelineRunReasonFailed.String(),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.task.PipelineTask.Matrix.Strategy = tc.strategy
+			state := append(PipelineRunState{tc.task}, tc.otherTasks...)
+			d, err := dagFromState(state)
+			if err != nil {
+				t.Fatalf("Unexpected error while building DAG for state %v: %v", state, err)
+			}
+			timeoutsState := tc.timeoutsState
+			timeoutsState.Clock = testClock
+			facts := PipelineRunFacts{
+				State:           state,
+				SpecStatus:      tc.specStatus,
+				TasksGraph:      d,
+				FinalTasksGraph: &dag.Graph{},
+				TimeoutsState:   timeoutsState,
+			}
+			queue, err := facts.DAGExecutionQueue()
+			if err != nil {
+				t.Fatalf("unexpected error getting DAG execution queue: %s", err)
+			}
+			if queued := len(queue) == 1 && queue[0] == tc.task; queued != tc.wantQueued {
+				t.Errorf("expected the matrixed task to be queued to be %t, but got queue %v", tc.wantQueued, queue.ToMap())
+			}
+			if done := tc.task.isDone(&facts); done != tc.wantDone {
+				t.Errorf("expected isDone to be %t but was %t", tc.wantDone, done)
+			}
+			if tc.task.isSuccessful() {
+				t.Error("expected the matrixed task with unscheduled TaskRuns not to be successful")
+			}
+			pr := &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "somepipelinerun"},
+				Spec:       v1.PipelineRunSpec{Status: tc.specStatus},
+			}
+			c := facts.GetPipelineConditionStatus(t.Context(), pr, zap.NewNop().Sugar(), testClock)
+			if c.Reason != tc.wantReason {
+				t.Errorf("expected the PipelineRun reason to be %q but was %q: %s", tc.wantReason, c.Reason, c.Message)
+			}
+		})
+	}
+}
+
 func TestPipelineRunState_CompletedOrSkippedDAGTasks(t *testing.T) {
 	largePipelineState := buildPipelineStateWithLargeDependencyGraph(t)
 	tcs := []struct {
@@ -4283,6 +4437,76 @@ func TestPipelineRunState_GetChildReferences(t *testing.T) {
 				}},
 			}},
 		},
+		{
+			name: "matrixed-task-with-strategy",
+			state: PipelineRunState{{
+				TaskRunNames: []string{"matrixed-task-run-0", "matrixed-task-run-1", "matrixed-task-run-2"},
+				PipelineTask: &v1.PipelineTask{
+					Name: "matrixed-task",
+					TaskRef: &v1.TaskRef{
+						Name:       "task",
+						Kind:       "Task",
+						APIVersion: "v1",
+					},
+					Matrix: &v1.Matrix{
+						Params: v1.Params{{
+							Name:  "foobar",
+							Value: v1.ParamValue{Type: v1.ParamTypeArray, ArrayVal: []string{"foo", "bar", "baz"}},
+						}},
+						Strategy: &v1.MatrixStrategy{FailFast: true},
+					},
+				},
+				TaskRuns: []*v1.TaskRun{{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1"},
+					ObjectMeta: metav1.ObjectMeta{Name: "matrixed-task-run-0"},
+					Status: v1.TaskRunStatus{Status: duckv1.Status{Conditions: duckv1.Conditions{{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionTrue,
+						Reason: "Succeeded",
+					}}}},
+				}, {
+					TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1"},
+					ObjectMeta: metav1.ObjectMeta{Name: "matrixed-task-run-1"},
+					Status: v1.TaskRunStatus{Status: duckv1.Status{Conditions: duckv1.Conditions{{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionFalse,
+						Reason: "Failed",
+					}}}},
+				}, {
+					TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1"},
+					ObjectMeta: metav1.ObjectMeta{Name: "matrixed-task-run-2"},
+					Status: v1.TaskRunStatus{Status: duckv1.Status{Conditions: duckv1.Conditions{{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionUnknown,
+						Reason: "Running",
+					}}}},
+				}},
+			}},
+			childRefs: []v1.ChildStatusReference{{
+				TypeMeta: runtime.TypeMeta{
+					APIVersion: "tekton.dev/v1",
+					Kind:       "TaskRun",
+				},
+				Name:             "matrixed-task-run-0",
+				PipelineTaskName: "matrixed-task",
+				Reason:           "Succeeded",
+			}, {
+				TypeMeta: runtime.TypeMeta{
+					APIVersion: "tekton.dev/v1",
+					Kind:       "TaskRun",
+				},
+				Name:             "matrixed-task-run-1",
+				PipelineTaskName: "matrixed-task",
+				Reason:           "Failed",
+			}, {
+				TypeMeta: runtime.TypeMeta{
+					APIVersion: "tekton.dev/v1",
+					Kind:       "TaskRun",
+				},
+				Name:             "matrixed-task-run-2",
+				PipelineTaskName: "matrixed-task",
+			}},
+		},
 		{
 			name: "unresolved-matrixed-custom-task",
 			state: PipelineRunState{{
@@ -0,0 +1,217 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+)
+
+// quotaTracker remembers the TaskRuns admitted by this controller whose pod
+// the informer has not observed yet, so that TaskRuns reconciled at the same
+// time cannot all be admitted.
+type quotaTracker struct {
+	mu       sync.Mutex
+	admitted map[types.NamespacedName]bool
+}
+
+// quotaState counts the TaskRuns running and waiting in each namespace.
+type quotaState struct {
+	running map[string]int
+	waiting map[string]int
+	total   int
+	// ahead is the number of TaskRuns waiting in the namespace of the TaskRun
+	// being admitted that were created before it.
+	ahead int
+}
+
+// isPendingQuota returns true if the TaskRun waits for the limits on running
+// TaskRuns.
+func isPendingQuota(tr *v1.TaskRun) bool {
+	cond := tr.Status.GetCondition(apis.ConditionSucceeded)
+	return cond != nil && cond.Reason == v1.TaskRunReasonPending.String()
+}
+
+// admitTaskRun returns true if the pod of the TaskRun may be created without
+// exceeding the limits on running TaskRuns. Otherwise it returns a message
+// explaining which limit the TaskRun waits for.
+func (c *Reconciler) admitTaskRun(ctx context.Context, tr *v1.TaskRun) (bool, string, error) {
+	cfg := config.FromContextOrDefaults(ctx).Scheduling
+	if cfg == nil || !cfg.IsEnabled() {
+		return true, "", nil
+	}
+
+	c.quota.mu.Lock()
+	defer c.quota.mu.Unlock()
+	state, err := c.quotaState(tr, cfg.MaxRunningTaskRuns > 0)
+	if err != nil {
+		return false, "", err
+	}
+
+	running := state.running[tr.Namespace] + state.ahead
+	if limit := cfg.NamespaceLimit(tr.Namespace); limit > 0 && running >= limit {
+		return false, fmt.Sprintf("TaskRun %q is pending, namespace %q has reached its limit of %d running TaskRuns", tr.Name, tr.Namespace, limit), nil
+	}
+	if total := cfg.MaxRunningTaskRuns; total > 0 {
+		if state.total+state.ahead >= total {
+			return false, fmt.Sprintf("TaskRun %q is pending, the cluster has reached its limit of %d running TaskRuns", tr.Name, total), nil
+		}
+		// Leave the free capacity to the namespaces below their fair share
+		// that have TaskRuns waiting for it.
+		shares := state.fairShares(cfg, tr.Namespace)
+		if running >= shares[tr.Namespace] {
+			for namespace, share := range shares {
+				limit := cfg.NamespaceLimit(namespace)
+				if namespace != tr.Namespace && state.waiting[namespace] > 0 && state.running[namespace] < share &&
+					(limit == 0 || state.running[namespace] < limit) {
+					return false, fmt.Sprintf("TaskRun %q is pending, namespace %q is using its fair share of %d running TaskRuns", tr.Name, tr.Namespace, shares[tr.Namespace]), nil
+				}
+			}
+		}
+	}
+	c.quota.admit(tr)
+	return true, "", nil
+}
+
+// quotaState counts the other TaskRuns running or admitted, and those waiting
+// to be admitted, in the namespace of the TaskRun or in all namespaces.
+// The caller must hold the lock of the quota tracker.
+func (c *Reconciler) quotaState(tr *v1.TaskRun, allNamespaces bool) (*quotaState, error) {
+	var trs []*v1.TaskRun
+	var err error
+	if allNamespaces {
+		trs, err = c.taskRunLister.List(labels.Everything())
+	} else {
+		trs, err = c.taskRunLister.TaskRuns(tr.Namespace).List(labels.Everything())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list TaskRuns: %w", err)
+	}
+
+	state := &quotaState{running: map[string]int{}, waiting: map[string]int{}}
+	seen := map[types.NamespacedName]bool{}
+	for _, other := range trs {
+		name := other.GetNamespacedName()
+		seen[name] = true
+		if other.Status.PodName != "" || other.IsDone() {
+			delete(c.quota.admitted, name)
+		}
+		if name == tr.GetNamespacedName() || other.IsDone() {
+			continue
+		}
+		switch {
+		case other.Status.PodName != "", c.quota.admitted[name]:
+			state.running[other.Namespace]++
+			state.total++
+		case isPendingQuota(other) && !other.IsCancelled():
+			state.waiting[other.Namespace]++
+			if other.Namespace == tr.Namespace && createdBefore(other, tr) {
+				state.ahead++
+			}
+		}
+	}
+	for name := range c.quota.admitted {
+		if (allNamespaces || name.Namespace == tr.Namespace) && !seen[name] {
+			delete(c.quota.admitted, name)
+		}
+	}
+	return state, nil
+}
+
+// fairShares returns the share of the running TaskRuns of each namespace with
+// TaskRuns running or waiting, in proportion to their weights.
+func (s *quotaState) fairShares(cfg *config.Scheduling, namespace string) map[string]int {
+	weights := map[string]int{namespace: cfg.NamespaceWeight(namespace)}
+	for _, counts := range []map[string]int{s.running, s.waiting} {
+		for ns := range counts {
+			weights[ns] = cfg.NamespaceWeight(ns)
+		}
+	}
+	sum := 0
+	for _, weight := range weights {
+		sum += weight
+	}
+	shares := make(map[string]int, len(weights))
+	for ns, weight := range weights {
+		shares[ns] = max(cfg.MaxRunningTaskRuns*weight/sum, 1)
+	}
+	return shares
+}
+
+// admit records that the TaskRun was admitted. The caller must hold the lock
+// of the quota tracker.
+func (t *quotaTracker) admit(tr *v1.TaskRun) {
+	if t.admitted == nil {
+		t.admitted = map[types.NamespacedName]bool{}
+	}
+	t.admitted[tr.GetNamespacedName()] = true
+}
+
+// createdBefore orders TaskRuns by creation time, then by name.
+func createdBefore(a, b *v1.TaskRun) bool {
+	if a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.Name < b.Name
+	}
+	return a.CreationTimestamp.Before(&b.CreationTimestamp)
+}
+
+// enqueuePendingTaskRuns returns an informer event handler enqueueing the
+// TaskRuns waiting for the limits on running TaskRuns when a TaskRun stops
+// running. Nothing is enqueued while no limit is configured in configStore.
+func (c *Reconciler) enqueuePendingTaskRuns(configStore *config.Store, enqueue func(any)) cache.ResourceEventHandler {
+	enqueuePending := func() {
+		if cfg := configStore.Load().Scheduling; cfg == nil || !cfg.IsEnabled() {
+			return
+		}
+		trs, err := c.taskRunLister.List(labels.Everything())
+		if err != nil {
+			return
+		}
+		for _, tr := range trs {
+			if isPendingQuota(tr) && !tr.IsDone() {
+				enqueue(tr)
+			}
+		}
+	}
+	return cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj any) {
+			oldTr, ok := oldObj.(*v1.TaskRun)
+			if !ok {
+				return
+			}
+			if tr, ok := newObj.(*v1.TaskRun); ok && !oldTr.IsDone() && tr.IsDone() {
+				enqueuePending()
+			}
+		},
+		DeleteFunc: func(obj any) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if tr, ok := obj.(*v1.TaskRun); ok && !tr.IsDone() {
+				enqueuePending()
+			}
+		},
+	}
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/system"
+)
+
+func quotaTaskRun(name, namespace string, created time.Duration) *v1.TaskRun {
+	return &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: metav1.NewTime(now.Add(-created)),
+		},
+		Spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{Name: "test-task"},
+		},
+	}
+}
+
+func runningTaskRun(tr *v1.TaskRun) *v1.TaskRun {
+	tr.Status.PodName = tr.Name + "-pod"
+	tr.Status.StartTime = &metav1.Time{Time: tr.CreationTimestamp.Add(time.Second)}
+	tr.Status.Status = duckv1.Status{Conditions: duckv1.Conditions{{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+		Reason: v1.TaskRunReasonRunning.String(),
+	}}}
+	return tr
+}
+
+func pendingTaskRun(tr *v1.TaskRun) *v1.TaskRun {
+	tr.Status.Status = duckv1.Status{Conditions: duckv1.Conditions{{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+		Reason: v1.TaskRunReasonPending.String(),
+	}}}
+	return tr
+}
+
+func succeededTaskRun(tr *v1.TaskRun) *v1.TaskRun {
+	runningTaskRun(tr).Status.Conditions[0].Status = corev1.ConditionTrue
+	tr.Status.Conditions[0].Reason = v1.TaskRunReasonSuccessful.String()
+	return tr
+}
+
+func TestReconcileTaskRunQuota(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		scheduling  map[string]string
+		existing    []*v1.TaskRun
+		wantPending string
+	}{{
+		name:     "no limits",
+		existing: []*v1.TaskRun{runningTaskRun(quotaTaskRun("running", "foo", time.Minute))},
+	}, {
+		name:       "below the namespace limit",
+		scheduling: map[string]string{"max-running-taskruns-per-namespace": "2"},
+		existing:   []*v1.TaskRun{runningTaskRun(quotaTaskRun("running", "foo", time.Minute))},
+	}, {
+		name:        "namespace limit reached",
+		scheduling:  map[string]string{"max-running-taskruns-per-namespace": "1"},
+		existing:    []*v1.TaskRun{runningTaskRun(quotaTaskRun("running", "foo", time.Minute))},
+		wantPending: `TaskRun "test-taskrun" is pending, namespace "foo" has reached its limit of 1 running TaskRuns`,
+	}, {
+		name:       "completed TaskRuns do not count",
+		scheduling: map[string]string{"max-running-taskruns-per-namespace": "1"},
+		existing:   []*v1.TaskRun{succeededTaskRun(quotaTaskRun("done", "foo", time.Minute))},
+	}, {
+		name:       "TaskRuns of other namespaces do not count",
+		scheduling: map[string]string{"max-running-taskruns-per-namespace": "1"},
+		existing:   []*v1.TaskRun{runningTaskRun(quotaTaskRun("running", "bar", time.Minute))},
+	}, {
+		name: "namespace override",
+		scheduling: map[string]string{
+			"max-running-taskruns-per-namespace": "1",
+			"namespace-max-running-taskruns.foo": "2",
+		},
+		existing: []*v1.TaskRun{runningTaskRun(quotaTaskRun("running", "foo", time.Minute))},
+	}, {
+		name:       "pending behind earlier TaskRuns",
+		scheduling: map[string]string{"max-running-taskruns-per-namespace": "2"},
+		existing: []*v1.TaskRun{
+			runningTaskRun(quotaTaskRun("running", "foo", time.Minute)),
+			pendingTaskRun(quotaTaskRun("earlier", "foo", 2*time.Second)),
+			pendingTaskRun(quotaTaskRun("later", "foo", 0)),
+		},
+		wantPending: `TaskRun "test-taskrun" is pending, namespace "foo" has reached its limit of 2 running TaskRuns`,
+	}, {
+		name:       "cluster limit reached",
+		scheduling: map[string]string{"max-running-taskruns": "2"},
+		existing: []*v1.TaskRun{
+			runningTaskRun(quotaTaskRun("running-foo", "foo", time.Minute)),
+			runningTaskRun(quotaTaskRun("running-bar", "bar", time.Minute)),
+		},
+		wantPending: `TaskRun "test-taskrun" is pending, the cluster has reached its limit of 2 running TaskRuns`,
+	}, {
+		name:       "fair share used while another namespace waits",
+		scheduling: map[string]string{"max-running-taskruns": "4"},
+		existing: []*v1.TaskRun{
+			runningTaskRun(quotaTaskRun("running-foo-1", "foo", time.Minute)),
+			runningTaskRun(quotaTaskRun("running-foo-2", "foo", time.Minute)),
+			runningTaskRun(quotaTaskRun("running-bar", "bar", time.Minute)),
+			pendingTaskRun(quotaTaskRun("pending-bar", "bar", time.Minute)),
+		},
+		wantPending: `TaskRun "test-taskrun" is pending, namespace "foo" is using its fair share of 2 running TaskRuns`,
+	}, {
+		name:       "fair share used while no other namespace waits",
+		scheduling: map[string]string{"max-running-taskruns": "4"},
+		existing: []*v1.TaskRun{
+			runningTaskRun(quotaTaskRun("running-foo-1", "foo", time.Minute)),
+			runningTaskRun(quotaTaskRun("running-foo-2", "foo", time.Minute)),
+			runningTaskRun(quotaTaskRun("running-bar", "bar", time.Minute)),
+		},
+	}, {
+		name: "weighted fair share",
+		scheduling: map[string]string{
+			"max-running-taskruns": "4",
+			"namespace-weight.foo": "3",
+		},
+		existing: []*v1.TaskRun{
+			runningTaskRun(quotaTaskRun("running-foo-1", "foo", time.Minute)),
+			runningTaskRun(quotaTaskRun("running-foo-2", "foo", time.Minute)),
+			runningTaskRun(quotaTaskRun("running-bar", "bar", time.Minute)),
+			pendingTaskRun(quotaTaskRun("pending-bar", "bar", time.Minute)),
+		},
+	}, {
+		name: "other namespace waits for its own limit",
+		scheduling: map[string]string{
+			"max-running-taskruns":               "4",
+			"namespace-max-running-taskruns.bar": "1",
+		},
+		existing: []*v1.TaskRun{
+			runningTaskRun(quotaTaskRun("running-foo-1", "foo", time.Minute)),
+			runningTaskRun(quotaTaskRun("running-foo-2", "foo", time.Minute)),
+			runningTaskRun(quotaTaskRun("running-bar", "bar", time.Minute)),
+			pendingTaskRun(quotaTaskRun("pending-bar", "bar", time.Minute)),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := quotaTaskRun("test-taskrun", "foo", time.Second)
+			d := test.Data{
+				TaskRuns: append(tc.existing, tr),
+				Tasks:    []*v1.Task{simpleTask},
+				ServiceAccounts: []*corev1.ServiceAccount{{
+					ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: tr.Namespace},
+				}},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetSchedulingConfigName(), Namespace: system.Namespace()},
+					Data:       tc.scheduling,
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			clients := testAssets.Clients
+
+			// Both outcomes requeue the TaskRun, either for its timeout or
+			// to check the limits again.
+			if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(tr)); err == nil {
+				t.Error("Wanted a wrapped requeue error, but got nil.")
+			}
+			reconciledRun, err := clients.Pipeline.TektonV1().TaskRuns(tr.Namespace).Get(testAssets.Ctx, tr.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("getting updated taskrun: %v", err)
+			}
+			pods, err := clients.Kube.CoreV1().Pods(tr.Namespace).List(testAssets.Ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("listing pods: %v", err)
+			}
+			condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+
+			if tc.wantPending == "" {
+				if len(pods.Items) != 1 {
+					t.Errorf("expected the pod of the TaskRun to be created, got %d pods", len(pods.Items))
+				}
+				if condition.Reason == v1.TaskRunReasonPending.String() {
+					t.Errorf("expected the TaskRun not to be pending, got condition %v", condition)
+				}
+				return
+			}
+			if len(pods.Items) != 0 {
+				t.Errorf("expected no pod to be created for a pending TaskRun, got %d pods", len(pods.Items))
+			}
+			if condition.Status != corev1.ConditionUnknown || condition.Reason != v1.TaskRunReasonPending.String() || condition.Message != tc.wantPending {
+				t.Errorf("expected the TaskRun to be pending with message %q, got condition %v", tc.wantPending, condition)
+			}
+			if reconciledRun.Status.StartTime != nil {
+				t.Errorf("expected a pending TaskRun not to have a start time, got %v", reconciledRun.Status.StartTime)
+			}
+		})
+	}
+}
+
+func TestReconcilePendingTaskRunEmitsStartedOnce(t *testing.T) {
+	tr := quotaTaskRun("test-taskrun", "foo", time.Second)
+	d := test.Data{
+		TaskRuns: []*v1.TaskRun{runningTaskRun(quotaTaskRun("running", "foo", time.Minute)), tr},
+		Tasks:    []*v1.Task{simpleTask},
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: tr.Namespace},
+		}},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetSchedulingConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{"max-running-taskruns-per-namespace": "1"},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	clients := testAssets.Clients
+
+	for i := range 2 {
+		if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(tr)); err == nil {
+			t.Errorf("reconcile %d: wanted a wrapped requeue error, but got nil", i)
+		}
+		reconciledRun, err := clients.Pipeline.TektonV1().TaskRuns(tr.Namespace).Get(testAssets.Ctx, tr.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("getting updated taskrun: %v", err)
+		}
+		if !isPendingQuota(reconciledRun) {
+			t.Fatalf("reconcile %d: expected the TaskRun to be pending, got condition %v", i, reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+		}
+		// Update the lister cache with the result of the reconcile.
+		if err := testAssets.Informers.TaskRun.Informer().GetIndexer().Update(reconciledRun); err != nil {
+			t.Fatalf("updating the informer: %v", err)
+		}
+	}
+
+	started := 0
+	for len(testAssets.Recorder.Events) > 0 {
+		if strings.HasPrefix(<-testAssets.Recorder.Events, "Normal Started") {
+			started++
+		}
+	}
+	if started != 1 {
+		t.Errorf("expected exactly 1 Started event, got %d", started)
+	}
+}
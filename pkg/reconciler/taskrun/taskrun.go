@@ -95,6 +95,7 @@ type Reconciler struct {
 	pvcHandler               volumeclaim.PvcHandler
 	resolutionRequester      resolution.Requester
 	tracerProvider           trace.TracerProvider
+	quota                    *quotaTracker
 }
 
 const (
@@ -137,9 +138,21 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1.TaskRun) pkgrecon
 	// Record the duration and count after the reconcile cycle.
 	defer c.durationAndCountMetrics(ctx, tr, before)
 
+	// Wait for the backoff of the RetryPolicy before starting the next attempt
+	// of a TaskRun that is retried.
+	if remaining := c.retryBackoff(tr); remaining > 0 {
+		logger.Infof("TaskRun %s is retried in %s", tr.GetNamespacedName().String(), remaining)
+		return controller.NewRequeueAfter(remaining)
+	}
+
 	// If the TaskRun is just starting, this will also set the starttime,
 	// from which the timeout will immediately begin counting down.
-	if !tr.HasStarted() {
+	// A TaskRun waiting for the limits on running TaskRuns was already started
+	// once and only gets its start time back, so that it keeps its Pending
+	// condition and the Started event is not emitted again.
+	if !tr.HasStarted() && isPendingQuota(tr) {
+		tr.Status.StartTime = &metav1.Time{Time: c.Clock.Now()}
+	} else if !tr.HasStarted() {
 		tr.Status.InitializeConditions()
 		// In case node time was not synchronized, when controller has been scheduled to other nodes.
 		if tr.Status.StartTime.Sub(tr.CreationTimestamp.Time) < 0 {
@@ -702,6 +715,17 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1.TaskRun, rtr *resourc
 	}
 
 	if pod == nil {
+		// Hold back TaskRuns exceeding the limits on running TaskRuns instead
+		// of letting their pod creation fail.
+		admitted, message, err := c.admitTaskRun(ctx, tr)
+		if err != nil {
+			return err
+		}
+		if !admitted {
+			tr.Status.StartTime = nil
+			tr.Status.MarkResourceOngoing(v1.TaskRunReasonPending, message)
+			return controller.NewRequeueAfter(time.Minute)
+		}
 		pod, err = c.createPod(ctx, ts, tr, rtr, workspaceVolumes)
 		if err != nil {
 			newErr := c.handlePodCreationError(tr, err)
@@ -1250,6 +1274,28 @@ func isConcurrentModificationError(err error) bool {
 	return strings.Contains(err.Error(), optimisticLockErrorMsg)
 }
 
+// retryBackoff returns how long to wait before starting the next attempt of
+// a TaskRun that is to be retried, according to the backoff of its
+// RetryPolicy.
+func (c *Reconciler) retryBackoff(tr *v1.TaskRun) time.Duration {
+	if tr.HasStarted() || tr.IsCancelled() || len(tr.Status.RetriesStatus) == 0 {
+		return 0
+	}
+	cond := tr.Status.GetCondition(apis.ConditionSucceeded)
+	if cond == nil || cond.Reason != v1.TaskRunReasonToBeRetried.String() {
+		return 0
+	}
+	delay := tr.Spec.RetryPolicy.Delay(len(tr.Status.RetriesStatus))
+	if delay == 0 {
+		return 0
+	}
+	failedAt := cond.LastTransitionTime.Inner.Time
+	if last := tr.Status.RetriesStatus[len(tr.Status.RetriesStatus)-1]; last.CompletionTime != nil {
+		failedAt = last.CompletionTime.Time
+	}
+	return failedAt.Add(delay).Sub(c.Clock.Now())
+}
+
 // retryTaskRun archives taskRun.Status to taskRun.Status.RetriesStatus, and set
 // taskRun status to Unknown with Reason v1.TaskRunReasonToBeRetried.
 func retryTaskRun(tr *v1.TaskRun, message string) {
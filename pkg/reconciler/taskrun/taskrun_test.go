@@ -1927,6 +1927,129 @@ status:
 	}
 }
 
+func TestReconcileRetryPolicy(t *testing.T) {
+	backingOffTaskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun-backing-off
+  namespace: foo
+spec:
+  retries: 2
+  retryPolicy:
+    backoff:
+      delay: 1m
+  taskRef:
+    name: test-task
+status:
+  conditions:
+  - reason: ToBeRetried
+    status: Unknown
+    type: Succeeded
+  retriesStatus:
+  - conditions:
+    - reason: TaskRunPodEvicted
+      status: "False"
+      type: Succeeded
+    completionTime: "2021-12-31T23:59:50Z"
+`)
+	backedOffTaskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun-backed-off
+  namespace: foo
+spec:
+  retries: 2
+  retryPolicy:
+    backoff:
+      delay: 1m
+  taskRef:
+    name: test-task
+status:
+  conditions:
+  - reason: ToBeRetried
+    status: Unknown
+    type: Succeeded
+  retriesStatus:
+  - conditions:
+    - reason: TaskRunPodEvicted
+      status: "False"
+      type: Succeeded
+    completionTime: "2021-12-31T23:58:00Z"
+`)
+	notMatchingTaskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun-not-matching
+  namespace: foo
+spec:
+  retries: 1
+  retryPolicy:
+    retryOn:
+    - reasons: [TaskRunPodEvicted]
+  taskRef:
+    name: test-task
+status:
+  startTime: "2021-12-31T23:59:59Z"
+  podName: test-taskrun-not-matching-pod
+  steps:
+  - container: step-unamed-0
+    name: unamed-0
+    waiting:
+      reason: "ImagePullBackOff"
+`)
+
+	for _, tc := range []struct {
+		name        string
+		tr          *v1.TaskRun
+		wantRequeue time.Duration
+		wantReason  string
+		wantStarted bool
+	}{{
+		name:        "wait for the backoff",
+		tr:          backingOffTaskRun,
+		wantRequeue: 50 * time.Second,
+		wantReason:  v1.TaskRunReasonToBeRetried.String(),
+	}, {
+		name:        "start after the backoff",
+		tr:          backedOffTaskRun,
+		wantReason:  v1.TaskRunReasonRunning.String(),
+		wantStarted: true,
+	}, {
+		name:        "no retry on failures not matching the retry conditions",
+		tr:          notMatchingTaskRun,
+		wantReason:  v1.TaskRunReasonImagePullFailed.String(),
+		wantStarted: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			testAssets, cancel := getTaskRunController(t, test.Data{
+				TaskRuns: []*v1.TaskRun{tc.tr},
+				Tasks:    []*v1.Task{simpleTask},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+					Data: map[string]string{
+						"enable-api-fields": config.AlphaAPIFields,
+					},
+				}},
+			})
+			defer cancel()
+			createServiceAccount(t, testAssets, "default", tc.tr.Namespace)
+
+			err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(tc.tr))
+			if ok, requeue := controller.IsRequeueKey(err); tc.wantRequeue > 0 && (!ok || requeue != tc.wantRequeue) {
+				t.Errorf("Reconcile(): %v, want requeue after %s", err, tc.wantRequeue)
+			}
+
+			reconciledTaskRun, err := testAssets.Clients.Pipeline.TektonV1().TaskRuns("foo").Get(testAssets.Ctx, tc.tr.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("got %v; want nil", err)
+			}
+			if got := reconciledTaskRun.Status.GetCondition(apis.ConditionSucceeded).Reason; got != tc.wantReason {
+				t.Errorf("got reason %q, want %q", got, tc.wantReason)
+			}
+			if started := reconciledTaskRun.HasStarted(); started != tc.wantStarted {
+				t.Errorf("got started %v, want %v", started, tc.wantStarted)
+			}
+		})
+	}
+}
+
 func TestReconcileGetTaskError(t *testing.T) {
 	tr := parse.MustParseV1TaskRun(t, `
 metadata:
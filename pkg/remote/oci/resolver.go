@@ -19,6 +19,7 @@ package oci
 import (
 	"archive/tar"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -29,9 +30,11 @@ import (
 	imgname "github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	ociremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
 	"github.com/tektoncd/pipeline/pkg/remote"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -44,6 +47,15 @@ const (
 	TitleAnnotation = "dev.tekton.image.name"
 	// MaximumBundleObjects defines the maximum number of objects in a bundle
 	MaximumBundleObjects = 20
+	// MaximumIndexBundles defines the maximum number of bundles in an image index
+	MaximumIndexBundles = 10
+
+	// ociEmptyMediaType is the media type of the empty config of OCI 1.1
+	// artifact manifests.
+	ociEmptyMediaType types.MediaType = "application/vnd.oci.empty.v1+json"
+	// dockerReferenceTypeAnnotation marks the manifests of image indexes
+	// that describe another manifest, like build attestations.
+	dockerReferenceTypeAnnotation = "vnd.docker.reference.type"
 )
 
 // Resolver implements the Resolver interface using OCI images.
@@ -63,27 +75,20 @@ func NewResolver(ref string, keychain authn.Keychain) remote.Resolver {
 func (o *Resolver) List(ctx context.Context) ([]remote.ResolvedObject, error) {
 	timeoutCtx, cancel := context.WithTimeout(ctx, o.timeout)
 	defer cancel()
-	img, err := o.retrieveImage(timeoutCtx)
+	images, err := o.retrieveImages(timeoutCtx)
 	if err != nil {
 		return nil, err
 	}
 
-	manifest, err := img.Manifest()
-	if err != nil {
-		return nil, fmt.Errorf("Could not parse image manifest: %w", err)
-	}
-
-	if err := o.checkImageCompliance(manifest); err != nil {
-		return nil, err
-	}
-
-	contents := make([]remote.ResolvedObject, 0, len(manifest.Layers))
-	for _, l := range manifest.Layers {
-		contents = append(contents, remote.ResolvedObject{
-			Kind:       l.Annotations[KindAnnotation],
-			APIVersion: l.Annotations[APIVersionAnnotation],
-			Name:       l.Annotations[TitleAnnotation],
-		})
+	var contents []remote.ResolvedObject
+	for _, img := range images {
+		layers, err := o.readLayers(img)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range layers {
+			contents = append(contents, l.ResolvedObject)
+		}
 	}
 
 	return contents, nil
@@ -93,62 +98,165 @@ func (o *Resolver) List(ctx context.Context) ([]remote.ResolvedObject, error) {
 func (o *Resolver) Get(ctx context.Context, kind, name string) (runtime.Object, *pipelinev1.RefSource, error) {
 	timeoutCtx, cancel := context.WithTimeout(ctx, o.timeout)
 	defer cancel()
-	img, err := o.retrieveImage(timeoutCtx)
+	images, err := o.retrieveImages(timeoutCtx)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	manifest, err := img.Manifest()
-	if err != nil {
-		return nil, nil, fmt.Errorf("could not parse image manifest: %w", err)
+	for _, img := range images {
+		layers, err := o.readLayers(img)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, l := range layers {
+			if kind == l.Kind && name == l.Name {
+				if l.obj != nil {
+					return l.obj, nil, nil
+				}
+				obj, err := readTarLayer(l.layer)
+				if err != nil {
+					// This could still be a raw layer so try to read it as that instead.
+					obj, err := readRawLayer(l.layer)
+					return obj, nil, err
+				}
+				return obj, nil, nil
+			}
+		}
 	}
+	return nil, nil, fmt.Errorf("could not find object in image with kind: %s and name: %s", kind, name)
+}
 
-	if err := o.checkImageCompliance(manifest); err != nil {
-		return nil, nil, err
-	}
+// bundleLayer is a layer of a bundle along with the object it describes.
+type bundleLayer struct {
+	remote.ResolvedObject
+	layer v1.Layer
+	// obj is the object held by the layer if it had to be read to identify it.
+	obj runtime.Object
+}
 
-	layers, err := img.Layers()
+// retrieveImages will fetch the bundle image or, if the reference is an image
+// index, the bundle images it groups.
+func (o *Resolver) retrieveImages(ctx context.Context) ([]v1.Image, error) {
+	imgRef, err := imgname.ParseReference(o.imageReference)
 	if err != nil {
-		return nil, nil, fmt.Errorf("could not read image layers: %w", err)
+		return nil, fmt.Errorf("%s is an unparseable image reference: %w", o.imageReference, err)
+	}
+	desc, err := ociremote.Get(imgRef, ociremote.WithAuthFromKeychain(o.keychain), ociremote.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if !desc.MediaType.IsIndex() {
+		img, err := desc.Image()
+		if err != nil {
+			return nil, err
+		}
+		return []v1.Image{img}, nil
 	}
 
-	layerMap := map[string]v1.Layer{}
-	for _, l := range layers {
-		digest, err := l.Digest()
+	idx, err := desc.ImageIndex()
+	if err != nil {
+		return nil, err
+	}
+	indexManifest, err := idx.IndexManifest()
+	if err != nil {
+		return nil, fmt.Errorf("could not parse image index: %w", err)
+	}
+	var images []v1.Image
+	for _, m := range indexManifest.Manifests {
+		if !m.MediaType.IsImage() || m.Annotations[dockerReferenceTypeAnnotation] == "attestation-manifest" {
+			continue
+		}
+		if len(images) == MaximumIndexBundles {
+			return nil, fmt.Errorf("image index %s contained more than the maximum %d allowed bundles", o.imageReference, MaximumIndexBundles)
+		}
+		img, err := idx.Image(m.Digest)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to find digest for layer: %w", err)
+			return nil, err
 		}
-		layerMap[digest.String()] = l
+		images = append(images, img)
 	}
+	return images, nil
+}
 
-	for idx, l := range manifest.Layers {
-		lKind := l.Annotations[KindAnnotation]
-		lName := l.Annotations[TitleAnnotation]
+// readLayers checks that img complies with the Tekton Bundle spec and returns
+// its layers. Layers of OCI 1.1 artifact manifests without annotations are
+// identified by the object they hold.
+func (o *Resolver) readLayers(img v1.Image) ([]bundleLayer, error) {
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, fmt.Errorf("could not parse image manifest: %w", err)
+	}
+	rawManifest, err := img.RawManifest()
+	if err != nil {
+		return nil, fmt.Errorf("could not read image manifest: %w", err)
+	}
+	artifact := isArtifactManifest(rawManifest, manifest)
+
+	if err := o.checkImageCompliance(manifest, artifact); err != nil {
+		return nil, err
+	}
+
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("could not read image layers: %w", err)
+	}
 
-		if kind == lKind && name == lName {
-			obj, err := readTarLayer(layerMap[l.Digest.String()])
+	bundleLayers := make([]bundleLayer, 0, len(manifest.Layers))
+	for idx, l := range manifest.Layers {
+		bl := bundleLayer{
+			ResolvedObject: remote.ResolvedObject{
+				Kind:       l.Annotations[KindAnnotation],
+				APIVersion: l.Annotations[APIVersionAnnotation],
+				Name:       l.Annotations[TitleAnnotation],
+			},
+			layer: layers[idx],
+		}
+		if artifact && !hasBundleAnnotations(l) {
+			obj, err := readTarLayer(layers[idx])
+			if err != nil {
+				obj, err = readRawLayer(layers[idx])
+			}
 			if err != nil {
-				// This could still be a raw layer so try to read it as that instead.
-				obj, err := readRawLayer(layers[idx])
-				return obj, nil, err
+				return nil, fmt.Errorf("invalid tekton bundle: %s:%s has no %s annotation and does not hold a Tekton resource: %w", o.imageReference, l.Digest, KindAnnotation, err)
 			}
-			return obj, nil, nil
+			gvk := obj.GetObjectKind().GroupVersionKind()
+			bl.Kind = strings.ToLower(gvk.Kind)
+			bl.APIVersion = gvk.Version
+			if m, ok := obj.(metav1.Object); ok {
+				bl.Name = m.GetName()
+			}
+			bl.obj = obj
 		}
+		bundleLayers = append(bundleLayers, bl)
 	}
-	return nil, nil, fmt.Errorf("could not find object in image with kind: %s and name: %s", kind, name)
+	return bundleLayers, nil
 }
 
-// retrieveImage will fetch the image's contents and manifest.
-func (o *Resolver) retrieveImage(ctx context.Context) (v1.Image, error) {
-	imgRef, err := imgname.ParseReference(o.imageReference)
-	if err != nil {
-		return nil, fmt.Errorf("%s is an unparseable image reference: %w", o.imageReference, err)
+// isArtifactManifest returns true if the manifest is an OCI 1.1 artifact
+// manifest, which declares an artifactType or has the empty config.
+func isArtifactManifest(rawManifest []byte, manifest *v1.Manifest) bool {
+	var m struct {
+		ArtifactType string `json:"artifactType"`
+	}
+	if err := json.Unmarshal(rawManifest, &m); err == nil && m.ArtifactType != "" {
+		return true
+	}
+	return manifest.Config.MediaType == ociEmptyMediaType
+}
+
+// hasBundleAnnotations returns true if the layer has any of the Tekton Bundle
+// annotations.
+func hasBundleAnnotations(l v1.Descriptor) bool {
+	for _, a := range []string{KindAnnotation, APIVersionAnnotation, TitleAnnotation} {
+		if _, ok := l.Annotations[a]; ok {
+			return true
+		}
 	}
-	return ociremote.Image(imgRef, ociremote.WithAuthFromKeychain(o.keychain), ociremote.WithContext(ctx))
+	return false
 }
 
 // checkImageCompliance will perform common checks to ensure the Tekton Bundle is compliant to our spec.
-func (o *Resolver) checkImageCompliance(manifest *v1.Manifest) error {
+func (o *Resolver) checkImageCompliance(manifest *v1.Manifest, artifact bool) error {
 	// Check the manifest's layers to ensure there are a maximum of 10.
 	if len(manifest.Layers) > MaximumBundleObjects {
 		return fmt.Errorf("bundle %s contained more than the maximum %d allow objects", o.imageReference, MaximumBundleObjects)
@@ -156,6 +264,10 @@ func (o *Resolver) checkImageCompliance(manifest *v1.Manifest) error {
 
 	// Ensure each layer complies to the spec.
 	for _, l := range manifest.Layers {
+		if artifact && !hasBundleAnnotations(l) {
+			continue
+		}
+
 		refDigest := fmt.Sprintf("%s:%s", o.imageReference, l.Digest.String())
 		if _, ok := l.Annotations[APIVersionAnnotation]; !ok {
 			return fmt.Errorf("invalid tekton bundle: %s does not contain a %s annotation", refDigest, APIVersionAnnotation)
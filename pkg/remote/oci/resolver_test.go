@@ -25,7 +25,12 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	ociremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/remote"
 	"github.com/tektoncd/pipeline/pkg/remote/oci"
@@ -201,3 +206,67 @@ func TestOCIResolver(t *testing.T) {
 		})
 	}
 }
+
+func TestOCIResolverImageIndex(t *testing.T) {
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	objs := []runtime.Object{
+		&v1beta1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "build"},
+			TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "Task"},
+		},
+		&v1beta1.Pipeline{
+			ObjectMeta: metav1.ObjectMeta{Name: "release"},
+			TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "Pipeline"},
+		},
+	}
+	var addenda []mutate.IndexAddendum
+	for _, obj := range objs {
+		ref, err := test.CreateImage(fmt.Sprintf("%s/testociindex/%s", u.Host, test.GetObjectName(obj)), obj)
+		if err != nil {
+			t.Fatalf("could not push image: %v", err)
+		}
+		imgRef, err := name.ParseReference(ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		img, err := ociremote.Image(imgRef)
+		if err != nil {
+			t.Fatalf("could not fetch image: %v", err)
+		}
+		addenda = append(addenda, mutate.IndexAddendum{Add: img})
+	}
+	indexRef, err := name.ParseReference(u.Host + "/testociindex:latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ociremote.WriteIndex(indexRef, mutate.AppendManifests(mutate.IndexMediaType(empty.Index, types.OCIImageIndex), addenda...)); err != nil {
+		t.Fatalf("could not push image index: %v", err)
+	}
+
+	resolver := oci.NewResolver(indexRef.String(), authn.DefaultKeychain)
+	listActual, err := resolver.List(t.Context())
+	if err != nil {
+		t.Fatalf("unexpected error listing contents of image index: %v", err)
+	}
+	listExpected := []remote.ResolvedObject{
+		{Kind: "task", APIVersion: "v1beta1", Name: "build"},
+		{Kind: "pipeline", APIVersion: "v1beta1", Name: "release"},
+	}
+	if d := cmp.Diff(listExpected, listActual); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+
+	actual, _, err := resolver.Get(t.Context(), "pipeline", "release")
+	if err != nil {
+		t.Fatalf("could not retrieve object from image index: %v", err)
+	}
+	if d := cmp.Diff(objs[1], actual); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
@@ -110,9 +110,17 @@ func (r *Resolver) Resolve(ctx context.Context, req *v1beta1.ResolutionRequestSp
 	}
 
 	if cache.ShouldUse(ctx, r, req.Params, LabelValueBundleResolverType) {
+		// Bundles are verified against the VerificationPolicies of the
+		// request namespace, so they must not be returned to requests from
+		// other namespaces.
+		cacheParams := req.Params
+		if bundleresolution.VerifySignatures(ctx) {
+			cacheParams = cache.WithKeyScope(req.Params, map[string]string{"namespace": resolutioncommon.RequestNamespace(ctx)})
+		}
 		return cache.GetFromCacheOrResolve(
 			ctx,
-			req.Params,
+			r,
+			cacheParams,
 			LabelValueBundleResolverType,
 			func() (resolutionframework.ResolvedResource, error) {
 				return r.resolveRequestFunc(ctx, r.kubeClientSet, req)
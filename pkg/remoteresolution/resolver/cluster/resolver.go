@@ -89,9 +89,16 @@ func (r *Resolver) IsImmutable([]v1.Param) bool {
 // Resolve uses the given params to resolve the requested file or resource.
 func (r *Resolver) Resolve(ctx context.Context, req *v1beta1.ResolutionRequestSpec) (resolutionframework.ResolvedResource, error) {
 	if cache.ShouldUse(ctx, r, req.Params, LabelValueClusterResolverType) {
+		// Grants are checked against the namespace of the request, so
+		// resources must not be returned to requests from other namespaces.
+		cacheParams := req.Params
+		if clusterresolution.EnforcesGrants(ctx) {
+			cacheParams = cache.WithKeyScope(req.Params, map[string]string{"namespace": resolutioncommon.RequestNamespace(ctx)})
+		}
 		return cache.GetFromCacheOrResolve(
 			ctx,
-			req.Params,
+			r,
+			cacheParams,
 			LabelValueClusterResolverType,
 			func() (resolutionframework.ResolvedResource, error) {
 				return clusterresolution.ResolveFromParams(ctx, req.Params, r.pipelineClientSet)
@@ -0,0 +1,177 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/resolution/common"
+	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+)
+
+// maxResponseSize is the largest response read from a backend. The resolved
+// data is base64 encoded in the response, so it is larger than the resource.
+const maxResponseSize = 2 * resolutionframework.MaxResolvedResourceSize
+
+// backend is a configured server implementing the external resolver
+// protocol.
+type backend struct {
+	name string
+	url  *url.URL
+}
+
+// invalidRequestError is returned when a backend rejects a request as
+// invalid.
+type invalidRequestError struct {
+	backend string
+	message string
+}
+
+func (e *invalidRequestError) Error() string {
+	return fmt.Sprintf("backend %s rejected the request: %s", e.backend, e.message)
+}
+
+// validate sends req to the validate endpoint of the backend. It returns an
+// invalidRequestError if the backend responds with a 4xx status. Backends
+// without a validate endpoint accept every request.
+func (b *backend) validate(ctx context.Context, req *Request) error {
+	resp, body, err := b.post(ctx, ValidatePath, req)
+	if err != nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNotFound, http.StatusMethodNotAllowed:
+		return nil
+	}
+	msg := errorMessage(resp, body)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+		return &invalidRequestError{backend: b.name, message: msg.Error}
+	}
+	return fmt.Errorf("backend %s failed to validate the request: %s", b.name, msg.Error)
+}
+
+// resolve sends req to the resolve endpoint of the backend. Errors with a
+// reason set by the backend are returned as a common.Error.
+func (b *backend) resolve(ctx context.Context, req *Request) (resolutionframework.ResolvedResource, error) {
+	resp, body, err := b.post(ctx, ResolvePath, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg := errorMessage(resp, body)
+		err := fmt.Errorf("backend %s failed to resolve the request: %s", b.name, msg.Error)
+		if msg.Reason != "" {
+			return nil, common.NewError(msg.Reason, err)
+		}
+		return nil, err
+	}
+	var rr ResolveResponse
+	if err := json.Unmarshal(body, &rr); err != nil {
+		return nil, fmt.Errorf("error parsing response of backend %s: %w", b.name, err)
+	}
+	if len(rr.Data) > resolutionframework.MaxResolvedResourceSize {
+		return nil, fmt.Errorf("resource resolved by backend %s is larger than %d bytes", b.name, resolutionframework.MaxResolvedResourceSize)
+	}
+	return &resolvedExternalResource{response: rr}, nil
+}
+
+// post sends req as JSON to the given endpoint of the backend and returns
+// the response along with its body.
+func (b *backend) post(ctx context.Context, path string, req *Request) (*http.Response, []byte, error) {
+	client, err := makeHttpClient(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	endpoint := b.url.JoinPath(path).String()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, nil, fmt.Errorf("constructing request to %s: %w", endpoint, err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error calling backend %s: %w", b.name, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading response of backend %s: %w", b.name, err)
+	}
+	if len(body) > maxResponseSize {
+		return nil, nil, fmt.Errorf("response of backend %s is larger than %d bytes", b.name, maxResponseSize)
+	}
+	return resp, body, nil
+}
+
+// errorMessage returns the ErrorResponse in body, or the status of resp if
+// the body is not one.
+func errorMessage(resp *http.Response, body []byte) ErrorResponse {
+	var msg ErrorResponse
+	if err := json.Unmarshal(body, &msg); err != nil || msg.Error == "" {
+		msg.Error = resp.Status
+	}
+	return msg
+}
+
+func makeHttpClient(ctx context.Context) (*http.Client, error) {
+	conf := resolutionframework.GetResolverConfigFromContext(ctx)
+	timeout, _ := time.ParseDuration(defaultTimeoutValue)
+	if v, ok := conf[TimeoutKey]; ok {
+		var err error
+		timeout, err = time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing timeout value %s: %w", v, err)
+		}
+	}
+	return &http.Client{Timeout: timeout}, nil
+}
+
+// resolvedExternalResource wraps the response of a backend.
+type resolvedExternalResource struct {
+	response ResolveResponse
+}
+
+var _ resolutionframework.ResolvedResource = &resolvedExternalResource{}
+
+// Data returns the resource resolved by the backend.
+func (rr *resolvedExternalResource) Data() []byte {
+	return rr.response.Data
+}
+
+// Annotations returns the annotations set by the backend.
+func (rr *resolvedExternalResource) Annotations() map[string]string {
+	return rr.response.Annotations
+}
+
+// RefSource returns the source reference set by the backend.
+func (rr *resolvedExternalResource) RefSource() *pipelinev1.RefSource {
+	return rr.response.RefSource
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+const (
+	// TimeoutKey is the configuration field name for controlling
+	// the maximum duration of a call to a backend.
+	TimeoutKey = "fetch-timeout"
+	// DefaultBackendKey is the configuration field name for the backend
+	// used by requests that do not specify one.
+	DefaultBackendKey = "default-backend"
+	// BackendKeyPrefix is the prefix of the configuration field names
+	// mapping the name of a backend to its base URL, e.g.
+	// "backend.catalog: http://catalog-resolver.tools.svc:8080".
+	BackendKeyPrefix = "backend."
+)
@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// The external resolver forwards requests to its backends as JSON over HTTP.
+// Backends implement two endpoints below their base URL:
+//
+//   - POST /validate checks the params of a request. Backends respond with
+//     200 OK if the request is valid and with a 4xx status and an
+//     ErrorResponse if it is not. The endpoint is optional, backends
+//     responding with 404 Not Found accept every request.
+//   - POST /resolve resolves a request. Backends respond with 200 OK and a
+//     ResolveResponse, or with an error status and an ErrorResponse.
+//
+// Both endpoints receive a Request as their body.
+const (
+	// ValidatePath is the path of the endpoint validating requests.
+	ValidatePath = "/validate"
+	// ResolvePath is the path of the endpoint resolving requests.
+	ResolvePath = "/resolve"
+)
+
+// Request is the body sent to both endpoints of a backend.
+type Request struct {
+	// Namespace is the namespace of the ResolutionRequest.
+	Namespace string `json:"namespace"`
+	// Name is the name of the ResolutionRequest.
+	Name string `json:"name"`
+	// Params are the params of the request, without the backend param.
+	Params []pipelinev1.Param `json:"params,omitempty"`
+	// URL is the url of the request, if any.
+	URL string `json:"url,omitempty"`
+}
+
+// ResolveResponse is the body of a successful response of the resolve
+// endpoint.
+type ResolveResponse struct {
+	// Data is the resolved resource, base64 encoded in JSON.
+	Data []byte `json:"data"`
+	// Annotations are added to the ResolutionRequest.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// RefSource records where the resource was resolved from for
+	// provenance.
+	RefSource *pipelinev1.RefSource `json:"refSource,omitempty"`
+}
+
+// ErrorResponse is the body of a response with an error status.
+type ErrorResponse struct {
+	// Error is a human readable description of the error.
+	Error string `json:"error"`
+	// Reason is an optional machine readable reason, which is set on the
+	// condition of the failed ResolutionRequest.
+	Reason string `json:"reason,omitempty"`
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	resolverconfig "github.com/tektoncd/pipeline/pkg/apis/config/resolver"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/framework"
+	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/framework/cache"
+	"github.com/tektoncd/pipeline/pkg/resolution/common"
+	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"go.uber.org/zap"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// LabelValueExternalResolverType is the value to use for the
+	// resolution.tekton.dev/type label on resource requests
+	LabelValueExternalResolverType = "external"
+	disabledError                  = "cannot handle resolution request, enable-external-resolver feature flag not true"
+	externalResolverName           = "External"
+	configMapName                  = "external-resolver-config"
+	defaultTimeoutValue            = "1m"
+)
+
+var _ framework.Resolver = (*Resolver)(nil)
+var _ resolutionframework.ConfigWatcher = (*Resolver)(nil)
+var _ cache.ImmutabilityChecker = (*Resolver)(nil)
+
+// Resolver implements a framework.Resolver that forwards requests to
+// resolvers running outside of the resolvers deployment, such as a sidecar
+// or a Service, using the protocol described in protocol.go.
+type Resolver struct {
+	logger *zap.SugaredLogger
+}
+
+// Initialize sets up the resolver's logger.
+func (r *Resolver) Initialize(ctx context.Context) error {
+	r.logger = logging.FromContext(ctx)
+	return nil
+}
+
+// GetName returns a string name to refer to this resolver by.
+func (r *Resolver) GetName(_ context.Context) string {
+	return externalResolverName
+}
+
+// GetConfigName returns the name of the external resolver's configmap.
+func (r *Resolver) GetConfigName(_ context.Context) string {
+	return configMapName
+}
+
+// GetSelector returns a map of labels to match requests to this resolver.
+func (r *Resolver) GetSelector(_ context.Context) map[string]string {
+	return map[string]string{
+		common.LabelKeyResolverType: LabelValueExternalResolverType,
+	}
+}
+
+// Validate ensures the request names a configured backend and forwards it to
+// the backend's validate endpoint. Backends that cannot be reached do not
+// fail validation, since validation errors are never retried; the request
+// is then validated by the resolve endpoint instead.
+func (r *Resolver) Validate(ctx context.Context, req *v1beta1.ResolutionRequestSpec) error {
+	if isDisabled(ctx) {
+		return errors.New(disabledError)
+	}
+	b, err := getBackend(ctx, req.Params)
+	if err != nil {
+		return err
+	}
+	err = b.validate(ctx, newRequest(ctx, req))
+	var invalid *invalidRequestError
+	if errors.As(err, &invalid) {
+		return err
+	}
+	if err != nil && r.logger != nil {
+		r.logger.Warnf("Could not validate request with backend %s, deferring to resolve: %v", b.name, err)
+	}
+	return nil
+}
+
+// IsImmutable implements ImmutabilityChecker.IsImmutable
+// Returns false, since the resolver cannot know whether a backend always
+// returns the same resource for the same params. Requests are only cached
+// with the "always" cache mode.
+func (r *Resolver) IsImmutable([]pipelinev1.Param) bool {
+	return false
+}
+
+// Resolve forwards the request to the resolve endpoint of its backend.
+func (r *Resolver) Resolve(ctx context.Context, req *v1beta1.ResolutionRequestSpec) (resolutionframework.ResolvedResource, error) {
+	if isDisabled(ctx) {
+		return nil, errors.New(disabledError)
+	}
+	b, err := getBackend(ctx, req.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	if cache.ShouldUse(ctx, r, req.Params, LabelValueExternalResolverType) {
+		// Backends may resolve requests with the credentials of their
+		// namespace, so resources are never shared between namespaces.
+		cacheParams := cache.WithKeyScope(req.Params, map[string]string{
+			"namespace": common.RequestNamespace(ctx),
+			"backend":   b.name,
+		})
+		return cache.GetFromCacheOrResolve(
+			ctx,
+			r,
+			cacheParams,
+			LabelValueExternalResolverType,
+			func() (resolutionframework.ResolvedResource, error) {
+				return b.resolve(ctx, newRequest(ctx, req))
+			},
+		)
+	}
+	return b.resolve(ctx, newRequest(ctx, req))
+}
+
+func isDisabled(ctx context.Context) bool {
+	cfg := resolverconfig.FromContextOrDefaults(ctx)
+	return !cfg.FeatureFlags.EnableExternalResolver
+}
+
+// getBackend returns the backend named by the params, or the default
+// backend if they do not name one. Only backends from the resolver
+// configuration can be used, so that requests cannot be sent to a server of
+// their choosing.
+func getBackend(ctx context.Context, params []pipelinev1.Param) (*backend, error) {
+	conf := resolutionframework.GetResolverConfigFromContext(ctx)
+	name := conf[DefaultBackendKey]
+	for _, p := range params {
+		if p.Name == BackendParam {
+			name = p.Value.StringVal
+		}
+	}
+	if name == "" {
+		return nil, fmt.Errorf("missing required external resolver param %s and no %s is configured", BackendParam, DefaultBackendKey)
+	}
+	rawURL, ok := conf[BackendKeyPrefix+name]
+	if !ok {
+		return nil, fmt.Errorf("unknown backend %s", name)
+	}
+	u, err := url.ParseRequestURI(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse url %s of backend %s: %w", rawURL, name, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("url %s of backend %s is not a valid http(s) url", rawURL, name)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	return &backend{name: name, url: u}, nil
+}
+
+// newRequest returns the body sent to a backend for req.
+func newRequest(ctx context.Context, req *v1beta1.ResolutionRequestSpec) *Request {
+	var params []pipelinev1.Param
+	for _, p := range req.Params {
+		if p.Name != BackendParam && p.Name != cache.CacheParam {
+			params = append(params, p)
+		}
+	}
+	return &Request{
+		Namespace: common.RequestNamespace(ctx),
+		Name:      common.RequestName(ctx),
+		Params:    params,
+		URL:       req.URL,
+	}
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/resolution/common"
+	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	frameworktesting "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+const sampleTask = `---
+kind: Task
+apiVersion: tekton.dev/v1
+metadata:
+  name: foo
+spec:
+  steps:
+  - name: step1
+    image: scratch`
+
+// fakeBackend is an in-process stand-in for a backend implementing the
+// external resolver protocol. It resolves the "task" param to the resource
+// of that name.
+type fakeBackend struct {
+	resources map[string]string
+	// noValidate makes the backend respond to validate requests with 404.
+	noValidate bool
+	// requests records the requests received by the resolve endpoint.
+	requests []Request
+}
+
+func (f *fakeBackend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+		writeError(w, http.StatusBadRequest, ErrorResponse{Error: "expected a JSON POST request"})
+		return
+	}
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	name := ""
+	for _, p := range req.Params {
+		if p.Name == "task" {
+			name = p.Value.StringVal
+		}
+	}
+	switch r.URL.Path {
+	case "/prefix" + ValidatePath:
+		if f.noValidate {
+			http.NotFound(w, r)
+			return
+		}
+		if name == "" {
+			writeError(w, http.StatusBadRequest, ErrorResponse{Error: "missing param task"})
+		}
+	case "/prefix" + ResolvePath:
+		f.requests = append(f.requests, req)
+		if name == "" {
+			writeError(w, http.StatusBadRequest, ErrorResponse{Error: "missing param task"})
+			return
+		}
+		resource, ok := f.resources[name]
+		if !ok {
+			writeError(w, http.StatusNotFound, ErrorResponse{Error: "task " + name + " not found", Reason: "TaskNotFound"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(ResolveResponse{
+			Data:        []byte(resource),
+			Annotations: map[string]string{"content-type": "application/x-yaml"},
+			RefSource:   &pipelinev1.RefSource{URI: "catalog://" + name},
+		})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, resp ErrorResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func TestGetSelector(t *testing.T) {
+	resolver := Resolver{}
+	sel := resolver.GetSelector(t.Context())
+	if typ, has := sel[common.LabelKeyResolverType]; !has {
+		t.Fatalf("unexpected selector: %v", sel)
+	} else if typ != LabelValueExternalResolverType {
+		t.Fatalf("unexpected type: %q", typ)
+	}
+}
+
+func TestGetName(t *testing.T) {
+	resolver := Resolver{}
+	ctx := t.Context()
+
+	if d := cmp.Diff(externalResolverName, resolver.GetName(ctx)); d != "" {
+		t.Errorf("invalid name: %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(configMapName, resolver.GetConfigName(ctx)); d != "" {
+		t.Errorf("invalid config map name: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	backend := &fakeBackend{}
+	svr := httptest.NewServer(backend)
+	defer svr.Close()
+	noValidate := httptest.NewServer(&fakeBackend{noValidate: true})
+	defer noValidate.Close()
+
+	for _, tc := range []struct {
+		name        string
+		conf        map[string]string
+		params      map[string]string
+		expectedErr string
+	}{{
+		name:   "valid",
+		conf:   map[string]string{DefaultBackendKey: "catalog", "backend.catalog": svr.URL + "/prefix"},
+		params: map[string]string{"task": "build"},
+	}, {
+		name:   "valid with backend param",
+		conf:   map[string]string{"backend.catalog": svr.URL + "/prefix/"},
+		params: map[string]string{BackendParam: "catalog", "task": "build"},
+	}, {
+		name:   "backend without validate endpoint",
+		conf:   map[string]string{DefaultBackendKey: "catalog", "backend.catalog": noValidate.URL + "/prefix"},
+		params: map[string]string{},
+	}, {
+		name:   "unreachable backend",
+		conf:   map[string]string{DefaultBackendKey: "catalog", "backend.catalog": "http://127.0.0.1:1"},
+		params: map[string]string{"task": "build"},
+	}, {
+		name:        "rejected by backend",
+		conf:        map[string]string{DefaultBackendKey: "catalog", "backend.catalog": svr.URL + "/prefix"},
+		params:      map[string]string{"other": "build"},
+		expectedErr: "backend catalog rejected the request: missing param task",
+	}, {
+		name:        "no backend",
+		conf:        map[string]string{"backend.catalog": svr.URL},
+		params:      map[string]string{"task": "build"},
+		expectedErr: "missing required external resolver param backend and no default-backend is configured",
+	}, {
+		name:        "unknown backend",
+		conf:        map[string]string{DefaultBackendKey: "catalog", "backend.catalog": svr.URL},
+		params:      map[string]string{BackendParam: "other", "task": "build"},
+		expectedErr: "unknown backend other",
+	}, {
+		name:        "backend url is not http",
+		conf:        map[string]string{DefaultBackendKey: "catalog", "backend.catalog": "ftp://catalog.example.com"},
+		params:      map[string]string{"task": "build"},
+		expectedErr: "url ftp://catalog.example.com of backend catalog is not a valid http(s) url",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := Resolver{}
+			ctx := frameworktesting.ContextWithExternalResolverEnabled(t.Context())
+			ctx = resolutionframework.InjectResolverConfigToContext(ctx, tc.conf)
+			err := resolver.Validate(ctx, &v1beta1.ResolutionRequestSpec{Params: toParams(tc.params)})
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error validating params: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expectedErr {
+				t.Fatalf("expected err '%v' but got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateDisabled(t *testing.T) {
+	resolver := Resolver{}
+	ctx := frameworktesting.ContextWithExternalResolverDisabled(t.Context())
+	err := resolver.Validate(ctx, &v1beta1.ResolutionRequestSpec{Params: toParams(map[string]string{"task": "build"})})
+	if err == nil || err.Error() != disabledError {
+		t.Fatalf("expected err '%v' but got '%v'", disabledError, err)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	backend := &fakeBackend{resources: map[string]string{
+		"build": sampleTask,
+		"large": strings.Repeat("a", resolutionframework.MaxResolvedResourceSize+1),
+	}}
+	svr := httptest.NewServer(backend)
+	defer svr.Close()
+	conf := map[string]string{DefaultBackendKey: "catalog", "backend.catalog": svr.URL + "/prefix"}
+
+	for _, tc := range []struct {
+		name           string
+		params         map[string]string
+		expectedErr    string
+		expectedReason string
+	}{{
+		name:   "resolved",
+		params: map[string]string{"task": "build", BackendParam: "catalog", "cache": "never"},
+	}, {
+		name:           "error with reason",
+		params:         map[string]string{"task": "missing"},
+		expectedErr:    "backend catalog failed to resolve the request: task missing not found",
+		expectedReason: "TaskNotFound",
+	}, {
+		name:           "error without reason",
+		params:         map[string]string{},
+		expectedErr:    "backend catalog failed to resolve the request: missing param task",
+		expectedReason: common.ReasonResolutionFailed,
+	}, {
+		name:           "too large",
+		params:         map[string]string{"task": "large"},
+		expectedErr:    "resource resolved by backend catalog is larger than 1048576 bytes",
+		expectedReason: common.ReasonResolutionFailed,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			backend.requests = nil
+			resolver := Resolver{}
+			ctx := frameworktesting.ContextWithExternalResolverEnabled(t.Context())
+			ctx = resolutionframework.InjectResolverConfigToContext(ctx, conf)
+			ctx = common.InjectRequestNamespace(ctx, "foo")
+			ctx = common.InjectRequestName(ctx, "rr")
+
+			output, err := resolver.Resolve(ctx, &v1beta1.ResolutionRequestSpec{Params: toParams(tc.params), URL: "https://example.com/run"})
+			if tc.expectedErr != "" {
+				if err == nil || err.Error() != tc.expectedErr {
+					t.Fatalf("expected err '%v' but got '%v'", tc.expectedErr, err)
+				}
+				if reason, _ := common.ReasonError(err); reason != tc.expectedReason {
+					t.Errorf("expected reason %q but got %q", tc.expectedReason, reason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error resolving: %v", err)
+			}
+			if d := cmp.Diff(sampleTask, string(output.Data())); d != "" {
+				t.Errorf("unexpected resource from Resolve: %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(map[string]string{"content-type": "application/x-yaml"}, output.Annotations()); d != "" {
+				t.Errorf("unexpected annotations from Resolve: %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(&pipelinev1.RefSource{URI: "catalog://build"}, output.RefSource()); d != "" {
+				t.Errorf("unexpected refsource from Resolve: %s", diff.PrintWantGot(d))
+			}
+			// The backend and cache params are not forwarded.
+			expectedRequests := []Request{{
+				Namespace: "foo",
+				Name:      "rr",
+				Params:    toParams(map[string]string{"task": "build"}),
+				URL:       "https://example.com/run",
+			}}
+			if d := cmp.Diff(expectedRequests, backend.requests); d != "" {
+				t.Errorf("unexpected requests to the backend: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestResolveDisabled(t *testing.T) {
+	resolver := Resolver{}
+	ctx := frameworktesting.ContextWithExternalResolverDisabled(t.Context())
+	_, err := resolver.Resolve(ctx, &v1beta1.ResolutionRequestSpec{Params: toParams(map[string]string{"task": "build"})})
+	if err == nil || err.Error() != disabledError {
+		t.Fatalf("expected err '%v' but got '%v'", disabledError, err)
+	}
+}
+
+func toParams(m map[string]string) []pipelinev1.Param {
+	var params []pipelinev1.Param
+
+	for k, v := range m {
+		params = append(params, pipelinev1.Param{
+			Name:  k,
+			Value: *pipelinev1.NewStructuredValues(v),
+		})
+	}
+
+	return params
+}
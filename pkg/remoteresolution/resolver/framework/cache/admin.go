@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	// AdminAddressEnv is the environment variable holding the address the
+	// cache admin endpoint listens on. The endpoint is disabled when unset.
+	AdminAddressEnv = "RESOLVER_CACHE_ADMIN_ADDRESS"
+	// adminEntriesPath is the path of the cache admin endpoint.
+	adminEntriesPath = "/cache/entries"
+	// resolverTypeQueryParam filters the entries listed or evicted by resolver type.
+	resolverTypeQueryParam = "resolverType"
+	// adminTimeout bounds reading requests and writing responses of the
+	// admin endpoint.
+	adminTimeout = 30 * time.Second
+)
+
+// evictionResponse is returned by the eviction requests of the admin endpoint.
+type evictionResponse struct {
+	Evicted int `json:"evicted"`
+}
+
+// NewAdminHandler returns an http.Handler to inspect and invalidate the
+// shared resolver cache:
+//
+//	GET    /cache/entries[?resolverType=<type>]  lists cached entries
+//	DELETE /cache/entries/<key>                  evicts a single entry
+//	DELETE /cache/entries?resolverType=<type>    evicts all entries of a resolver
+//	DELETE /cache/entries                        evicts all entries
+//
+// The cache is looked up on every request so that the handler always acts on
+// the cache configured by the current cache ConfigMap.
+func NewAdminHandler(ctx context.Context) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+adminEntriesPath, func(w http.ResponseWriter, r *http.Request) {
+		resolverType := r.URL.Query().Get(resolverTypeQueryParam)
+		entries := []Entry{}
+		for _, e := range Get(ctx).Entries() {
+			if resolverType == "" || e.ResolverType == resolverType {
+				entries = append(entries, e)
+			}
+		}
+		writeJSON(w, entries)
+	})
+
+	mux.HandleFunc("DELETE "+adminEntriesPath, func(w http.ResponseWriter, r *http.Request) {
+		cache := Get(ctx)
+		if resolverType := r.URL.Query().Get(resolverTypeQueryParam); resolverType != "" {
+			writeJSON(w, evictionResponse{Evicted: cache.RemoveByResolverType(resolverType)})
+			return
+		}
+		writeJSON(w, evictionResponse{Evicted: cache.Clear()})
+	})
+
+	mux.HandleFunc("DELETE "+adminEntriesPath+"/{key}", func(w http.ResponseWriter, r *http.Request) {
+		evicted := Get(ctx).RemoveByKey(r.PathValue("key"))
+		if evicted == 0 {
+			http.Error(w, "cache entry not found", http.StatusNotFound)
+			return
+		}
+		writeJSON(w, evictionResponse{Evicted: evicted})
+	})
+
+	return mux
+}
+
+// NewAdminServer returns a server serving the handler of NewAdminHandler on
+// addr. The endpoint is unauthenticated and can evict entries, so addr must
+// be a loopback address.
+func NewAdminServer(ctx context.Context, addr string) (*http.Server, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache admin address %q: %w", addr, err)
+	}
+	if !isLoopback(host) {
+		return nil, fmt.Errorf("cache admin address %q must be a loopback address", addr)
+	}
+	return &http.Server{
+		Addr:              addr,
+		Handler:           NewAdminHandler(ctx),
+		ReadHeaderTimeout: adminTimeout,
+		ReadTimeout:       adminTimeout,
+		WriteTimeout:      adminTimeout,
+		IdleTimeout:       2 * adminTimeout,
+	}, nil
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
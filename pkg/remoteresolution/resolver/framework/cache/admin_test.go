@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+func newAdminTestCache(t *testing.T) (*resolverCache, http.Handler) {
+	t.Helper()
+	testCache := newResolverCache(100, time.Hour)
+	for resolverType, values := range map[string][]string{
+		"git":    {"https://github.com/tektoncd/pipeline", "https://github.com/tektoncd/catalog"},
+		"bundle": {"registry.io/repo@sha256:abcdef"},
+	} {
+		for _, v := range values {
+			params := []pipelinev1.Param{{Name: "url", Value: pipelinev1.ParamValue{Type: pipelinev1.ParamTypeString, StringVal: v}}}
+			testCache.Add(resolverType, params, &mockResolvedResource{data: []byte(v)})
+		}
+	}
+	ctx := context.WithValue(t.Context(), resolverCacheKey{}, testCache)
+	return testCache, NewAdminHandler(ctx)
+}
+
+func serveAdmin(t *testing.T, handler http.Handler, method, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+	return rec
+}
+
+func TestAdminHandlerListEntries(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        string
+		expectedCount int
+	}{
+		{name: "all entries", target: "/cache/entries", expectedCount: 3},
+		{name: "filtered by resolver type", target: "/cache/entries?resolverType=git", expectedCount: 2},
+		{name: "unknown resolver type", target: "/cache/entries?resolverType=hub", expectedCount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, handler := newAdminTestCache(t)
+			rec := serveAdmin(t, handler, http.MethodGet, tt.target)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+			}
+
+			var entries []Entry
+			if err := json.Unmarshal(rec.Body.Bytes(), &entries); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(entries) != tt.expectedCount {
+				t.Fatalf("got %d entries, want %d", len(entries), tt.expectedCount)
+			}
+			for _, e := range entries {
+				if e.Size == 0 || e.InsertedAt.IsZero() || e.ResolverType == "" {
+					t.Errorf("entry is missing metadata: %+v", e)
+				}
+			}
+		})
+	}
+}
+
+func TestAdminHandlerEvict(t *testing.T) {
+	tests := []struct {
+		name            string
+		target          func(c *resolverCache) string
+		expectedStatus  int
+		expectedEvicted int
+		expectedLeft    int
+	}{
+		{
+			name: "by key",
+			target: func(c *resolverCache) string {
+				return "/cache/entries/" + c.Entries()[0].Key
+			},
+			expectedStatus:  http.StatusOK,
+			expectedEvicted: 1,
+			expectedLeft:    2,
+		},
+		{
+			name:           "unknown key",
+			target:         func(*resolverCache) string { return "/cache/entries/does-not-exist" },
+			expectedStatus: http.StatusNotFound,
+			expectedLeft:   3,
+		},
+		{
+			name:            "by resolver type",
+			target:          func(*resolverCache) string { return "/cache/entries?resolverType=git" },
+			expectedStatus:  http.StatusOK,
+			expectedEvicted: 2,
+			expectedLeft:    1,
+		},
+		{
+			name:            "all",
+			target:          func(*resolverCache) string { return "/cache/entries" },
+			expectedStatus:  http.StatusOK,
+			expectedEvicted: 3,
+			expectedLeft:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testCache, handler := newAdminTestCache(t)
+			rec := serveAdmin(t, handler, http.MethodDelete, tt.target(testCache))
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.expectedStatus)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var resp evictionResponse
+				if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				if resp.Evicted != tt.expectedEvicted {
+					t.Errorf("evicted = %d, want %d", resp.Evicted, tt.expectedEvicted)
+				}
+			}
+			if left := len(testCache.Entries()); left != tt.expectedLeft {
+				t.Errorf("%d entries left, want %d", left, tt.expectedLeft)
+			}
+		})
+	}
+}
+
+func TestNewAdminServer(t *testing.T) {
+	tests := []struct {
+		addr      string
+		expectErr bool
+	}{
+		{addr: "127.0.0.1:8081"},
+		{addr: "[::1]:8081"},
+		{addr: "localhost:8081"},
+		{addr: ":8081", expectErr: true},
+		{addr: "0.0.0.0:8081", expectErr: true},
+		{addr: "10.0.0.1:8081", expectErr: true},
+		{addr: "127.0.0.1", expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			server, err := NewAdminServer(t.Context(), tt.addr)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("Expected an error for address %q", tt.addr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewAdminServer() returned error: %v", err)
+			}
+			if server.Addr != tt.addr || server.ReadHeaderTimeout == 0 || server.WriteTimeout == 0 {
+				t.Errorf("Unexpected server %+v", server)
+			}
+		})
+	}
+}
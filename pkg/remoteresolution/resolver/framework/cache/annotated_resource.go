@@ -30,12 +30,18 @@ const (
 	cacheResolverTypeKey = "resolution.tekton.dev/cache-resolver-type"
 	// cacheOperationKey is the annotation key for the cache operation type
 	cacheOperationKey = "resolution.tekton.dev/cache-operation"
+	// cacheExpiresAtKey is the annotation key for when a revalidatable
+	// resource becomes stale and must be revalidated before it is used again
+	cacheExpiresAtKey = "resolution.tekton.dev/cache-expires-at"
 	// cacheValueTrue is the value used for cache annotations
 	cacheValueTrue = "true"
 	// cacheOperationStore is the value for cache store operations
 	cacheOperationStore = "store"
 	// cacheOperationRetrieve is the value for cache retrieve operations
 	cacheOperationRetrieve = "retrieve"
+	// cacheOperationRevalidate is the value for stale resources that the
+	// resolver confirmed to be unchanged
+	cacheOperationRevalidate = "revalidate"
 )
 
 // annotatedResource wraps a ResolvedResource with cache annotations
@@ -50,6 +56,12 @@ func newAnnotatedResource(
 	operation string,
 	timestamp string,
 ) *annotatedResource {
+	// Re-annotating a cached resource replaces its cache annotations rather
+	// than wrapping it again.
+	if cached, ok := resource.(*annotatedResource); ok {
+		resource = cached.resource
+	}
+
 	// Create a new map to avoid concurrent map writes when the same resource
 	// is being annotated from multiple goroutines
 	existingAnnotations := resource.Annotations()
@@ -58,6 +70,7 @@ func newAnnotatedResource(
 	for k, v := range existingAnnotations {
 		annotations[k] = v
 	}
+	delete(annotations, cacheExpiresAtKey)
 
 	annotations[cacheAnnotationKey] = cacheValueTrue
 	annotations[cacheTimestampKey] = timestamp
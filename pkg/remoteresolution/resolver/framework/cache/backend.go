@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"sync"
+	"time"
+
+	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+)
+
+const (
+	// backendMemory keeps cache entries in an in-process LRU cache. Entries
+	// are lost when the resolver process restarts.
+	backendMemory = "memory"
+	// backendDisk keeps cache entries in a content-addressed, size-bounded
+	// store on the local filesystem so they survive process restarts.
+	backendDisk = "disk"
+)
+
+// backend is the storage used by resolverCache. Keys are the hashed
+// (resolverType, params) keys produced by generateCacheKey.
+type backend interface {
+	// Get returns the resource stored under key, if present and not expired.
+	Get(key string) (resolutionframework.ResolvedResource, bool)
+	// Add stores resource under key for the given ttl.
+	Add(key string, resource resolutionframework.ResolvedResource, ttl time.Duration) error
+	// Remove deletes the entry stored under key, if any.
+	Remove(key string) error
+	// Clear deletes all entries.
+	Clear() error
+	// List describes every unexpired entry, ordered from least recently
+	// used to most recently used.
+	List() []backendEntry
+}
+
+// backendEntry describes a single entry returned by backend.List.
+type backendEntry struct {
+	key         string
+	size        int64
+	annotations map[string]string
+}
+
+var _ backend = (*memoryBackend)(nil)
+
+// memoryBackend is a backend that wraps utilcache.LRUExpireCache.
+type memoryBackend struct {
+	cache *utilcache.LRUExpireCache
+	clock utilcache.Clock
+
+	// stored tracks the entries added to cache, so that the entries it drops
+	// on its own can be counted as evictions.
+	mu     sync.Mutex
+	stored map[string]memoryEntry
+}
+
+// memoryEntry is what memoryBackend tracks about an entry of its cache.
+type memoryEntry struct {
+	resolverType string
+	expiresAt    time.Time
+}
+
+func newMemoryBackend(maxSize int, clock utilcache.Clock) *memoryBackend {
+	return &memoryBackend{
+		cache:  utilcache.NewLRUExpireCacheWithClock(maxSize, clock),
+		clock:  clock,
+		stored: map[string]memoryEntry{},
+	}
+}
+
+// Get implements backend.Get.
+func (m *memoryBackend) Get(key string) (resolutionframework.ResolvedResource, bool) {
+	value, found := m.cache.Get(key)
+	if !found {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		// The cache drops expired entries when they are read.
+		if entry, ok := m.stored[key]; ok && m.clock.Now().After(entry.expiresAt) {
+			delete(m.stored, key)
+			recordEvictions(entry.resolverType, evictionReasonTTL, 1)
+		}
+		return nil, false
+	}
+	resource, ok := value.(resolutionframework.ResolvedResource)
+	return resource, ok
+}
+
+// Add implements backend.Add.
+func (m *memoryBackend) Add(key string, resource resolutionframework.ResolvedResource, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.cache.Add(key, resource, ttl)
+	m.stored[key] = memoryEntry{
+		resolverType: resource.Annotations()[cacheResolverTypeKey],
+		expiresAt:    m.clock.Now().Add(ttl),
+	}
+	m.sweep()
+	return nil
+}
+
+// sweep counts and forgets the entries that expired or that the cache dropped
+// to make room for new ones. Callers must hold m.mu.
+func (m *memoryBackend) sweep() {
+	now := m.clock.Now()
+	present := map[any]bool{}
+	for _, key := range m.cache.Keys() {
+		present[key] = true
+	}
+	for key, entry := range m.stored {
+		switch {
+		case now.After(entry.expiresAt):
+			recordEvictions(entry.resolverType, evictionReasonTTL, 1)
+		case !present[key]:
+			recordEvictions(entry.resolverType, evictionReasonLRU, 1)
+		default:
+			continue
+		}
+		delete(m.stored, key)
+	}
+}
+
+// Remove implements backend.Remove.
+func (m *memoryBackend) Remove(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.cache.Remove(key)
+	delete(m.stored, key)
+	return nil
+}
+
+// Clear implements backend.Clear.
+func (m *memoryBackend) Clear() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// predicate that returns true clears all entries
+	m.cache.RemoveAll(func(_ any) bool { return true })
+	m.stored = map[string]memoryEntry{}
+	return nil
+}
+
+// List implements backend.List. Reading each entry refreshes its position in
+// the LRU order, but since entries are visited from least to most recently
+// used the relative order is preserved.
+func (m *memoryBackend) List() []backendEntry {
+	keys := m.cache.Keys()
+	entries := make([]backendEntry, 0, len(keys))
+	for _, k := range keys {
+		key, ok := k.(string)
+		if !ok {
+			continue
+		}
+		if resource, found := m.Get(key); found {
+			entries = append(entries, backendEntry{
+				key:         key,
+				size:        int64(len(resource.Data())),
+				annotations: resource.Annotations(),
+			})
+		}
+	}
+	return entries
+}
@@ -31,14 +31,19 @@ import (
 
 var _ resolutionframework.ConfigWatcher = (*resolverCache)(nil)
 
-// resolverCache is a wrapper around utilcache.LRUExpireCache that provides
+// resolverCache is a wrapper around a cache backend that provides
 // type-safe methods for caching resolver results.
 type resolverCache struct {
-	cache   *utilcache.LRUExpireCache
-	logger  *zap.SugaredLogger
-	ttl     time.Duration
-	maxSize int
-	clock   utilcache.Clock
+	cache        backend
+	backendType  string
+	logger       *zap.SugaredLogger
+	ttl          time.Duration
+	immutableTTL time.Duration
+	// revalidationTTL is how long revalidatable entries are kept after they
+	// become stale.
+	revalidationTTL time.Duration
+	maxSize         int
+	clock           utilcache.Clock
 }
 
 func newResolverCache(maxSize int, ttl time.Duration) *resolverCache {
@@ -46,11 +51,18 @@ func newResolverCache(maxSize int, ttl time.Duration) *resolverCache {
 }
 
 func newResolverCacheWithClock(maxSize int, ttl time.Duration, clock utilcache.Clock) *resolverCache {
+	return newResolverCacheWithBackend(newMemoryBackend(maxSize, clock), backendMemory, maxSize, ttl, clock)
+}
+
+func newResolverCacheWithBackend(b backend, backendType string, maxSize int, ttl time.Duration, clock utilcache.Clock) *resolverCache {
 	return &resolverCache{
-		cache:   utilcache.NewLRUExpireCacheWithClock(maxSize, clock),
-		ttl:     ttl,
-		maxSize: maxSize,
-		clock:   clock,
+		cache:           b,
+		backendType:     backendType,
+		ttl:             ttl,
+		immutableTTL:    defaultImmutableExpiration,
+		revalidationTTL: defaultRevalidationExpiration,
+		maxSize:         maxSize,
+		clock:           clock,
 	}
 }
 
@@ -62,7 +74,7 @@ func (c *resolverCache) GetConfigName(_ context.Context) string {
 // withLogger returns a new ResolverCache instance with the provided logger.
 // This prevents state leak by not storing logger in the global singleton.
 func (c *resolverCache) withLogger(logger *zap.SugaredLogger) *resolverCache {
-	return &resolverCache{logger: logger, cache: c.cache, ttl: c.ttl, maxSize: c.maxSize, clock: c.clock}
+	return &resolverCache{logger: logger, cache: c.cache, backendType: c.backendType, ttl: c.ttl, immutableTTL: c.immutableTTL, revalidationTTL: c.revalidationTTL, maxSize: c.maxSize, clock: c.clock}
 }
 
 // TTL returns the time-to-live duration for cache entries.
@@ -70,69 +82,217 @@ func (c *resolverCache) TTL() time.Duration {
 	return c.ttl
 }
 
+// ImmutableTTL returns the time-to-live duration for cache entries of
+// immutable references, such as commit SHAs and OCI digests.
+func (c *resolverCache) ImmutableTTL() time.Duration {
+	return c.immutableTTL
+}
+
+// RevalidationTTL returns how long entries stored with AddRevalidatable are
+// kept after they become stale, so that they can be revalidated.
+func (c *resolverCache) RevalidationTTL() time.Duration {
+	return c.revalidationTTL
+}
+
 // MaxSize returns the maximum number of entries the cache can hold.
 func (c *resolverCache) MaxSize() int {
 	return c.maxSize
 }
 
+// Backend returns the name of the storage backend used by the cache.
+func (c *resolverCache) Backend() string {
+	return c.backendType
+}
+
 // Get retrieves a cached resource by resolver type and parameters, returning
 // the resource and whether it was found.
 func (c *resolverCache) Get(resolverType string, params []pipelinev1.Param) (resolutionframework.ResolvedResource, bool) {
 	key := generateCacheKey(resolverType, params)
-	value, found := c.cache.Get(key)
-	if !found {
+	resource, found := c.cache.Get(key)
+	if !found || c.isStale(resource) {
 		c.infow("Cache miss", "key", key)
-		return nil, found
-	}
-
-	resource, ok := value.(resolutionframework.ResolvedResource)
-	if !ok {
-		c.infow("Failed casting cached resource", "key", key)
+		recordMiss(resolverType)
 		return nil, false
 	}
 
 	c.infow("Cache hit", "key", key)
+	recordHit(resolverType)
 	timestamp := c.clock.Now().Format(time.RFC3339)
 	return newAnnotatedResource(resource, resolverType, cacheOperationRetrieve, timestamp), true
 }
 
+// getStale returns the resource stored for resolverType and params even if it
+// is stale, along with whether it is stale. It does not record metrics.
+func (c *resolverCache) getStale(resolverType string, params []pipelinev1.Param) (resolutionframework.ResolvedResource, bool, bool) {
+	resource, found := c.cache.Get(generateCacheKey(resolverType, params))
+	if !found {
+		return nil, false, false
+	}
+	return resource, c.isStale(resource), true
+}
+
+// isStale returns true if resource was stored with AddRevalidatable and
+// its TTL has passed.
+func (c *resolverCache) isStale(resource resolutionframework.ResolvedResource) bool {
+	expiresAt, ok := resource.Annotations()[cacheExpiresAtKey]
+	if !ok {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339Nano, expiresAt)
+	return err != nil || !c.clock.Now().Before(t)
+}
+
 func (c *resolverCache) infow(msg string, keysAndValues ...any) {
 	if c.logger != nil {
 		c.logger.Infow(msg, keysAndValues...)
 	}
 }
 
+func (c *resolverCache) warnw(msg string, keysAndValues ...any) {
+	if c.logger != nil {
+		c.logger.Warnw(msg, keysAndValues...)
+	}
+}
+
 // Add stores a resource in the cache with the configured TTL and returns an
 // annotated version of the resource.
 func (c *resolverCache) Add(
 	resolverType string,
 	params []pipelinev1.Param,
 	resource resolutionframework.ResolvedResource,
+) resolutionframework.ResolvedResource {
+	return c.AddWithTTL(resolverType, params, resource, c.ttl)
+}
+
+// AddWithTTL stores a resource in the cache with the given TTL and returns an
+// annotated version of the resource.
+func (c *resolverCache) AddWithTTL(
+	resolverType string,
+	params []pipelinev1.Param,
+	resource resolutionframework.ResolvedResource,
+	ttl time.Duration,
 ) resolutionframework.ResolvedResource {
 	key := generateCacheKey(resolverType, params)
-	c.infow("Adding to cache", "key", key, "expiration", c.ttl)
+	c.infow("Adding to cache", "key", key, "expiration", ttl)
 
 	timestamp := c.clock.Now().Format(time.RFC3339)
 	annotatedResource := newAnnotatedResource(resource, resolverType, cacheOperationStore, timestamp)
 
-	c.cache.Add(key, annotatedResource, c.ttl)
+	if err := c.cache.Add(key, annotatedResource, ttl); err != nil {
+		c.warnw("Failed adding to cache", "key", key, "error", err)
+	}
+
+	return annotatedResource
+}
+
+// AddRevalidatable stores a resource that is served from the cache for ttl
+// and then kept for the revalidation TTL, during which it is stale and can be
+// revalidated with GetFromCacheOrRevalidate. It returns an annotated version
+// of the resource.
+func (c *resolverCache) AddRevalidatable(
+	resolverType string,
+	params []pipelinev1.Param,
+	resource resolutionframework.ResolvedResource,
+	ttl time.Duration,
+) resolutionframework.ResolvedResource {
+	key := generateCacheKey(resolverType, params)
+	c.infow("Adding revalidatable resource to cache", "key", key, "expiration", ttl, "revalidation", c.revalidationTTL)
+
+	now := c.clock.Now()
+	annotatedResource := newAnnotatedResource(resource, resolverType, cacheOperationStore, now.Format(time.RFC3339))
+	annotatedResource.annotations[cacheExpiresAtKey] = now.Add(ttl).Format(time.RFC3339Nano)
+
+	if err := c.cache.Add(key, annotatedResource, ttl+c.revalidationTTL); err != nil {
+		c.warnw("Failed adding to cache", "key", key, "error", err)
+	}
 
 	return annotatedResource
 }
 
 // Remove deletes a cached resource identified by resolver type and parameters.
 func (c *resolverCache) Remove(resolverType string, params []pipelinev1.Param) {
-	key := generateCacheKey(resolverType, params)
-	c.infow("Removing from cache", "key", key)
+	c.RemoveByKey(generateCacheKey(resolverType, params))
+}
 
-	c.cache.Remove(key)
+// RemoveByKey deletes the entry stored under key and returns the number of
+// entries removed.
+func (c *resolverCache) RemoveByKey(key string) int {
+	return c.evict(func(e Entry) bool { return e.Key == key })
 }
 
-// Clear removes all entries from the cache.
-func (c *resolverCache) Clear() {
+// RemoveByResolverType deletes all entries stored by the given resolver type
+// and returns the number of entries removed.
+func (c *resolverCache) RemoveByResolverType(resolverType string) int {
+	return c.evict(func(e Entry) bool { return e.ResolverType == resolverType })
+}
+
+// Clear removes all entries from the cache and returns the number of entries
+// removed.
+func (c *resolverCache) Clear() int {
 	c.infow("Clearing all cache entries")
-	// predicate that returns true clears all entries
-	c.cache.RemoveAll(func(_ any) bool { return true })
+	entries := c.Entries()
+	if err := c.cache.Clear(); err != nil {
+		c.warnw("Failed clearing cache", "error", err)
+	}
+
+	counts := map[string]int{}
+	for _, e := range entries {
+		counts[e.ResolverType]++
+	}
+	for resolverType, count := range counts {
+		recordInvalidations(resolverType, count)
+	}
+	return len(entries)
+}
+
+func (c *resolverCache) evict(match func(Entry) bool) int {
+	removed := 0
+	for _, e := range c.Entries() {
+		if !match(e) {
+			continue
+		}
+		c.infow("Removing from cache", "key", e.Key)
+		if err := c.cache.Remove(e.Key); err != nil {
+			c.warnw("Failed removing from cache", "key", e.Key, "error", err)
+			continue
+		}
+		recordInvalidations(e.ResolverType, 1)
+		removed++
+	}
+	return removed
+}
+
+// Entry describes a resource stored in the cache.
+type Entry struct {
+	// Key is the hash of the resolver type and parameters.
+	Key string `json:"key"`
+	// ResolverType is the type of the resolver that stored the entry.
+	ResolverType string `json:"resolverType"`
+	// Size is the size in bytes of the resolved data.
+	Size int64 `json:"size"`
+	// InsertedAt is when the entry was stored.
+	InsertedAt time.Time `json:"insertedAt"`
+	// Annotations are the annotations stored with the resolved data.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Entries returns all unexpired entries in the cache, ordered from least
+// recently used to most recently used.
+func (c *resolverCache) Entries() []Entry {
+	stored := c.cache.List()
+	entries := make([]Entry, 0, len(stored))
+	for _, s := range stored {
+		// Entries without a valid timestamp are reported with a zero InsertedAt.
+		insertedAt, _ := time.Parse(time.RFC3339, s.annotations[cacheTimestampKey])
+		entries = append(entries, Entry{
+			Key:          s.key,
+			ResolverType: s.annotations[cacheResolverTypeKey],
+			Size:         s.size,
+			InsertedAt:   insertedAt,
+			Annotations:  s.annotations,
+		})
+	}
+	return entries
 }
 
 func generateCacheKey(resolverType string, params []pipelinev1.Param) string {
@@ -18,12 +18,14 @@ package cache
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"strconv"
 	"sync"
 	"time"
 
 	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"knative.dev/pkg/configmap"
 )
 
@@ -33,11 +35,25 @@ const (
 	resolverCacheConfigMapNameEnv = "RESOLVER_CACHE_CONFIG_MAP_NAME"
 	// defaultConfigMapName is the default name of the ConfigMap that configures resolver cache settings
 	// the ConfigMap contains max-size and ttl configuration for the shared resolver cache
-	defaultConfigMapName = "resolver-cache-config"
-	maxSizeConfigMapKey  = "max-size"
-	ttlConfigMapKey      = "ttl"
-	defaultCacheSize     = 1000
-	defaultExpiration    = 5 * time.Minute
+	defaultConfigMapName        = "resolver-cache-config"
+	maxSizeConfigMapKey         = "max-size"
+	ttlConfigMapKey             = "ttl"
+	immutableTTLConfigMapKey    = "immutable-ttl"
+	revalidationTTLConfigMapKey = "revalidation-ttl"
+	backendConfigMapKey         = "backend"
+	diskPathConfigMapKey        = "disk-path"
+	diskMaxBytesConfigMapKey    = "disk-max-bytes"
+	defaultCacheSize            = 1000
+	defaultExpiration           = 5 * time.Minute
+	// defaultImmutableExpiration is long enough that entries of immutable
+	// references are only removed by size-based eviction.
+	defaultImmutableExpiration = 100 * 365 * 24 * time.Hour
+	// defaultRevalidationExpiration is how long stale entries of resolvers
+	// supporting conditional requests are kept for revalidation.
+	defaultRevalidationExpiration = 24 * time.Hour
+	defaultBackend                = backendMemory
+	defaultDiskPath               = "/tmp/resolver-cache"
+	defaultDiskMaxBytes           = 512 * 1024 * 1024
 )
 
 var (
@@ -61,7 +77,9 @@ func NewCacheConfigStore(cacheConfigName string, logger configmap.Logger) *Cache
 			configmap.Constructors{
 				getCacheConfigName(): resolutionframework.DataFromConfigMap,
 			},
-			onCacheConfigChanged,
+			func(name string, value any) {
+				onCacheConfigChanged(logger, name, value)
+			},
 		),
 	}
 }
@@ -99,7 +117,7 @@ func getCacheConfigName() string {
 	return defaultConfigMapName
 }
 
-func onCacheConfigChanged(_ string, value any) {
+func onCacheConfigChanged(logger configmap.Logger, _ string, value any) {
 	conf, ok := value.(map[string]string)
 	if !ok {
 		return
@@ -119,8 +137,75 @@ func onCacheConfigChanged(_ string, value any) {
 		}
 	}
 
+	immutableTTL := defaultImmutableExpiration
+	if immutableTTLStr, ok := conf[immutableTTLConfigMapKey]; ok {
+		if parsed, err := time.ParseDuration(immutableTTLStr); err == nil && parsed > 0 {
+			immutableTTL = parsed
+		}
+	}
+
+	revalidationTTL := defaultRevalidationExpiration
+	if revalidationTTLStr, ok := conf[revalidationTTLConfigMapKey]; ok {
+		if parsed, err := time.ParseDuration(revalidationTTLStr); err == nil && parsed >= 0 {
+			revalidationTTL = parsed
+		}
+	}
+
 	cacheMu.Lock()
 	defer cacheMu.Unlock()
 
-	sharedCache = newResolverCache(maxSize, ttl)
+	c, err := newConfiguredCache(logger, sharedCache, conf, maxSize, ttl)
+	if err != nil {
+		logger.Errorf("Invalid resolver cache configuration, keeping the current cache: %v", err)
+		return
+	}
+	sharedCache = c
+	sharedCache.immutableTTL = immutableTTL
+	sharedCache.revalidationTTL = revalidationTTL
+}
+
+// newConfiguredCache creates a cache using the backend selected in the cache
+// ConfigMap. If the disk backend cannot be initialized the in-memory backend
+// is used instead so that resolution keeps working. The disk backend of
+// current is reused if it is stored in the same directory, since two
+// backends sharing a directory would delete each other's blobs.
+func newConfiguredCache(logger configmap.Logger, current *resolverCache, conf map[string]string, maxSize int, ttl time.Duration) (*resolverCache, error) {
+	backendType := defaultBackend
+	if b, ok := conf[backendConfigMapKey]; ok && b != "" {
+		backendType = b
+	}
+
+	switch backendType {
+	case backendMemory:
+		return newResolverCache(maxSize, ttl), nil
+	case backendDisk:
+	default:
+		return nil, fmt.Errorf("unknown %s %q, must be %q or %q", backendConfigMapKey, backendType, backendMemory, backendDisk)
+	}
+
+	path := defaultDiskPath
+	if p, ok := conf[diskPathConfigMapKey]; ok && p != "" {
+		path = p
+	}
+
+	maxBytes := int64(defaultDiskMaxBytes)
+	if maxBytesStr, ok := conf[diskMaxBytesConfigMapKey]; ok {
+		if parsed, err := resource.ParseQuantity(maxBytesStr); err == nil && parsed.Value() > 0 {
+			maxBytes = parsed.Value()
+		}
+	}
+
+	if current != nil {
+		if disk, ok := current.cache.(*diskBackend); ok && disk.root == path {
+			disk.setLimits(maxSize, maxBytes)
+			return newResolverCacheWithBackend(disk, backendDisk, maxSize, ttl, realClock{}), nil
+		}
+	}
+
+	disk, err := newDiskBackend(path, maxSize, maxBytes, realClock{})
+	if err != nil {
+		logger.Errorf("Failed to use %s for the resolver cache, falling back to the memory backend: %v", path, err)
+		return newResolverCache(maxSize, ttl), nil
+	}
+	return newResolverCacheWithBackend(disk, backendDisk, maxSize, ttl, realClock{}), nil
 }
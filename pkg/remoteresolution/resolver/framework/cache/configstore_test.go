@@ -188,7 +188,7 @@ func TestOnCacheConfigMapChanged(t *testing.T) {
 				t.Fatalf("DataFromConfigMap() returned error: %v", err)
 			}
 
-			onCacheConfigChanged("test-config", data)
+			onCacheConfigChanged(logtesting.TestLogger(t), "test-config", data)
 			cache := Get(logtesting.TestContextWithLogger(t))
 
 			if cache.MaxSize() != tt.expectedMaxSize {
@@ -208,7 +208,7 @@ func TestOnCacheConfigChangedWithInvalidType(t *testing.T) {
 		"max-size": strconv.Itoa(defaultCacheSize),
 		"ttl":      defaultExpiration.String(),
 	}
-	onCacheConfigChanged("test-config", goodConfig)
+	onCacheConfigChanged(logtesting.TestLogger(t), "test-config", goodConfig)
 
 	ctx := logtesting.TestContextWithLogger(t)
 	cacheBefore := Get(ctx)
@@ -220,7 +220,7 @@ func TestOnCacheConfigChangedWithInvalidType(t *testing.T) {
 
 	// Test that onCacheConfigChanged handles invalid types gracefully
 	// This should not panic and should preserve the existing cache
-	onCacheConfigChanged("test-config", "invalid-type")
+	onCacheConfigChanged(logtesting.TestLogger(t), "test-config", "invalid-type")
 
 	// Verify we can still get the cache and it wasn't modified
 	cacheAfter := Get(ctx)
@@ -237,3 +237,191 @@ func TestOnCacheConfigChangedWithInvalidType(t *testing.T) {
 		t.Errorf("Expected MaxSize to remain %d after invalid config, got %d", maxSizeBefore, cacheAfter.MaxSize())
 	}
 }
+
+func TestOnCacheConfigChangedSelectsBackend(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name            string
+		conf            map[string]string
+		expectedBackend string
+	}{
+		{
+			name:            "no backend uses memory",
+			conf:            map[string]string{},
+			expectedBackend: backendMemory,
+		},
+		{
+			name:            "memory backend",
+			conf:            map[string]string{"backend": "memory"},
+			expectedBackend: backendMemory,
+		},
+		{
+			name: "disk backend",
+			conf: map[string]string{
+				"backend":        "disk",
+				"disk-path":      dir,
+				"disk-max-bytes": "10Mi",
+			},
+			expectedBackend: backendDisk,
+		},
+		{
+			name: "disk backend with unusable path falls back to memory",
+			conf: map[string]string{
+				"backend":   "disk",
+				"disk-path": "/dev/null/resolver-cache",
+			},
+			expectedBackend: backendMemory,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			onCacheConfigChanged(logtesting.TestLogger(t), "test-config", tt.conf)
+			cache := Get(logtesting.TestContextWithLogger(t))
+
+			if cache.Backend() != tt.expectedBackend {
+				t.Errorf("Backend = %q, want %q", cache.Backend(), tt.expectedBackend)
+			}
+		})
+	}
+
+	// Restore the default cache for other tests.
+	onCacheConfigChanged(logtesting.TestLogger(t), "test-config", map[string]string{})
+}
+
+func TestOnCacheConfigChangedImmutableTTL(t *testing.T) {
+	tests := []struct {
+		name                 string
+		conf                 map[string]string
+		expectedImmutableTTL time.Duration
+	}{
+		{
+			name:                 "no immutable-ttl never expires",
+			conf:                 map[string]string{},
+			expectedImmutableTTL: defaultImmutableExpiration,
+		},
+		{
+			name:                 "custom immutable-ttl",
+			conf:                 map[string]string{"immutable-ttl": "24h"},
+			expectedImmutableTTL: 24 * time.Hour,
+		},
+		{
+			name:                 "invalid immutable-ttl uses default",
+			conf:                 map[string]string{"immutable-ttl": "forever"},
+			expectedImmutableTTL: defaultImmutableExpiration,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			onCacheConfigChanged(logtesting.TestLogger(t), "test-config", tt.conf)
+			cache := Get(logtesting.TestContextWithLogger(t))
+
+			if cache.ImmutableTTL() != tt.expectedImmutableTTL {
+				t.Errorf("ImmutableTTL = %v, want %v", cache.ImmutableTTL(), tt.expectedImmutableTTL)
+			}
+		})
+	}
+
+	// Restore the default cache for other tests.
+	onCacheConfigChanged(logtesting.TestLogger(t), "test-config", map[string]string{})
+}
+
+func TestOnCacheConfigChangedRevalidationTTL(t *testing.T) {
+	tests := []struct {
+		name                    string
+		conf                    map[string]string
+		expectedRevalidationTTL time.Duration
+	}{
+		{
+			name:                    "default revalidation-ttl",
+			conf:                    map[string]string{},
+			expectedRevalidationTTL: defaultRevalidationExpiration,
+		},
+		{
+			name:                    "custom revalidation-ttl",
+			conf:                    map[string]string{"revalidation-ttl": "1h"},
+			expectedRevalidationTTL: time.Hour,
+		},
+		{
+			name:                    "revalidation disabled",
+			conf:                    map[string]string{"revalidation-ttl": "0s"},
+			expectedRevalidationTTL: 0,
+		},
+		{
+			name:                    "invalid revalidation-ttl uses default",
+			conf:                    map[string]string{"revalidation-ttl": "-1h"},
+			expectedRevalidationTTL: defaultRevalidationExpiration,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			onCacheConfigChanged(logtesting.TestLogger(t), "test-config", tt.conf)
+			cache := Get(logtesting.TestContextWithLogger(t))
+
+			if cache.RevalidationTTL() != tt.expectedRevalidationTTL {
+				t.Errorf("RevalidationTTL = %v, want %v", cache.RevalidationTTL(), tt.expectedRevalidationTTL)
+			}
+		})
+	}
+
+	// Restore the default cache for other tests.
+	onCacheConfigChanged(logtesting.TestLogger(t), "test-config", map[string]string{})
+}
+
+func TestOnCacheConfigChangedUnknownBackend(t *testing.T) {
+	onCacheConfigChanged(logtesting.TestLogger(t), "test-config", map[string]string{"ttl": "1h"})
+
+	// An unknown backend is rejected rather than silently using memory, so
+	// the cache configured before is kept.
+	onCacheConfigChanged(logtesting.TestLogger(t), "test-config", map[string]string{"backend": "redis", "ttl": "2h"})
+	cache := Get(logtesting.TestContextWithLogger(t))
+	if cache.TTL() != time.Hour {
+		t.Errorf("TTL = %v, want %v", cache.TTL(), time.Hour)
+	}
+
+	// Restore the default cache for other tests.
+	onCacheConfigChanged(logtesting.TestLogger(t), "test-config", map[string]string{})
+}
+
+func TestOnCacheConfigChangedReusesDiskBackend(t *testing.T) {
+	dir := t.TempDir()
+	onCacheConfigChanged(logtesting.TestLogger(t), "test-config", map[string]string{
+		"backend":        "disk",
+		"disk-path":      dir,
+		"disk-max-bytes": "10Mi",
+	})
+	before, ok := Get(logtesting.TestContextWithLogger(t)).cache.(*diskBackend)
+	if !ok {
+		t.Fatal("Expected a disk backend")
+	}
+
+	onCacheConfigChanged(logtesting.TestLogger(t), "test-config", map[string]string{
+		"backend":        "disk",
+		"disk-path":      dir,
+		"disk-max-bytes": "1Mi",
+		"max-size":       "10",
+	})
+	after, ok := Get(logtesting.TestContextWithLogger(t)).cache.(*diskBackend)
+	if !ok {
+		t.Fatal("Expected a disk backend")
+	}
+	if after != before {
+		t.Error("Expected the disk backend to be reused for the same disk-path")
+	}
+	if after.maxBytes != 1024*1024 || after.maxSize != 10 {
+		t.Errorf("Expected limits to be updated, got maxSize %d and maxBytes %d", after.maxSize, after.maxBytes)
+	}
+
+	onCacheConfigChanged(logtesting.TestLogger(t), "test-config", map[string]string{
+		"backend":   "disk",
+		"disk-path": t.TempDir(),
+	})
+	if other, _ := Get(logtesting.TestContextWithLogger(t)).cache.(*diskBackend); other == before {
+		t.Error("Expected a new disk backend for a different disk-path")
+	}
+
+	// Restore the default cache for other tests.
+	onCacheConfigChanged(logtesting.TestLogger(t), "test-config", map[string]string{})
+}
@@ -0,0 +1,426 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+)
+
+const (
+	// diskEntriesDir holds one metadata file per cache key.
+	diskEntriesDir = "entries"
+	// diskBlobsDir holds resolved data, named by the sha256 digest of its content.
+	diskBlobsDir = "blobs"
+	// diskEntrySuffix is the file extension of metadata files.
+	diskEntrySuffix = ".json"
+)
+
+var _ backend = (*diskBackend)(nil)
+
+// diskEntry is the metadata persisted for every cache key. The resolved
+// data itself is stored separately in a blob addressed by Digest so that
+// identical content resolved through different params is stored once.
+type diskEntry struct {
+	Digest      string                `json:"digest"`
+	Size        int64                 `json:"size"`
+	Annotations map[string]string     `json:"annotations,omitempty"`
+	RefSource   *pipelinev1.RefSource `json:"refSource,omitempty"`
+	ExpiresAt   time.Time             `json:"expiresAt"`
+
+	// lastAccess is only tracked in memory and used for LRU eviction.
+	lastAccess time.Time
+}
+
+// storedResource is a ResolvedResource read back from the disk backend.
+type storedResource struct {
+	data        []byte
+	annotations map[string]string
+	refSource   *pipelinev1.RefSource
+}
+
+// Data returns the bytes of the resource
+func (s *storedResource) Data() []byte {
+	return s.data
+}
+
+// Annotations returns the annotations stored with the resource
+func (s *storedResource) Annotations() map[string]string {
+	return s.annotations
+}
+
+// RefSource returns the source reference of the remote data
+func (s *storedResource) RefSource() *pipelinev1.RefSource {
+	return s.refSource
+}
+
+// diskBackend is a backend that persists entries on the local filesystem.
+// It is bounded both by the number of entries and by the total size of the
+// stored blobs; when either bound is exceeded the least recently used
+// entries are evicted.
+type diskBackend struct {
+	mu       sync.Mutex
+	root     string
+	maxSize  int
+	maxBytes int64
+	clock    utilcache.Clock
+
+	entries   map[string]*diskEntry
+	blobRefs  map[string]int
+	blobBytes int64
+}
+
+// newDiskBackend creates a disk backend rooted at root, loading any entries
+// left behind by a previous process. Expired entries and entries whose blob
+// is missing or corrupt are discarded while loading.
+func newDiskBackend(root string, maxSize int, maxBytes int64, clock utilcache.Clock) (*diskBackend, error) {
+	for _, dir := range []string{diskEntriesDir, diskBlobsDir} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o700); err != nil {
+			return nil, fmt.Errorf("creating resolver cache directory: %w", err)
+		}
+	}
+
+	d := &diskBackend{
+		root:     root,
+		maxSize:  maxSize,
+		maxBytes: maxBytes,
+		clock:    clock,
+		entries:  map[string]*diskEntry{},
+		blobRefs: map[string]int{},
+	}
+	if err := d.load(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// setLimits changes the bounds of the backend, evicting entries if it no
+// longer fits within them.
+func (d *diskBackend) setLimits(maxSize int, maxBytes int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.maxSize = maxSize
+	d.maxBytes = maxBytes
+	d.evict()
+}
+
+func (d *diskBackend) load() error {
+	files, err := os.ReadDir(filepath.Join(d.root, diskEntriesDir))
+	if err != nil {
+		return fmt.Errorf("reading resolver cache directory: %w", err)
+	}
+
+	now := d.clock.Now()
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() {
+			continue
+		}
+		if !strings.HasSuffix(name, diskEntrySuffix) {
+			// Leftover temporary file from an interrupted write.
+			_ = os.Remove(filepath.Join(d.root, diskEntriesDir, name))
+			continue
+		}
+		key := strings.TrimSuffix(name, diskEntrySuffix)
+
+		entry, err := d.readEntry(key)
+		if err != nil || !now.Before(entry.ExpiresAt) || !d.blobExists(entry.Digest) {
+			_ = os.Remove(d.entryPath(key))
+			continue
+		}
+		if info, err := f.Info(); err == nil {
+			entry.lastAccess = info.ModTime()
+		}
+		d.track(key, entry)
+	}
+
+	d.removeUnreferencedBlobs()
+	d.evict()
+	return nil
+}
+
+// Get implements backend.Get.
+func (d *diskBackend) Get(key string) (resolutionframework.ResolvedResource, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entry, ok := d.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	now := d.clock.Now()
+	if !now.Before(entry.ExpiresAt) {
+		d.evictEntry(key, evictionReasonTTL)
+		return nil, false
+	}
+
+	data, err := os.ReadFile(d.blobPath(entry.Digest))
+	if err != nil || digestOf(data) != entry.Digest {
+		// The blob disappeared or was tampered with; drop the entry so
+		// that the resource is resolved again.
+		_ = d.remove(key)
+		return nil, false
+	}
+
+	entry.lastAccess = now
+	_ = os.Chtimes(d.entryPath(key), now, now)
+
+	return &storedResource{
+		data:        data,
+		annotations: entry.Annotations,
+		refSource:   entry.RefSource,
+	}, true
+}
+
+// Add implements backend.Add.
+func (d *diskBackend) Add(key string, resource resolutionframework.ResolvedResource, ttl time.Duration) error {
+	data := resource.Data()
+	now := d.clock.Now()
+	entry := &diskEntry{
+		Digest:      digestOf(data),
+		Size:        int64(len(data)),
+		Annotations: resource.Annotations(),
+		RefSource:   resource.RefSource(),
+		ExpiresAt:   now.Add(ttl),
+		lastAccess:  now,
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := d.remove(key); err != nil {
+		return err
+	}
+	if !d.blobExists(entry.Digest) {
+		if err := writeFileAtomic(d.blobPath(entry.Digest), data); err != nil {
+			return fmt.Errorf("writing resolver cache blob: %w", err)
+		}
+	}
+
+	meta, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("serializing resolver cache entry: %w", err)
+	}
+	if err := writeFileAtomic(d.entryPath(key), meta); err != nil {
+		return fmt.Errorf("writing resolver cache entry: %w", err)
+	}
+
+	d.track(key, entry)
+	d.evict()
+	return nil
+}
+
+// Remove implements backend.Remove.
+func (d *diskBackend) Remove(key string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.remove(key)
+}
+
+// Clear implements backend.Clear.
+func (d *diskBackend) Clear() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var errs []error
+	for key := range d.entries {
+		errs = append(errs, d.remove(key))
+	}
+	return errors.Join(errs...)
+}
+
+// List implements backend.List. Only the in-memory entry metadata is used,
+// no blobs are read.
+func (d *diskBackend) List() []backendEntry {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.clock.Now()
+	keys := make([]string, 0, len(d.entries))
+	for key, entry := range d.entries {
+		if now.Before(entry.ExpiresAt) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return d.entries[keys[i]].lastAccess.Before(d.entries[keys[j]].lastAccess)
+	})
+
+	entries := make([]backendEntry, 0, len(keys))
+	for _, key := range keys {
+		entry := d.entries[key]
+		entries = append(entries, backendEntry{
+			key:         key,
+			size:        entry.Size,
+			annotations: entry.Annotations,
+		})
+	}
+	return entries
+}
+
+// remove deletes key and, if no other entry references it, its blob.
+// Callers must hold d.mu.
+func (d *diskBackend) remove(key string) error {
+	if _, ok := d.entries[key]; !ok {
+		return nil
+	}
+	digest := d.untrack(key)
+
+	var errs []error
+	if err := os.Remove(d.entryPath(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		errs = append(errs, err)
+	}
+	if d.blobRefs[digest] == 0 {
+		delete(d.blobRefs, digest)
+		if err := os.Remove(d.blobPath(digest)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// evict removes expired entries and then least recently used entries until
+// the backend is within its entry and byte bounds. Callers must hold d.mu.
+func (d *diskBackend) evict() {
+	now := d.clock.Now()
+	for key, entry := range d.entries {
+		if !now.Before(entry.ExpiresAt) {
+			d.evictEntry(key, evictionReasonTTL)
+		}
+	}
+
+	if len(d.entries) <= d.maxSize && d.blobBytes <= d.maxBytes {
+		return
+	}
+
+	keys := make([]string, 0, len(d.entries))
+	for key := range d.entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return d.entries[keys[i]].lastAccess.Before(d.entries[keys[j]].lastAccess)
+	})
+	for _, key := range keys {
+		if len(d.entries) <= d.maxSize && d.blobBytes <= d.maxBytes {
+			return
+		}
+		d.evictEntry(key, evictionReasonLRU)
+	}
+}
+
+// evictEntry removes key and counts it as evicted for reason. Callers must
+// hold d.mu.
+func (d *diskBackend) evictEntry(key, reason string) {
+	recordEvictions(d.entries[key].Annotations[cacheResolverTypeKey], reason, 1)
+	_ = d.remove(key)
+}
+
+func (d *diskBackend) track(key string, entry *diskEntry) {
+	d.entries[key] = entry
+	if d.blobRefs[entry.Digest] == 0 {
+		d.blobBytes += entry.Size
+	}
+	d.blobRefs[entry.Digest]++
+}
+
+func (d *diskBackend) untrack(key string) string {
+	entry := d.entries[key]
+	delete(d.entries, key)
+	d.blobRefs[entry.Digest]--
+	if d.blobRefs[entry.Digest] == 0 {
+		d.blobBytes -= entry.Size
+	}
+	return entry.Digest
+}
+
+func (d *diskBackend) removeUnreferencedBlobs() {
+	files, err := os.ReadDir(filepath.Join(d.root, diskBlobsDir))
+	if err != nil {
+		return
+	}
+	for _, f := range files {
+		if d.blobRefs[f.Name()] == 0 {
+			_ = os.Remove(filepath.Join(d.root, diskBlobsDir, f.Name()))
+		}
+	}
+}
+
+func (d *diskBackend) readEntry(key string) (*diskEntry, error) {
+	b, err := os.ReadFile(d.entryPath(key))
+	if err != nil {
+		return nil, err
+	}
+	entry := &diskEntry{}
+	if err := json.Unmarshal(b, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (d *diskBackend) blobExists(digest string) bool {
+	_, err := os.Stat(d.blobPath(digest))
+	return err == nil
+}
+
+func (d *diskBackend) entryPath(key string) string {
+	return filepath.Join(d.root, diskEntriesDir, key+diskEntrySuffix)
+}
+
+func (d *diskBackend) blobPath(digest string) string {
+	return filepath.Join(d.root, diskBlobsDir, digest)
+}
+
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it
+// into place so that readers never observe a partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestDiskBackendSurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+	fc := &fakeClock{now: time.Now()}
+	params := []pipelinev1.Param{
+		{Name: "bundle", Value: pipelinev1.ParamValue{Type: pipelinev1.ParamTypeString, StringVal: "registry.io/repo@sha256:abcdef"}},
+	}
+	resource := &mockResolvedResource{
+		data:        []byte("task data"),
+		annotations: map[string]string{"foo": "bar"},
+		refSource: &pipelinev1.RefSource{
+			URI:    "registry.io/repo",
+			Digest: map[string]string{"sha256": "abcdef"},
+		},
+	}
+
+	disk, err := newDiskBackend(dir, 10, 1024, fc)
+	if err != nil {
+		t.Fatalf("newDiskBackend() returned error: %v", err)
+	}
+	cache := newResolverCacheWithBackend(disk, backendDisk, 10, time.Hour, fc).withLogger(zaptest.NewLogger(t).Sugar())
+	cache.Add("bundle", params, resource)
+
+	// A new backend on the same directory simulates a process restart.
+	restarted, err := newDiskBackend(dir, 10, 1024, fc)
+	if err != nil {
+		t.Fatalf("newDiskBackend() returned error: %v", err)
+	}
+	cache = newResolverCacheWithBackend(restarted, backendDisk, 10, time.Hour, fc)
+
+	cached, ok := cache.Get("bundle", params)
+	if !ok {
+		t.Fatal("Expected cache hit after restart, but got cache miss")
+	}
+	if string(cached.Data()) != "task data" {
+		t.Errorf("Data = %q, want %q", cached.Data(), "task data")
+	}
+	if cached.Annotations()["foo"] != "bar" {
+		t.Errorf("Expected original annotations to be preserved, got %v", cached.Annotations())
+	}
+	if cached.Annotations()[cacheOperationKey] != cacheOperationRetrieve {
+		t.Errorf("Expected cache operation %q, got %q", cacheOperationRetrieve, cached.Annotations()[cacheOperationKey])
+	}
+	if d := cmp.Diff(resource.refSource, cached.RefSource()); d != "" {
+		t.Errorf("RefSource mismatch (-want +got): %s", d)
+	}
+}
+
+func TestDiskBackendExpiration(t *testing.T) {
+	dir := t.TempDir()
+	fc := &fakeClock{now: time.Now()}
+
+	disk, err := newDiskBackend(dir, 10, 1024, fc)
+	if err != nil {
+		t.Fatalf("newDiskBackend() returned error: %v", err)
+	}
+	if err := disk.Add("key", &mockResolvedResource{data: []byte("data")}, time.Minute); err != nil {
+		t.Fatalf("Add() returned error: %v", err)
+	}
+
+	fc.Advance(time.Minute + time.Second)
+	if _, ok := disk.Get("key"); ok {
+		t.Error("Expected cache miss after TTL expiration, but got cache hit")
+	}
+	if _, err := os.Stat(disk.entryPath("key")); !os.IsNotExist(err) {
+		t.Errorf("Expected expired entry to be removed from disk, got %v", err)
+	}
+}
+
+func TestDiskBackendSizeBound(t *testing.T) {
+	dir := t.TempDir()
+	fc := &fakeClock{now: time.Now()}
+
+	disk, err := newDiskBackend(dir, 10, 10, fc)
+	if err != nil {
+		t.Fatalf("newDiskBackend() returned error: %v", err)
+	}
+	for _, key := range []string{"first", "second", "third"} {
+		fc.Advance(time.Second)
+		if err := disk.Add(key, &mockResolvedResource{data: []byte(key + "!")}, time.Hour); err != nil {
+			t.Fatalf("Add() returned error: %v", err)
+		}
+	}
+
+	// "first!" (6 bytes) and "second!" (7 bytes) do not fit in 10 bytes
+	// together, so only the most recently added entry remains.
+	if _, ok := disk.Get("first"); ok {
+		t.Error("Expected first entry to be evicted")
+	}
+	if _, ok := disk.Get("second"); ok {
+		t.Error("Expected second entry to be evicted")
+	}
+	if _, ok := disk.Get("third"); !ok {
+		t.Error("Expected third entry to still be cached")
+	}
+
+	blobs, err := os.ReadDir(filepath.Join(dir, diskBlobsDir))
+	if err != nil {
+		t.Fatalf("ReadDir() returned error: %v", err)
+	}
+	if len(blobs) != 1 {
+		t.Errorf("Expected 1 blob on disk, got %d", len(blobs))
+	}
+}
+
+func TestDiskBackendSharesIdenticalContent(t *testing.T) {
+	dir := t.TempDir()
+	fc := &fakeClock{now: time.Now()}
+
+	disk, err := newDiskBackend(dir, 10, 1024, fc)
+	if err != nil {
+		t.Fatalf("newDiskBackend() returned error: %v", err)
+	}
+	for _, key := range []string{"a", "b"} {
+		if err := disk.Add(key, &mockResolvedResource{data: []byte("same")}, time.Hour); err != nil {
+			t.Fatalf("Add() returned error: %v", err)
+		}
+	}
+	if err := disk.Remove("a"); err != nil {
+		t.Fatalf("Remove() returned error: %v", err)
+	}
+
+	cached, ok := disk.Get("b")
+	if !ok {
+		t.Fatal("Expected entry b to still be cached after removing a")
+	}
+	if string(cached.Data()) != "same" {
+		t.Errorf("Data = %q, want %q", cached.Data(), "same")
+	}
+
+	if err := disk.Clear(); err != nil {
+		t.Fatalf("Clear() returned error: %v", err)
+	}
+	blobs, err := os.ReadDir(filepath.Join(dir, diskBlobsDir))
+	if err != nil {
+		t.Fatalf("ReadDir() returned error: %v", err)
+	}
+	if len(blobs) != 0 {
+		t.Errorf("Expected no blobs after Clear(), got %d", len(blobs))
+	}
+}
+
+func TestDiskBackendDiscardsCorruptBlob(t *testing.T) {
+	dir := t.TempDir()
+	fc := &fakeClock{now: time.Now()}
+
+	disk, err := newDiskBackend(dir, 10, 1024, fc)
+	if err != nil {
+		t.Fatalf("newDiskBackend() returned error: %v", err)
+	}
+	if err := disk.Add("key", &mockResolvedResource{data: []byte("data")}, time.Hour); err != nil {
+		t.Fatalf("Add() returned error: %v", err)
+	}
+	if err := os.WriteFile(disk.blobPath(digestOf([]byte("data"))), []byte("tampered"), 0o600); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+
+	if _, ok := disk.Get("key"); ok {
+		t.Error("Expected cache miss for a blob whose content does not match its digest")
+	}
+}
+
+func TestDiskBackendEntries(t *testing.T) {
+	fc := &fakeClock{now: time.Now()}
+	disk, err := newDiskBackend(t.TempDir(), 10, 1024, fc)
+	if err != nil {
+		t.Fatalf("newDiskBackend() returned error: %v", err)
+	}
+	cache := newResolverCacheWithBackend(disk, backendDisk, 10, time.Hour, fc)
+	params := []pipelinev1.Param{
+		{Name: "bundle", Value: pipelinev1.ParamValue{Type: pipelinev1.ParamTypeString, StringVal: "registry.io/repo@sha256:abcdef"}},
+	}
+	cache.Add("bundle", params, &mockResolvedResource{data: []byte("task data")})
+
+	entries := cache.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+	want := Entry{
+		Key:          generateCacheKey("bundle", params),
+		ResolverType: "bundle",
+		Size:         int64(len("task data")),
+		InsertedAt:   fc.now.Truncate(time.Second),
+	}
+	if d := cmp.Diff(want, entries[0], cmpopts.IgnoreFields(Entry{}, "Annotations"), cmpopts.EquateApproxTime(0)); d != "" {
+		t.Errorf("Entry mismatch (-want +got): %s", d)
+	}
+
+	if removed := cache.RemoveByResolverType("bundle"); removed != 1 {
+		t.Errorf("RemoveByResolverType() = %d, want 1", removed)
+	}
+	if _, ok := cache.Get("bundle", params); ok {
+		t.Error("Expected cache miss after eviction, but got cache hit")
+	}
+}
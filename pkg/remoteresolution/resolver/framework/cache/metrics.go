@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	resolverTypeAttributeKey = "resolver_type"
+	reasonAttributeKey       = "reason"
+
+	// evictionReasonTTL is the reason of entries dropped because they expired.
+	evictionReasonTTL = "ttl"
+	// evictionReasonLRU is the reason of entries dropped because the cache was full.
+	evictionReasonLRU = "lru"
+	// evictionReasonAdmin is the reason of entries removed through the admin endpoint.
+	evictionReasonAdmin = "admin"
+)
+
+// cacheMetrics holds the OpenTelemetry instruments for the resolver cache.
+type cacheMetrics struct {
+	hits          metric.Int64Counter
+	misses        metric.Int64Counter
+	invalidations metric.Int64Counter
+	evictions     metric.Int64Counter
+	hitRatio      metric.Float64ObservableGauge
+}
+
+// lookupCount is the number of hits and misses of a resolver type since
+// the resolvers started.
+type lookupCount struct {
+	hits   int64
+	misses int64
+}
+
+var (
+	metricsOnce sync.Once
+	metrics     *cacheMetrics
+
+	lookupsMu sync.Mutex
+	lookups   = map[string]*lookupCount{}
+)
+
+// getMetrics returns the cache instruments, creating them on first use. A nil
+// result means the instruments could not be created and nothing is recorded.
+func getMetrics() *cacheMetrics {
+	metricsOnce.Do(func() {
+		meter := otel.GetMeterProvider().Meter("tekton_pipelines_resolvers")
+
+		hits, err := meter.Int64Counter(
+			"tekton_pipelines_resolvers_cache_hits_total",
+			metric.WithDescription("Number of resolution requests served from the resolver cache"),
+		)
+		if err != nil {
+			return
+		}
+		misses, err := meter.Int64Counter(
+			"tekton_pipelines_resolvers_cache_misses_total",
+			metric.WithDescription("Number of resolution requests not found in the resolver cache"),
+		)
+		if err != nil {
+			return
+		}
+		// Entries dropped by the backends on their own, because they expired
+		// or the cache is full, are counted by the evictions counter only.
+		invalidations, err := meter.Int64Counter(
+			"tekton_pipelines_resolvers_cache_invalidations_total",
+			metric.WithDescription("Number of entries removed from the resolver cache through the admin endpoint"),
+		)
+		if err != nil {
+			return
+		}
+		evictions, err := meter.Int64Counter(
+			"tekton_pipelines_resolvers_cache_evictions_total",
+			metric.WithDescription("Number of entries removed from the resolver cache, by reason"),
+		)
+		if err != nil {
+			return
+		}
+		hitRatio, err := meter.Float64ObservableGauge(
+			"tekton_pipelines_resolvers_cache_hit_ratio",
+			metric.WithDescription("Ratio of resolution requests served from the resolver cache since the resolvers started"),
+			metric.WithFloat64Callback(observeHitRatio),
+		)
+		if err != nil {
+			return
+		}
+		metrics = &cacheMetrics{hits: hits, misses: misses, invalidations: invalidations, evictions: evictions, hitRatio: hitRatio}
+	})
+	return metrics
+}
+
+func recordHit(resolverType string) {
+	countLookup(resolverType, true)
+	if m := getMetrics(); m != nil {
+		m.hits.Add(context.Background(), 1, resolverTypeAttribute(resolverType))
+	}
+}
+
+func recordMiss(resolverType string) {
+	countLookup(resolverType, false)
+	if m := getMetrics(); m != nil {
+		m.misses.Add(context.Background(), 1, resolverTypeAttribute(resolverType))
+	}
+}
+
+// countLookup counts a hit or a miss for the hit ratio gauge.
+func countLookup(resolverType string, hit bool) {
+	lookupsMu.Lock()
+	defer lookupsMu.Unlock()
+	c, ok := lookups[resolverType]
+	if !ok {
+		c = &lookupCount{}
+		lookups[resolverType] = c
+	}
+	if hit {
+		c.hits++
+	} else {
+		c.misses++
+	}
+}
+
+// observeHitRatio observes the ratio of hits to lookups of each resolver
+// type.
+func observeHitRatio(_ context.Context, o metric.Float64Observer) error {
+	lookupsMu.Lock()
+	defer lookupsMu.Unlock()
+	for resolverType, c := range lookups {
+		o.Observe(float64(c.hits)/float64(c.hits+c.misses), resolverTypeAttribute(resolverType))
+	}
+	return nil
+}
+
+// recordInvalidations counts entries removed through the admin endpoint, both
+// as invalidations and as evictions with the admin reason.
+func recordInvalidations(resolverType string, count int) {
+	if count == 0 {
+		return
+	}
+	if m := getMetrics(); m != nil {
+		m.invalidations.Add(context.Background(), int64(count), resolverTypeAttribute(resolverType))
+	}
+	recordEvictions(resolverType, evictionReasonAdmin, count)
+}
+
+// recordEvictions counts entries removed from the cache for the given reason.
+func recordEvictions(resolverType, reason string, count int) {
+	if count == 0 {
+		return
+	}
+	if m := getMetrics(); m != nil {
+		m.evictions.Add(context.Background(), int64(count), metric.WithAttributes(
+			attribute.String(resolverTypeAttributeKey, resolverType),
+			attribute.String(reasonAttributeKey, reason),
+		))
+	}
+}
+
+func resolverTypeAttribute(resolverType string) metric.MeasurementOption {
+	return metric.WithAttributes(attribute.String(resolverTypeAttributeKey, resolverType))
+}
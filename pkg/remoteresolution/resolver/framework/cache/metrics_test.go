@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// setupMetrics resets the cache instruments and returns a reader of the
+// measurements recorded from then on.
+func setupMetrics() *sdkmetric.ManualReader {
+	metricsOnce = sync.Once{}
+	metrics = nil
+	lookups = map[string]*lookupCount{}
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	return reader
+}
+
+func TestHitRatio(t *testing.T) {
+	reader := setupMetrics()
+
+	recordHit("git")
+	recordHit("git")
+	recordHit("git")
+	recordMiss("git")
+	recordMiss("bundles")
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(t.Context(), &rm); err != nil {
+		t.Fatalf("Collect error: %v", err)
+	}
+	got := map[string]float64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "tekton_pipelines_resolvers_cache_hit_ratio" {
+				continue
+			}
+			gauge, ok := m.Data.(metricdata.Gauge[float64])
+			if !ok {
+				t.Fatalf("hit ratio metric data is not a Gauge[float64]: %T", m.Data)
+			}
+			for _, dp := range gauge.DataPoints {
+				resolverType, _ := dp.Attributes.Value(resolverTypeAttributeKey)
+				got[resolverType.AsString()] = dp.Value
+			}
+		}
+	}
+	if d := cmp.Diff(map[string]float64{"git": 0.75, "bundles": 0}, got); d != "" {
+		t.Errorf("unexpected hit ratios %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestEvictions(t *testing.T) {
+	for _, backendType := range []string{backendMemory, backendDisk} {
+		t.Run(backendType, func(t *testing.T) {
+			reader := setupMetrics()
+			fc := &fakeClock{now: time.Now()}
+			ttl := time.Hour
+			var cache *resolverCache
+			if backendType == backendDisk {
+				disk, err := newDiskBackend(t.TempDir(), 2, 1<<20, fc)
+				if err != nil {
+					t.Fatalf("newDiskBackend() returned error: %v", err)
+				}
+				cache = newResolverCacheWithBackend(disk, backendDisk, 2, ttl, fc)
+			} else {
+				cache = newResolverCacheWithClock(2, ttl, fc)
+			}
+
+			var params [][]pipelinev1.Param
+			for _, url := range []string{"first", "second", "third"} {
+				p := []pipelinev1.Param{{Name: "url", Value: *pipelinev1.NewStructuredValues(url)}}
+				params = append(params, p)
+				fc.Advance(time.Second)
+				cache.Add("git", p, &mockResolvedResource{data: []byte(url)})
+			}
+			// The first entry was dropped to make room for the third one.
+			if n := cache.RemoveByKey(generateCacheKey("git", params[2])); n != 1 {
+				t.Fatalf("RemoveByKey() removed %d entries, want 1", n)
+			}
+			fc.Advance(ttl + time.Second)
+			if _, ok := cache.Get("git", params[1]); ok {
+				t.Fatal("Expected cache miss after TTL expiration")
+			}
+
+			var rm metricdata.ResourceMetrics
+			if err := reader.Collect(t.Context(), &rm); err != nil {
+				t.Fatalf("Collect error: %v", err)
+			}
+			got := map[string]int64{}
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if m.Name != "tekton_pipelines_resolvers_cache_evictions_total" {
+						continue
+					}
+					sum, ok := m.Data.(metricdata.Sum[int64])
+					if !ok {
+						t.Fatalf("evictions metric data is not a Sum[int64]: %T", m.Data)
+					}
+					for _, dp := range sum.DataPoints {
+						resolverType, _ := dp.Attributes.Value(resolverTypeAttributeKey)
+						reason, _ := dp.Attributes.Value(reasonAttributeKey)
+						got[resolverType.AsString()+"/"+reason.AsString()] = dp.Value
+					}
+				}
+			}
+			want := map[string]int64{"git/lru": 1, "git/admin": 1, "git/ttl": 1}
+			if d := cmp.Diff(want, got); d != "" {
+				t.Errorf("unexpected evictions %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -18,19 +18,28 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
+	"time"
 
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
 )
 
 const (
-	cacheModeAlways              = "always"
-	cacheModeNever               = "never"
-	cacheModeAuto                = "auto"
-	CacheParam                   = "cache"
-	defaultCacheModeConfigMapKey = "default-cache-mode"
+	cacheModeAlways               = "always"
+	cacheModeNever                = "never"
+	cacheModeAuto                 = "auto"
+	CacheParam                    = "cache"
+	defaultCacheModeConfigMapKey  = "default-cache-mode"
+	cacheTTLConfigMapKey          = "cache-ttl"
+	cacheImmutableTTLConfigMapKey = "cache-immutable-ttl"
+
+	// keyScopeParamPrefix prefixes the names of the params added by
+	// WithKeyScope. Param names cannot contain a "/", so they never collide
+	// with the params of a request.
+	keyScopeParamPrefix = "cache.tekton.dev/"
 )
 
 // ImmutabilityChecker extends the base Resolver interface with cache-specific methods.
@@ -102,10 +111,57 @@ func Validate(cacheMode string) error {
 	return fmt.Errorf("invalid cache mode '%s', must be one of: %v (or empty for default)", cacheMode, validCacheModes)
 }
 
+// WithKeyScope returns params along with a param for every entry of scope.
+// Passing the result to GetFromCacheOrResolve or GetFromCacheOrRevalidate
+// caches resources resolved with the same params separately for every scope,
+// for example for every namespace whose credentials were used to fetch them.
+func WithKeyScope(params []v1.Param, scope map[string]string) []v1.Param {
+	scoped := slices.Clone(params)
+	for k, v := range scope {
+		scoped = append(scoped, v1.Param{
+			Name:  keyScopeParamPrefix + k,
+			Value: *v1.NewStructuredValues(v),
+		})
+	}
+	return scoped
+}
+
+// ttlFor returns the TTL of cache entries for the given params. Immutable
+// references use the immutable TTL and all other references the regular TTL.
+// Both can be overridden in individual resolver ConfigMaps with the
+// "cache-immutable-ttl" and "cache-ttl" keys.
+func ttlFor(
+	ctx context.Context,
+	resolver ImmutabilityChecker,
+	params []v1.Param,
+) time.Duration {
+	cacheInstance := Get(ctx)
+	ttl, key := cacheInstance.TTL(), cacheTTLConfigMapKey
+	if resolver.IsImmutable(params) {
+		ttl, key = cacheInstance.ImmutableTTL(), cacheImmutableTTLConfigMapKey
+	}
+
+	conf := resolutionframework.GetResolverConfigFromContext(ctx)
+	if ttlStr, ok := conf[key]; ok {
+		if parsed, err := time.ParseDuration(ttlStr); err == nil && parsed > 0 {
+			ttl = parsed
+		}
+	}
+	return ttl
+}
+
 type resolveFn = func() (resolutionframework.ResolvedResource, error)
 
+// revalidateFn resolves a resource given the stale cached version of it, or
+// nil if there is none. It returns false and no resource if the stale
+// version is still current.
+type revalidateFn = func(stale resolutionframework.ResolvedResource) (resolutionframework.ResolvedResource, bool, error)
+
+// GetFromCacheOrResolve returns the cached resource for params, or resolves
+// it and stores it in the cache with the TTL returned by ttlFor.
 func GetFromCacheOrResolve(
 	ctx context.Context,
+	resolver ImmutabilityChecker,
 	params []v1.Param,
 	resolverType string,
 	resolve resolveFn,
@@ -124,5 +180,46 @@ func GetFromCacheOrResolve(
 
 	// Store annotated resource with store operation and return annotated resource
 	// to indicate it was stored in cache
-	return cacheInstance.Add(resolverType, params, resource), nil
+	return cacheInstance.AddWithTTL(resolverType, params, resource, ttlFor(ctx, resolver, params)), nil
+}
+
+// GetFromCacheOrRevalidate returns the cached resource for params if it is
+// fresh. Otherwise it calls revalidate with the stale resource, if any, which
+// lets resolvers supporting conditional requests confirm that the stale
+// resource is unchanged instead of resolving it again. Either way the
+// resource is stored with AddRevalidatable and the TTL returned by ttlFor.
+func GetFromCacheOrRevalidate(
+	ctx context.Context,
+	resolver ImmutabilityChecker,
+	params []v1.Param,
+	resolverType string,
+	revalidate revalidateFn,
+) (resolutionframework.ResolvedResource, error) {
+	cacheInstance := Get(ctx)
+
+	stale, isStale, found := cacheInstance.getStale(resolverType, params)
+	if found && !isStale {
+		recordHit(resolverType)
+		timestamp := cacheInstance.clock.Now().Format(time.RFC3339)
+		return newAnnotatedResource(stale, resolverType, cacheOperationRetrieve, timestamp), nil
+	}
+
+	resource, modified, err := revalidate(stale)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := ttlFor(ctx, resolver, params)
+	if !modified {
+		if stale == nil {
+			return nil, errors.New("resolver reported an unmodified resource without a cached one")
+		}
+		recordHit(resolverType)
+		cacheInstance.AddRevalidatable(resolverType, params, stale, ttl)
+		timestamp := cacheInstance.clock.Now().Format(time.RFC3339)
+		return newAnnotatedResource(stale, resolverType, cacheOperationRevalidate, timestamp), nil
+	}
+
+	recordMiss(resolverType)
+	return cacheInstance.AddRevalidatable(resolverType, params, resource, ttl), nil
 }
@@ -17,9 +17,11 @@ limitations under the License.
 package cache
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
@@ -287,7 +289,7 @@ func TestGetFromCacheOrResolve(t *testing.T) {
 				Get(ctx).Add(tt.resolverType, tt.params, mockResource)
 			}
 
-			result, err := GetFromCacheOrResolve(ctx, tt.params, tt.resolverType, resolveFn)
+			result, err := GetFromCacheOrResolve(ctx, &resolverFake{}, tt.params, tt.resolverType, resolveFn)
 
 			// Verify error handling
 			if tt.resolveErr != nil {
@@ -315,3 +317,146 @@ func TestGetFromCacheOrResolve(t *testing.T) {
 		})
 	}
 }
+
+func TestWithKeyScope(t *testing.T) {
+	params := []pipelinev1.Param{{
+		Name:  bundleresolution.ParamBundle,
+		Value: pipelinev1.ParamValue{Type: pipelinev1.ParamTypeString, StringVal: "registry.io/repo@sha256:abcdef"},
+	}}
+	keyA := generateCacheKey("bundle", WithKeyScope(params, map[string]string{"namespace": "a"}))
+	keyB := generateCacheKey("bundle", WithKeyScope(params, map[string]string{"namespace": "b"}))
+	unscoped := generateCacheKey("bundle", params)
+
+	if keyA == keyB || keyA == unscoped || keyB == unscoped {
+		t.Errorf("Expected distinct keys for every scope, got %q, %q and unscoped %q", keyA, keyB, unscoped)
+	}
+	if again := generateCacheKey("bundle", WithKeyScope(params, map[string]string{"namespace": "a"})); again != keyA {
+		t.Errorf("Expected the same key for the same scope, got %q and %q", keyA, again)
+	}
+	if len(params) != 1 {
+		t.Errorf("Expected params to be left unchanged, got %v", params)
+	}
+}
+
+func TestTTLFor(t *testing.T) {
+	cacheInstance := newResolverCache(100, 5*time.Minute)
+	cacheInstance.immutableTTL = 24 * time.Hour
+
+	tests := []struct {
+		name        string
+		bundleRef   string
+		configMap   map[string]string
+		expectedTTL time.Duration
+	}{
+		{
+			name:        "mutable reference uses ttl",
+			bundleRef:   "registry.io/repo:latest",
+			expectedTTL: 5 * time.Minute,
+		},
+		{
+			name:        "immutable reference uses immutable ttl",
+			bundleRef:   "registry.io/repo@sha256:abcdef",
+			expectedTTL: 24 * time.Hour,
+		},
+		{
+			name:        "resolver config overrides ttl",
+			bundleRef:   "registry.io/repo:latest",
+			configMap:   map[string]string{"cache-ttl": "30s", "cache-immutable-ttl": "1h"},
+			expectedTTL: 30 * time.Second,
+		},
+		{
+			name:        "resolver config overrides immutable ttl",
+			bundleRef:   "registry.io/repo@sha256:abcdef",
+			configMap:   map[string]string{"cache-ttl": "30s", "cache-immutable-ttl": "1h"},
+			expectedTTL: time.Hour,
+		},
+		{
+			name:        "invalid resolver config is ignored",
+			bundleRef:   "registry.io/repo:latest",
+			configMap:   map[string]string{"cache-ttl": "soon"},
+			expectedTTL: 5 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(t.Context(), resolverCacheKey{}, cacheInstance)
+			if len(tt.configMap) > 0 {
+				ctx = resolutionframework.InjectResolverConfigToContext(ctx, tt.configMap)
+			}
+			params := []pipelinev1.Param{
+				{Name: bundleresolution.ParamBundle, Value: pipelinev1.ParamValue{StringVal: tt.bundleRef}},
+			}
+
+			if ttl := ttlFor(ctx, &resolverFake{}, params); ttl != tt.expectedTTL {
+				t.Errorf("ttlFor() = %v, want %v", ttl, tt.expectedTTL)
+			}
+		})
+	}
+}
+
+func TestGetFromCacheOrRevalidate(t *testing.T) {
+	fc := &fakeClock{time.Now()}
+	cacheInstance := newResolverCacheWithClock(100, time.Minute, fc)
+	cacheInstance.revalidationTTL = time.Hour
+	ctx := context.WithValue(t.Context(), resolverCacheKey{}, cacheInstance)
+	params := []pipelinev1.Param{
+		{Name: "url", Value: pipelinev1.ParamValue{Type: pipelinev1.ParamTypeString, StringVal: "https://example.com/task.yaml"}},
+	}
+
+	steps := []struct {
+		name              string
+		advance           time.Duration
+		data              string
+		modified          bool
+		expectCall        bool
+		expectStale       bool
+		expectData        string
+		expectedOperation string
+	}{
+		{name: "miss resolves", data: "v1", modified: true, expectCall: true, expectData: "v1", expectedOperation: cacheOperationStore},
+		{name: "fresh entry is served from the cache", advance: 30 * time.Second, data: "unused", expectData: "v1", expectedOperation: cacheOperationRetrieve},
+		{name: "stale entry is revalidated", advance: time.Minute, expectCall: true, expectStale: true, expectData: "v1", expectedOperation: cacheOperationRevalidate},
+		{name: "revalidated entry is fresh again", advance: 30 * time.Second, data: "unused", expectData: "v1", expectedOperation: cacheOperationRetrieve},
+		{name: "modified stale entry is replaced", advance: time.Minute, data: "v2", modified: true, expectCall: true, expectStale: true, expectData: "v2", expectedOperation: cacheOperationStore},
+		{name: "entry is dropped after the revalidation ttl", advance: 2 * time.Hour, data: "v3", modified: true, expectCall: true, expectData: "v3", expectedOperation: cacheOperationStore},
+	}
+
+	for _, step := range steps {
+		fc.Advance(step.advance)
+		called := false
+		var gotStale resolutionframework.ResolvedResource
+
+		result, err := GetFromCacheOrRevalidate(ctx, &resolverFake{}, params, "http", func(stale resolutionframework.ResolvedResource) (resolutionframework.ResolvedResource, bool, error) {
+			called, gotStale = true, stale
+			if !step.modified {
+				return nil, false, nil
+			}
+			return &mockResolvedResource{data: []byte(step.data)}, true, nil
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", step.name, err)
+		}
+		if called != step.expectCall {
+			t.Errorf("%s: revalidate called = %v, want %v", step.name, called, step.expectCall)
+		}
+		if (gotStale != nil) != step.expectStale {
+			t.Errorf("%s: got stale resource %v, expected one: %v", step.name, gotStale, step.expectStale)
+		}
+		if string(result.Data()) != step.expectData {
+			t.Errorf("%s: data = %q, want %q", step.name, result.Data(), step.expectData)
+		}
+		if op := result.Annotations()[cacheOperationKey]; op != step.expectedOperation {
+			t.Errorf("%s: cache operation = %q, want %q", step.name, op, step.expectedOperation)
+		}
+	}
+
+	// A fresh revalidatable entry is a regular cache hit, a stale one is not.
+	if _, ok := cacheInstance.Get("http", params); !ok {
+		t.Error("Expected fresh revalidatable entry to be a cache hit")
+	}
+	fc.Advance(2 * time.Minute)
+	if _, ok := cacheInstance.Get("http", params); ok {
+		t.Error("Expected stale revalidatable entry to be a cache miss")
+	}
+}
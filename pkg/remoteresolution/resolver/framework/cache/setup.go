@@ -18,35 +18,22 @@ package cache
 
 import (
 	"context"
-	"sync"
 
-	"k8s.io/client-go/rest"
-	"knative.dev/pkg/injection"
 	"knative.dev/pkg/logging"
 )
 
-var (
-	sharedCache   *resolverCache
-	cacheInitOnce sync.Once
-)
+var sharedCache *resolverCache
 
 type resolverCacheKey struct{}
 
-func init() {
-	injection.Default.RegisterClient(addCacheWithLoggerToCtx)
-}
-
-func addCacheWithLoggerToCtx(ctx context.Context, _ *rest.Config) context.Context {
-	return context.WithValue(ctx, resolverCacheKey{}, createCacheOnce(ctx))
-}
-
-func createCacheOnce(ctx context.Context) *resolverCache {
-	cacheInitOnce.Do(func() {
-		cacheMu.Lock()
-		defer cacheMu.Unlock()
+func getSharedCache(ctx context.Context) *resolverCache {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
 
+	// The cache may already have been created by a cache ConfigMap update.
+	if sharedCache == nil {
 		sharedCache = newResolverCache(defaultCacheSize, defaultExpiration)
-	})
+	}
 
 	return sharedCache.withLogger(
 		logging.FromContext(ctx),
@@ -54,12 +41,14 @@ func createCacheOnce(ctx context.Context) *resolverCache {
 }
 
 // Get extracts the ResolverCache from the context.
-// If the cache is not available in the context (e.g., in tests),
-// it falls back to the shared cache with a logger from the context.
+// If the cache is not available in the context, it returns the shared
+// cache with a logger from the context. The shared cache is looked up on
+// every call so that changes to the cache ConfigMap, such as switching
+// the storage backend, take effect without restarting the resolvers.
 func Get(ctx context.Context) *resolverCache {
 	if untyped := ctx.Value(resolverCacheKey{}); untyped != nil {
 		return untyped.(*resolverCache)
 	}
 
-	return createCacheOnce(ctx)
+	return getSharedCache(ctx)
 }
@@ -20,6 +20,7 @@ import (
 	"context"
 	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
 	rrclient "github.com/tektoncd/pipeline/pkg/client/resolution/injection/client"
 	rrinformer "github.com/tektoncd/pipeline/pkg/client/resolution/injection/informers/resolution/v1beta1/resolutionrequest"
 	rrcache "github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/framework/cache"
@@ -86,7 +87,14 @@ func NewController(ctx context.Context, resolver Resolver, modifiers ...Reconcil
 				},
 				// TODO(sbwsg): should we deliver delete events
 				// to the resolver?
-				// DeleteFunc: impl.Enqueue,
+				DeleteFunc: func(obj interface{}) {
+					if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+						obj = tombstone.Obj
+					}
+					if rr, ok := obj.(*v1beta1.ResolutionRequest); ok {
+						r.attempts.forget(rr.UID)
+					}
+				},
 			},
 		})
 		if err != nil {
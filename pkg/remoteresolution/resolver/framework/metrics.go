@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	resolverTypeAttributeKey = "resolver_type"
+	statusAttributeKey       = "status"
+	reasonAttributeKey       = "reason"
+
+	statusSuccess = "success"
+	statusFailed  = "failed"
+)
+
+// reconcilerMetrics holds the OpenTelemetry instruments for the resolver
+// framework reconciler.
+type reconcilerMetrics struct {
+	coalesced          metric.Int64Counter
+	resolutionDuration metric.Float64Histogram
+	resolutionTotal    metric.Int64Counter
+	payloadSize        metric.Int64Histogram
+}
+
+var (
+	metricsOnce sync.Once
+	metrics     *reconcilerMetrics
+)
+
+// getMetrics returns the reconciler instruments, creating them on first use.
+// A nil result means the instruments could not be created and nothing is
+// recorded.
+func getMetrics() *reconcilerMetrics {
+	metricsOnce.Do(func() {
+		meter := otel.GetMeterProvider().Meter("tekton_pipelines_resolvers")
+
+		coalesced, err := meter.Int64Counter(
+			"tekton_pipelines_resolvers_coalesced_requests_total",
+			metric.WithDescription("Number of resolution requests filled with the result of an identical request resolved at the same time"),
+		)
+		if err != nil {
+			return
+		}
+		resolutionDuration, err := meter.Float64Histogram(
+			"tekton_pipelines_resolvers_resolution_duration_seconds",
+			metric.WithDescription("The time taken by an attempt to resolve a resolution request in seconds"),
+			metric.WithUnit("s"),
+			metric.WithExplicitBucketBoundaries(0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120),
+		)
+		if err != nil {
+			return
+		}
+		resolutionTotal, err := meter.Int64Counter(
+			"tekton_pipelines_resolvers_resolution_total",
+			metric.WithDescription("Number of resolution requests that succeeded or failed"),
+		)
+		if err != nil {
+			return
+		}
+		payloadSize, err := meter.Int64Histogram(
+			"tekton_pipelines_resolvers_resolved_payload_size_bytes",
+			metric.WithDescription("The size of the resolved resources in bytes"),
+			metric.WithUnit("By"),
+			metric.WithExplicitBucketBoundaries(1024, 4096, 16384, 65536, 262144, 524288, 1048576),
+		)
+		if err != nil {
+			return
+		}
+		metrics = &reconcilerMetrics{
+			coalesced:          coalesced,
+			resolutionDuration: resolutionDuration,
+			resolutionTotal:    resolutionTotal,
+			payloadSize:        payloadSize,
+		}
+	})
+	return metrics
+}
+
+func recordCoalesced(resolverType string) {
+	if m := getMetrics(); m != nil {
+		m.coalesced.Add(context.Background(), 1, metric.WithAttributes(attribute.String(resolverTypeAttributeKey, resolverType)))
+	}
+}
+
+// recordAttempt records the duration of an attempt to resolve a request,
+// whether it succeeded or not.
+func recordAttempt(resolverType string, duration time.Duration, succeeded bool) {
+	status := statusSuccess
+	if !succeeded {
+		status = statusFailed
+	}
+	if m := getMetrics(); m != nil {
+		m.resolutionDuration.Record(context.Background(), duration.Seconds(), metric.WithAttributes(
+			attribute.String(resolverTypeAttributeKey, resolverType),
+			attribute.String(statusAttributeKey, status),
+		))
+	}
+}
+
+// recordSuccess records a request resolved into a payload of size bytes.
+func recordSuccess(resolverType string, size int) {
+	if m := getMetrics(); m != nil {
+		m.resolutionTotal.Add(context.Background(), 1, metric.WithAttributes(
+			attribute.String(resolverTypeAttributeKey, resolverType),
+			attribute.String(statusAttributeKey, statusSuccess),
+			attribute.String(reasonAttributeKey, resolutioncommon.ReasonResolutionSuccessful),
+		))
+		m.payloadSize.Record(context.Background(), int64(size), metric.WithAttributes(attribute.String(resolverTypeAttributeKey, resolverType)))
+	}
+}
+
+// recordFailure records a request marked as failed with the given reason.
+func recordFailure(resolverType, reason string) {
+	if m := getMetrics(); m != nil {
+		m.resolutionTotal.Add(context.Background(), 1, metric.WithAttributes(
+			attribute.String(resolverTypeAttributeKey, resolverType),
+			attribute.String(statusAttributeKey, statusFailed),
+			attribute.String(reasonAttributeKey, reason),
+		))
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func resetMetrics() {
+	metricsOnce = sync.Once{}
+	metrics = nil
+}
+
+func TestRecordResolutions(t *testing.T) {
+	resetMetrics()
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	recordAttempt("git", 2*time.Second, false)
+	recordAttempt("git", time.Second, true)
+	recordAttempt("bundles", 500*time.Millisecond, true)
+	recordSuccess("git", 2048)
+	recordSuccess("bundles", 100)
+	recordFailure("git", "ResolutionTimedOut")
+	recordFailure("git", "ResolutionTimedOut")
+	recordFailure("bundles", "SignatureVerificationFailed")
+	recordCoalesced("git")
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(t.Context(), &rm); err != nil {
+		t.Fatalf("Collect error: %v", err)
+	}
+	want := map[string]float64{
+		"tekton_pipelines_resolvers_coalesced_requests_total{resolver_type=git}":                                              1,
+		"tekton_pipelines_resolvers_resolution_duration_seconds{resolver_type=bundles,status=success}":                        0.5,
+		"tekton_pipelines_resolvers_resolution_duration_seconds{resolver_type=git,status=failed}":                             2,
+		"tekton_pipelines_resolvers_resolution_duration_seconds{resolver_type=git,status=success}":                            1,
+		"tekton_pipelines_resolvers_resolution_total{reason=ResolutionSuccessful,resolver_type=bundles,status=success}":       1,
+		"tekton_pipelines_resolvers_resolution_total{reason=ResolutionSuccessful,resolver_type=git,status=success}":           1,
+		"tekton_pipelines_resolvers_resolution_total{reason=ResolutionTimedOut,resolver_type=git,status=failed}":              2,
+		"tekton_pipelines_resolvers_resolution_total{reason=SignatureVerificationFailed,resolver_type=bundles,status=failed}": 1,
+		"tekton_pipelines_resolvers_resolved_payload_size_bytes{resolver_type=bundles}":                                       100,
+		"tekton_pipelines_resolvers_resolved_payload_size_bytes{resolver_type=git}":                                           2048,
+	}
+	if d := cmp.Diff(want, collected(rm)); d != "" {
+		t.Errorf("unexpected metrics %s", diff.PrintWantGot(d))
+	}
+}
+
+// collected returns the value of every data point of rm, or the sum of the
+// recorded values for histograms, keyed by metric name and attributes.
+func collected(rm metricdata.ResourceMetrics) map[string]float64 {
+	got := map[string]float64{}
+	key := func(name string, attrs []string) string {
+		sort.Strings(attrs)
+		return name + "{" + strings.Join(attrs, ",") + "}"
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					got[key(m.Name, attributes(dp.Attributes.ToSlice()))] = float64(dp.Value)
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					got[key(m.Name, attributes(dp.Attributes.ToSlice()))] = dp.Sum
+				}
+			case metricdata.Histogram[int64]:
+				for _, dp := range data.DataPoints {
+					got[key(m.Name, attributes(dp.Attributes.ToSlice()))] = float64(dp.Sum)
+				}
+			}
+		}
+	}
+	return got
+}
+
+func attributes(kvs []attribute.KeyValue) []string {
+	var attrs []string
+	for _, kv := range kvs {
+		attrs = append(attrs, string(kv.Key)+"="+kv.Value.AsString())
+	}
+	return attrs
+}
@@ -22,8 +22,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	resolverconfig "github.com/tektoncd/pipeline/pkg/apis/config/resolver"
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
@@ -32,6 +35,7 @@ import (
 	rrcache "github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/framework/cache"
 	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"golang.org/x/sync/singleflight"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
@@ -77,6 +81,13 @@ type Reconciler struct {
 	resolutionRequestClientSet rrclient.Interface
 
 	configStore *framework.ConfigStore
+
+	// inFlight coalesces the resolution of identical requests that are
+	// resolved at the same time.
+	inFlight singleflight.Group
+
+	// attempts tracks the failed attempts of requests being retried.
+	attempts attemptTracker
 }
 
 var _ reconciler.LeaderAware = &Reconciler{}
@@ -100,8 +111,16 @@ func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
 	}
 
 	if rr.IsDone() {
+		r.attempts.forget(rr.UID)
 		return nil
 	}
+	// Updating the status of a request being retried enqueues it again
+	// before its backoff has passed.
+	if s, ok := r.attempts.get(rr.UID); ok {
+		if wait := s.next.Sub(r.Clock.Now()); wait > 0 {
+			return controller.NewRequeueAfter(wait)
+		}
+	}
 
 	// Inject request-scoped information into the context, such as
 	// the namespace that the request originates from and the
@@ -116,8 +135,16 @@ func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
 }
 
 func (r *Reconciler) resolve(ctx context.Context, key string, rr *v1beta1.ResolutionRequest) error {
-	errChan := make(chan error)
-	resourceChan := make(chan framework.ResolvedResource)
+	// The channels are buffered so that the resolution does not block
+	// forever once the attempt timed out.
+	errChan := make(chan error, 1)
+	resourceChan := make(chan framework.ResolvedResource, 1)
+
+	resolverType := rr.Labels[resolutioncommon.LabelKeyResolverType]
+	admissionPolicy := resolverconfig.FromContextOrDefaults(ctx).AdmissionPolicy
+	if err := admissionPolicy.Admit(rr.Namespace, resolverType, rr.Spec.Params); err != nil {
+		return r.OnError(ctx, rr, resolutioncommon.NewError(resolutioncommon.ReasonResolutionDenied, err))
+	}
 
 	paramsMap := make(map[string]string)
 	for _, p := range rr.Spec.Params {
@@ -134,10 +161,11 @@ func (r *Reconciler) resolve(ctx context.Context, key string, rr *v1beta1.Resolu
 		}
 	}
 
-	timeoutDuration := defaultMaximumResolutionDuration
+	policy := retryPolicyFromContext(ctx)
+	timeoutDuration := policy.timeout
 	if timed, ok := r.resolver.(framework.TimedResolution); ok {
 		var err error
-		timeoutDuration, err = timed.GetResolutionTimeout(ctx, defaultMaximumResolutionDuration, paramsMap)
+		timeoutDuration, err = timed.GetResolutionTimeout(ctx, policy.timeout, paramsMap)
 		if err != nil {
 			return err
 		}
@@ -149,6 +177,8 @@ func (r *Reconciler) resolve(ctx context.Context, key string, rr *v1beta1.Resolu
 	resolutionCtx, cancelFn := context.WithTimeout(ctx, timeoutDuration)
 	defer cancelFn()
 
+	start := r.Clock.Now()
+
 	go func() {
 		validationError := r.resolver.Validate(resolutionCtx, &rr.Spec)
 		if validationError != nil {
@@ -158,7 +188,7 @@ func (r *Reconciler) resolve(ctx context.Context, key string, rr *v1beta1.Resolu
 			}
 			return
 		}
-		resource, resolveErr := r.resolver.Resolve(resolutionCtx, &rr.Spec)
+		resource, resolveErr := r.resolveCoalesced(resolutionCtx, rr, max(timeoutDuration, policy.timeout))
 		if resolveErr != nil {
 			errChan <- &resolutioncommon.GetResourceError{
 				ResolverName: r.resolver.GetName(resolutionCtx),
@@ -173,19 +203,136 @@ func (r *Reconciler) resolve(ctx context.Context, key string, rr *v1beta1.Resolu
 	select {
 	case err := <-errChan:
 		if err != nil {
-			return r.OnError(ctx, rr, err)
+			recordAttempt(resolverType, r.Clock.Since(start), false)
+			return r.onAttemptError(ctx, rr, policy, err)
 		}
 	case <-resolutionCtx.Done():
 		if err := resolutionCtx.Err(); err != nil {
-			return r.OnError(ctx, rr, err)
+			recordAttempt(resolverType, r.Clock.Since(start), false)
+			// Later attempts must not wait for the resolution that
+			// timed out.
+			r.forgetInFlight(rr)
+			return r.onAttemptError(ctx, rr, policy, err)
 		}
 	case resource := <-resourceChan:
+		recordAttempt(resolverType, r.Clock.Since(start), true)
+		r.attempts.forget(rr.UID)
 		return r.writeResolvedData(ctx, rr, resource)
 	}
 
 	return errors.New("unknown error")
 }
 
+// resolveCoalesced resolves the request, sharing the result of an identical
+// request from the same namespace being resolved at the same time instead of
+// resolving it again. The shared resolution is detached from the context of
+// the request that started it and bounded by sharedTimeout instead, so that
+// the other requests waiting for it are not failed when that request is
+// cancelled. Each request stops waiting when its own ctx is done.
+func (r *Reconciler) resolveCoalesced(ctx context.Context, rr *v1beta1.ResolutionRequest, sharedTimeout time.Duration) (framework.ResolvedResource, error) {
+	resolverType := rr.Labels[resolutioncommon.LabelKeyResolverType]
+	key, err := coalescingKey(resolverType, rr)
+	if err != nil {
+		return r.resolver.Resolve(ctx, &rr.Spec)
+	}
+	leader := false
+	resultChan := r.inFlight.DoChan(key, func() (interface{}, error) {
+		leader = true
+		sharedCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sharedTimeout)
+		defer cancel()
+		return r.resolver.Resolve(sharedCtx, &rr.Spec)
+	})
+	select {
+	case result := <-resultChan:
+		if !leader {
+			recordCoalesced(resolverType)
+		}
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.(framework.ResolvedResource), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// forgetInFlight makes the next identical request resolve again instead of
+// sharing the result of the resolution in flight.
+func (r *Reconciler) forgetInFlight(rr *v1beta1.ResolutionRequest) {
+	if key, err := coalescingKey(rr.Labels[resolutioncommon.LabelKeyResolverType], rr); err == nil {
+		r.inFlight.Forget(key)
+	}
+}
+
+// coalescingKey returns the key identifying requests that resolve the same
+// resource. Requests from different namespaces are never coalesced since
+// resolvers may use the credentials of the request namespace.
+func coalescingKey(resolverType string, rr *v1beta1.ResolutionRequest) (string, error) {
+	spec := rr.Spec.DeepCopy()
+	slices.SortStableFunc(spec.Params, func(a, b pipelinev1.Param) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	return resolverType + "/" + rr.Namespace + "/" + string(b), nil
+}
+
+// onAttemptError handles an attempt to resolve a ResolutionRequest that
+// failed or timed out. Unless the error is not retryable or the request was
+// attempted as many times as the retry policy allows, the attempt count and
+// error are recorded on the Succeeded condition and the request is requeued
+// after the backoff. Without a retry policy errors are handled by OnError.
+func (r *Reconciler) onAttemptError(ctx context.Context, rr *v1beta1.ResolutionRequest, policy retryPolicy, err error) error {
+	if policy.maxAttempts <= 1 || !isRetryable(err) {
+		r.attempts.forget(rr.UID)
+		return r.OnError(ctx, rr, err)
+	}
+
+	s, _ := r.attempts.get(rr.UID)
+	s.attempts++
+	if s.attempts >= policy.maxAttempts {
+		r.attempts.forget(rr.UID)
+		reason, resolutionErr := resolutioncommon.ReasonError(err)
+		if errors.Is(err, context.DeadlineExceeded) {
+			reason = resolutioncommon.ReasonResolutionTimedOut
+		}
+		err = resolutioncommon.NewError(reason, fmt.Errorf("resolution failed after %d attempts: %w", s.attempts, resolutionErr))
+		_ = r.MarkFailed(ctx, rr, err)
+		return controller.NewPermanentError(err)
+	}
+
+	delay := policy.delay(s.attempts)
+	s.next = r.Clock.Now().Add(delay)
+	r.attempts.set(rr.UID, s)
+	if err := r.markRetrying(ctx, rr, fmt.Sprintf("attempt %d of %d failed, retrying in %s: %v", s.attempts, policy.maxAttempts, delay.Round(time.Millisecond), err)); err != nil {
+		return err
+	}
+	return controller.NewRequeueAfter(delay)
+}
+
+// markRetrying updates the Succeeded condition of a ResolutionRequest
+// whose resolution will be attempted again.
+func (r *Reconciler) markRetrying(ctx context.Context, rr *v1beta1.ResolutionRequest, message string) error {
+	key := fmt.Sprintf("%s/%s", rr.Namespace, rr.Name)
+	latestGeneration, err := r.resolutionRequestClientSet.ResolutionV1beta1().ResolutionRequests(rr.Namespace).Get(ctx, rr.Name, metav1.GetOptions{})
+	if err != nil {
+		logging.FromContext(ctx).Warnf("error getting latest generation of resolutionrequest %q: %v", key, err)
+		return err
+	}
+	if latestGeneration.IsDone() {
+		return nil
+	}
+	latestGeneration.Status.MarkRetrying(message)
+	_, err = r.resolutionRequestClientSet.ResolutionV1beta1().ResolutionRequests(rr.Namespace).UpdateStatus(ctx, latestGeneration, metav1.UpdateOptions{})
+	if err != nil {
+		logging.FromContext(ctx).Warnf("error marking resolutionrequest %q as retrying: %v", key, err)
+		return err
+	}
+	return nil
+}
+
 // OnError is used to handle any situation where a ResolutionRequest has
 // reached a terminal situation that cannot be recovered from.
 func (r *Reconciler) OnError(ctx context.Context, rr *v1beta1.ResolutionRequest, err error) error {
@@ -222,6 +369,7 @@ func (r *Reconciler) MarkFailed(ctx context.Context, rr *v1beta1.ResolutionReque
 		logging.FromContext(ctx).Warnf("error marking resolutionrequest %q as failed: %v", key, err)
 		return err
 	}
+	recordFailure(rr.Labels[resolutioncommon.LabelKeyResolverType], reason)
 	return nil
 }
 
@@ -250,6 +398,7 @@ func (r *Reconciler) writeResolvedData(ctx context.Context, rr *v1beta1.Resoluti
 			Original:             err,
 		})
 	}
+	recordSuccess(rr.Labels[resolutioncommon.LabelKeyResolverType], len(resource.Data()))
 
 	return nil
 }
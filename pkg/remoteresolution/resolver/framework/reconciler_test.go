@@ -21,11 +21,14 @@ import (
 	"encoding/base64"
 	"errors"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	resolverconfig "github.com/tektoncd/pipeline/pkg/apis/config/resolver"
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
@@ -402,6 +405,412 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
+// blockingResolver counts the requests it validates and resolves, and blocks
+// resolution until release is closed or the context is done.
+type blockingResolver struct {
+	framework.FakeResolver
+	validated atomic.Int32
+	resolved  atomic.Int32
+	release   chan struct{}
+}
+
+func (r *blockingResolver) Validate(ctx context.Context, req *v1beta1.ResolutionRequestSpec) error {
+	r.validated.Add(1)
+	return r.FakeResolver.Validate(ctx, req)
+}
+
+func (r *blockingResolver) Resolve(ctx context.Context, req *v1beta1.ResolutionRequestSpec) (resolutionframework.ResolvedResource, error) {
+	r.resolved.Add(1)
+	select {
+	case <-r.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return r.FakeResolver.Resolve(ctx, req)
+}
+
+func TestReconcileCoalescesIdenticalRequests(t *testing.T) {
+	request := func(namespace, name string) *v1beta1.ResolutionRequest {
+		return &v1beta1.ResolutionRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespace,
+				CreationTimestamp: metav1.Time{Time: time.Now()},
+				Labels: map[string]string{
+					resolutioncommon.LabelKeyResolverType: resolutionframework.LabelValueFakeResolverType,
+				},
+			},
+			Spec: v1beta1.ResolutionRequestSpec{
+				Params: []pipelinev1.Param{{
+					Name:  resolutionframework.FakeParamName,
+					Value: *pipelinev1.NewStructuredValues("bar"),
+				}},
+			},
+		}
+	}
+	// Identical requests from another namespace are resolved on their own.
+	requests := []*v1beta1.ResolutionRequest{
+		request("foo", "rr-1"),
+		request("foo", "rr-2"),
+		request("foo", "rr-3"),
+		request("other", "rr-1"),
+	}
+	d := test.Data{
+		ResolutionRequests: requests,
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "resolver-cache-config",
+				Namespace: system.Namespace(),
+			},
+			Data: map[string]string{},
+		}},
+	}
+	resolver := &blockingResolver{
+		FakeResolver: framework.FakeResolver{ForParam: map[string]*resolutionframework.FakeResolvedResource{
+			"bar": {Content: "some content"},
+		}},
+		release: make(chan struct{}),
+	}
+
+	ctx, _ := ttesting.SetupFakeContext(t)
+	testAssets, cancel := getResolverFrameworkController(ctx, t, d, resolver, setClockOnReconciler)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(requests))
+	for _, rr := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRequestName(rr))
+		}()
+	}
+	// Release the resolution once every request has been validated and is
+	// about to be resolved.
+	for resolver.validated.Load() < int32(len(requests)) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(resolver.release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := resolver.resolved.Load(); got != 2 {
+		t.Errorf("expected 2 resolutions, got %d", got)
+	}
+	c := testAssets.Clients.ResolutionRequests.ResolutionV1beta1()
+	for _, rr := range requests {
+		reconciledRR, err := c.ResolutionRequests(rr.Namespace).Get(testAssets.Ctx, rr.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("getting updated ResolutionRequest: %v", err)
+		}
+		if d := cmp.Diff(base64.StdEncoding.Strict().EncodeToString([]byte("some content")), reconciledRR.Status.Data); d != "" {
+			t.Errorf("unexpected data of %s: %s", getRequestName(rr), diff.PrintWantGot(d))
+		}
+	}
+}
+
+func TestReconcileCoalescedRequestOutlivesCancelledLeader(t *testing.T) {
+	request := func(name string) *v1beta1.ResolutionRequest {
+		return &v1beta1.ResolutionRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "foo",
+				CreationTimestamp: metav1.Time{Time: time.Now()},
+				Labels: map[string]string{
+					resolutioncommon.LabelKeyResolverType: resolutionframework.LabelValueFakeResolverType,
+				},
+			},
+			Spec: v1beta1.ResolutionRequestSpec{
+				Params: []pipelinev1.Param{{
+					Name:  resolutionframework.FakeParamName,
+					Value: *pipelinev1.NewStructuredValues("bar"),
+				}},
+			},
+		}
+	}
+	leaderRR, followerRR := request("rr-1"), request("rr-2")
+	d := test.Data{
+		ResolutionRequests: []*v1beta1.ResolutionRequest{leaderRR, followerRR},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "resolver-cache-config",
+				Namespace: system.Namespace(),
+			},
+			Data: map[string]string{},
+		}},
+	}
+	resolver := &blockingResolver{
+		FakeResolver: framework.FakeResolver{ForParam: map[string]*resolutionframework.FakeResolvedResource{
+			"bar": {Content: "some content"},
+		}},
+		release: make(chan struct{}),
+	}
+
+	ctx, _ := ttesting.SetupFakeContext(t)
+	testAssets, cancel := getResolverFrameworkController(ctx, t, d, resolver, setClockOnReconciler)
+	defer cancel()
+
+	leaderCtx, cancelLeader := context.WithCancel(testAssets.Ctx)
+	leaderDone := make(chan struct{})
+	go func() {
+		defer close(leaderDone)
+		_ = testAssets.Controller.Reconciler.Reconcile(leaderCtx, getRequestName(leaderRR))
+	}()
+	for resolver.resolved.Load() < 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	followerErr := make(chan error, 1)
+	go func() {
+		followerErr <- testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRequestName(followerRR))
+	}()
+	for resolver.validated.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	// Cancelling the request that started the resolution must not fail the
+	// request waiting for it.
+	cancelLeader()
+	<-leaderDone
+	close(resolver.release)
+
+	if err := <-followerErr; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := resolver.resolved.Load(); got != 1 {
+		t.Errorf("expected 1 resolution, got %d", got)
+	}
+	reconciledRR, err := testAssets.Clients.ResolutionRequests.ResolutionV1beta1().ResolutionRequests(followerRR.Namespace).Get(testAssets.Ctx, followerRR.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting updated ResolutionRequest: %v", err)
+	}
+	if d := cmp.Diff(base64.StdEncoding.Strict().EncodeToString([]byte("some content")), reconciledRR.Status.Data); d != "" {
+		t.Errorf("unexpected data of %s: %s", getRequestName(followerRR), diff.PrintWantGot(d))
+	}
+}
+
+// flakyResolver fails its first resolutions with failErr, or with a
+// transient error if it is nil, and is configured by the
+// fake-resolver-config ConfigMap.
+type flakyResolver struct {
+	framework.FakeResolver
+	failures int32
+	failErr  error
+	resolved atomic.Int32
+}
+
+func (r *flakyResolver) GetConfigName(context.Context) string {
+	return "fake-resolver-config"
+}
+
+func (r *flakyResolver) Resolve(ctx context.Context, req *v1beta1.ResolutionRequestSpec) (resolutionframework.ResolvedResource, error) {
+	if r.resolved.Add(1) <= r.failures {
+		if r.failErr != nil {
+			return nil, r.failErr
+		}
+		return nil, errors.New("registry unavailable")
+	}
+	return r.FakeResolver.Resolve(ctx, req)
+}
+
+func TestReconcileRetriesWithBackoff(t *testing.T) {
+	type attempt struct {
+		// advance is how long the clock moves before the attempt.
+		advance time.Duration
+		// minRequeue and maxRequeue bound the jittered backoff, or are
+		// zero if the attempt is not requeued.
+		minRequeue time.Duration
+		maxRequeue time.Duration
+		wantErr    string
+		wantReason string
+		wantMsg    string
+	}
+	for _, tc := range []struct {
+		name         string
+		config       map[string]string
+		failures     int32
+		failErr      error
+		waitFor      time.Duration
+		attempts     []attempt
+		wantResolved int32
+		wantData     string
+	}{{
+		name:     "succeeds after retries",
+		config:   map[string]string{"resolution-max-attempts": "3", "resolution-backoff": "10s", "resolution-max-backoff": "15s"},
+		failures: 2,
+		attempts: []attempt{{
+			minRequeue: 10 * time.Second,
+			maxRequeue: 12 * time.Second,
+			wantReason: resolutioncommon.ReasonResolutionRetrying,
+			wantMsg:    "attempt 1 of 3 failed, retrying in ",
+		}, {
+			// The backoff has not passed yet.
+			advance:    5 * time.Second,
+			minRequeue: 5 * time.Second,
+			maxRequeue: 7 * time.Second,
+			wantReason: resolutioncommon.ReasonResolutionRetrying,
+			wantMsg:    "attempt 1 of 3 failed, retrying in ",
+		}, {
+			advance:    7 * time.Second,
+			minRequeue: 15 * time.Second,
+			maxRequeue: 18 * time.Second,
+			wantReason: resolutioncommon.ReasonResolutionRetrying,
+			wantMsg:    "attempt 2 of 3 failed, retrying in ",
+		}, {
+			advance:    18 * time.Second,
+			wantReason: resolutioncommon.ReasonResolutionRetrying,
+			wantMsg:    "attempt 2 of 3 failed, retrying in ",
+		}},
+		wantResolved: 3,
+		wantData:     "some content",
+	}, {
+		name:     "fails after max attempts",
+		config:   map[string]string{"resolution-max-attempts": "2", "resolution-backoff": "10s"},
+		failures: 5,
+		attempts: []attempt{{
+			minRequeue: 10 * time.Second,
+			maxRequeue: 12 * time.Second,
+			wantReason: resolutioncommon.ReasonResolutionRetrying,
+			wantMsg:    "attempt 1 of 2 failed, retrying in ",
+		}, {
+			advance:    12 * time.Second,
+			wantErr:    `resolution failed after 2 attempts: error getting "Fake" "foo/rr": registry unavailable`,
+			wantReason: resolutioncommon.ReasonResolutionFailed,
+			wantMsg:    `resolution failed after 2 attempts: error getting "Fake" "foo/rr": registry unavailable`,
+		}},
+		wantResolved: 2,
+	}, {
+		name:    "times out",
+		config:  map[string]string{"resolution-max-attempts": "2", "resolution-timeout": "100ms"},
+		waitFor: time.Second,
+		attempts: []attempt{{
+			minRequeue: time.Second,
+			maxRequeue: 1200 * time.Millisecond,
+			wantReason: resolutioncommon.ReasonResolutionRetrying,
+			wantMsg:    "attempt 1 of 2 failed, retrying in ",
+		}, {
+			advance:    2 * time.Second,
+			wantErr:    "resolution failed after 2 attempts: context deadline exceeded",
+			wantReason: resolutioncommon.ReasonResolutionTimedOut,
+			wantMsg:    "resolution failed after 2 attempts: context deadline exceeded",
+		}},
+		wantResolved: 2,
+	}, {
+		name:     "does not retry resources that are not found",
+		config:   map[string]string{"resolution-max-attempts": "3", "resolution-backoff": "10s"},
+		failures: 5,
+		failErr:  resolutioncommon.NewError(resolutioncommon.ReasonResourceNotFound, errors.New("file does not exist")),
+		attempts: []attempt{{
+			wantErr:    `error getting "Fake" "foo/rr": file does not exist`,
+			wantReason: resolutioncommon.ReasonResourceNotFound,
+			wantMsg:    "file does not exist",
+		}},
+		wantResolved: 1,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := &v1beta1.ResolutionRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "rr",
+					Namespace:         "foo",
+					UID:               "rr-uid",
+					CreationTimestamp: metav1.Time{Time: time.Now()},
+					Labels: map[string]string{
+						resolutioncommon.LabelKeyResolverType: resolutionframework.LabelValueFakeResolverType,
+					},
+				},
+				Spec: v1beta1.ResolutionRequestSpec{
+					Params: []pipelinev1.Param{{
+						Name:  resolutionframework.FakeParamName,
+						Value: *pipelinev1.NewStructuredValues("bar"),
+					}},
+				},
+			}
+			d := test.Data{
+				ResolutionRequests: []*v1beta1.ResolutionRequest{rr},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "resolver-cache-config",
+						Namespace: system.Namespace(),
+					},
+					Data: map[string]string{},
+				}, {
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resolverconfig.GetFeatureFlagsConfigName(),
+						Namespace: system.Namespace(),
+					},
+					Data: map[string]string{},
+				}, {
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "fake-resolver-config",
+						Namespace: system.Namespace(),
+					},
+					Data: tc.config,
+				}},
+			}
+			resolver := &flakyResolver{
+				FakeResolver: framework.FakeResolver{ForParam: map[string]*resolutionframework.FakeResolvedResource{
+					"bar": {Content: "some content", WaitFor: tc.waitFor},
+				}},
+				failures: tc.failures,
+				failErr:  tc.failErr,
+			}
+			fakeClock := clock.NewFakePassiveClock(now)
+
+			ctx, _ := ttesting.SetupFakeContext(t)
+			testAssets, cancel := getResolverFrameworkController(ctx, t, d, resolver, func(r *framework.Reconciler) {
+				r.Clock = fakeClock
+			})
+			defer cancel()
+
+			c := testAssets.Clients.ResolutionRequests.ResolutionV1beta1()
+			for i, a := range tc.attempts {
+				fakeClock.SetTime(fakeClock.Now().Add(a.advance))
+				err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRequestName(rr))
+				switch {
+				case a.wantErr != "":
+					if err == nil || err.Error() != a.wantErr || !controller.IsPermanentError(err) {
+						t.Fatalf("attempt %d: expected permanent error %q but got: %v", i, a.wantErr, err)
+					}
+				case a.maxRequeue > 0:
+					ok, after := controller.IsRequeueKey(err)
+					if !ok || after < a.minRequeue || after > a.maxRequeue {
+						t.Fatalf("attempt %d: expected a requeue after %s to %s but got: %v", i, a.minRequeue, a.maxRequeue, err)
+					}
+				case err != nil:
+					t.Fatalf("attempt %d: unexpected error: %v", i, err)
+				}
+
+				reconciledRR, err := c.ResolutionRequests(rr.Namespace).Get(testAssets.Ctx, rr.Name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("getting updated ResolutionRequest: %v", err)
+				}
+				cond := reconciledRR.Status.GetCondition(apis.ConditionSucceeded)
+				if cond == nil || cond.Reason != a.wantReason || !strings.HasPrefix(cond.Message, a.wantMsg) {
+					t.Errorf("attempt %d: expected condition with reason %q and message %q but got: %v", i, a.wantReason, a.wantMsg, cond)
+				}
+			}
+
+			if got := resolver.resolved.Load(); got != tc.wantResolved {
+				t.Errorf("expected %d resolutions, got %d", tc.wantResolved, got)
+			}
+			reconciledRR, err := c.ResolutionRequests(rr.Namespace).Get(testAssets.Ctx, rr.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("getting updated ResolutionRequest: %v", err)
+			}
+			if d := cmp.Diff(base64.StdEncoding.Strict().EncodeToString([]byte(tc.wantData)), reconciledRR.Status.Data); d != "" {
+				t.Errorf("unexpected data %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func getResolverFrameworkController(ctx context.Context, t *testing.T, d test.Data, resolver framework.Resolver, modifiers ...framework.ReconcilerModifier) (test.Assets, func()) {
 	t.Helper()
 	names.TestingSeed()
@@ -437,3 +846,101 @@ func setClockOnReconciler(r *framework.Reconciler) {
 		r.Clock = testClock
 	}
 }
+
+func TestReconcileDeniedByAdmissionPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		namespace    string
+		wantErr      string
+		wantReason   string
+		wantResolved int32
+	}{{
+		name:         "admitted",
+		namespace:    "foo",
+		wantResolved: 1,
+	}, {
+		name:       "denied",
+		namespace:  "restricted",
+		wantErr:    `request denied by admission rule "restricted": request.namespace != "restricted"`,
+		wantReason: resolutioncommon.ReasonResolutionDenied,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := &v1beta1.ResolutionRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "rr",
+					Namespace: tc.namespace,
+					UID:       "rr-uid",
+					Labels: map[string]string{
+						resolutioncommon.LabelKeyResolverType: resolutionframework.LabelValueFakeResolverType,
+					},
+				},
+				Spec: v1beta1.ResolutionRequestSpec{
+					Params: []pipelinev1.Param{{
+						Name:  resolutionframework.FakeParamName,
+						Value: *pipelinev1.NewStructuredValues("bar"),
+					}},
+				},
+			}
+			d := test.Data{
+				ResolutionRequests: []*v1beta1.ResolutionRequest{rr},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "resolver-cache-config",
+						Namespace: system.Namespace(),
+					},
+					Data: map[string]string{},
+				}, {
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resolverconfig.GetFeatureFlagsConfigName(),
+						Namespace: system.Namespace(),
+					},
+					Data: map[string]string{},
+				}, {
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resolverconfig.GetAdmissionPolicyConfigName(),
+						Namespace: system.Namespace(),
+					},
+					Data: map[string]string{"restricted": `request.namespace != "restricted"`},
+				}, {
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "fake-resolver-config",
+						Namespace: system.Namespace(),
+					},
+					Data: map[string]string{},
+				}},
+			}
+			resolver := &flakyResolver{
+				FakeResolver: framework.FakeResolver{ForParam: map[string]*resolutionframework.FakeResolvedResource{
+					"bar": {Content: "some content"},
+				}},
+			}
+
+			ctx, _ := ttesting.SetupFakeContext(t)
+			testAssets, cancel := getResolverFrameworkController(ctx, t, d, resolver, setClockOnReconciler)
+			defer cancel()
+
+			err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRequestName(rr))
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr || !controller.IsPermanentError(err) {
+					t.Fatalf("expected permanent error %q but got: %v", tc.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			reconciledRR, err := testAssets.Clients.ResolutionRequests.ResolutionV1beta1().ResolutionRequests(rr.Namespace).Get(testAssets.Ctx, rr.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("getting updated ResolutionRequest: %v", err)
+			}
+			if tc.wantReason != "" {
+				cond := reconciledRR.Status.GetCondition(apis.ConditionSucceeded)
+				if cond == nil || cond.Reason != tc.wantReason || cond.Message != tc.wantErr {
+					t.Errorf("expected condition with reason %q and message %q but got: %v", tc.wantReason, tc.wantErr, cond)
+				}
+			}
+			if got := resolver.resolved.Load(); got != tc.wantResolved {
+				t.Errorf("expected %d resolutions, got %d", tc.wantResolved, got)
+			}
+		})
+	}
+}
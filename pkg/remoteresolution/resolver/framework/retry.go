@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// resolutionTimeoutConfigMapKey overrides the maximum time a single
+	// resolution attempt may take.
+	resolutionTimeoutConfigMapKey = "resolution-timeout"
+	// maxAttemptsConfigMapKey is the number of times a request is
+	// resolved before it is marked as failed.
+	maxAttemptsConfigMapKey = "resolution-max-attempts"
+	// backoffConfigMapKey is the delay before the first retry. It doubles
+	// with each further attempt.
+	backoffConfigMapKey = "resolution-backoff"
+	// maxBackoffConfigMapKey caps the delay between attempts.
+	maxBackoffConfigMapKey = "resolution-max-backoff"
+
+	defaultBackoff    = time.Second
+	defaultMaxBackoff = time.Minute
+	// backoffJitter is the maximum fraction of a delay added to it at
+	// random, so that requests failing together are not retried together.
+	backoffJitter = 0.2
+)
+
+// nonRetryableReasons are the reasons of errors that fail the same way
+// however many times a request is attempted.
+var nonRetryableReasons = []string{
+	resolutioncommon.ReasonResolutionDenied,
+	resolutioncommon.ReasonSignatureVerificationFailed,
+	resolutioncommon.ReasonResourceNotFound,
+}
+
+// retryPolicy is how long a resolver is given to resolve a request and how
+// failed attempts are retried.
+type retryPolicy struct {
+	timeout     time.Duration
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+}
+
+// retryPolicyFromContext returns the retry policy configured in the
+// ConfigMap of the resolver. Missing and invalid values use the defaults,
+// which resolve requests once.
+func retryPolicyFromContext(ctx context.Context) retryPolicy {
+	p := retryPolicy{
+		timeout:     defaultMaximumResolutionDuration,
+		maxAttempts: 1,
+		backoff:     defaultBackoff,
+		maxBackoff:  defaultMaxBackoff,
+	}
+	conf := framework.GetResolverConfigFromContext(ctx)
+	for key, d := range map[string]*time.Duration{
+		resolutionTimeoutConfigMapKey: &p.timeout,
+		backoffConfigMapKey:           &p.backoff,
+		maxBackoffConfigMapKey:        &p.maxBackoff,
+	} {
+		if parsed, err := time.ParseDuration(conf[key]); err == nil && parsed > 0 {
+			*d = parsed
+		}
+	}
+	if parsed, err := strconv.Atoi(conf[maxAttemptsConfigMapKey]); err == nil && parsed > 0 {
+		p.maxAttempts = parsed
+	}
+	if p.maxBackoff < p.backoff {
+		p.maxBackoff = p.backoff
+	}
+	return p
+}
+
+// isRetryable returns false if err fails a request regardless of how many
+// times it is attempted: invalid requests and errors with one of the
+// nonRetryableReasons.
+func isRetryable(err error) bool {
+	var invalid *resolutioncommon.InvalidRequestError
+	if errors.As(err, &invalid) {
+		return false
+	}
+	var e *resolutioncommon.Error
+	return !errors.As(err, &e) || !slices.Contains(nonRetryableReasons, e.Reason)
+}
+
+// delay returns the jittered delay before retrying a request that failed
+// the given number of attempts.
+func (p retryPolicy) delay(attempts int) time.Duration {
+	d := p.backoff
+	for i := 1; i < attempts && d < p.maxBackoff; i++ {
+		d *= 2
+	}
+	return wait.Jitter(min(d, p.maxBackoff), backoffJitter)
+}
+
+// attemptState is the number of failed attempts of a request and when it
+// may be attempted again.
+type attemptState struct {
+	attempts int
+	next     time.Time
+}
+
+// attemptTracker records the failed attempts of the requests being retried.
+// Its zero value is ready to use.
+type attemptTracker struct {
+	mu    sync.Mutex
+	state map[types.UID]attemptState
+}
+
+// get returns the state of the request with the given UID.
+func (t *attemptTracker) get(uid types.UID) (attemptState, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s, ok := t.state[uid]
+	return s, ok
+}
+
+// set records the state of the request with the given UID.
+func (t *attemptTracker) set(uid types.UID, s attemptState) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.state == nil {
+		t.state = map[types.UID]attemptState{}
+	}
+	t.state[uid] = s
+}
+
+// forget drops the state of a request that is done.
+func (t *attemptTracker) forget(uid types.UID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.state, uid)
+}
@@ -169,9 +169,21 @@ func (r *Resolver) Resolve(ctx context.Context, req *v1beta1.ResolutionRequestSp
 	}
 
 	if cache.ShouldUse(ctx, r, req.Params, labelValueGitResolverType) {
+		// Resources verified against the allowed signers are cached separately
+		// from unverified ones, so that enabling verification, or changing the
+		// signers, does not return resources cached before.
+		signersDigest, err := r.newGitResolver(params).AllowedSignersDigest(ctx)
+		if err != nil {
+			return nil, resolutioncommon.NewError(resolutioncommon.ReasonSignatureVerificationFailed, err)
+		}
+		cacheParams := req.Params
+		if signersDigest != "" {
+			cacheParams = cache.WithKeyScope(req.Params, map[string]string{"allowed-signers": signersDigest})
+		}
 		return cache.GetFromCacheOrResolve(
 			ctx,
-			req.Params,
+			r,
+			cacheParams,
 			labelValueGitResolverType,
 			func() (resolutionframework.ResolvedResource, error) {
 				return r.resolveViaGit(ctx, params)
@@ -181,14 +193,18 @@ func (r *Resolver) Resolve(ctx context.Context, req *v1beta1.ResolutionRequestSp
 	return r.resolveViaGit(ctx, params)
 }
 
-func (r *Resolver) resolveViaGit(ctx context.Context, params map[string]string) (resolutionframework.ResolvedResource, error) {
-	g := &git.GitResolver{
+func (r *Resolver) newGitResolver(params map[string]string) *git.GitResolver {
+	return &git.GitResolver{
 		KubeClient: r.kubeClient,
 		Logger:     r.logger,
 		Cache:      r.cache,
 		TTL:        r.ttl,
 		Params:     params,
 	}
+}
+
+func (r *Resolver) resolveViaGit(ctx context.Context, params map[string]string) (resolutionframework.ResolvedResource, error) {
+	g := r.newGitResolver(params)
 
 	if params[git.UrlParam] != "" {
 		return g.ResolveGitClone(ctx)
@@ -48,6 +48,7 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
 	"knative.dev/pkg/system"
 	_ "knative.dev/pkg/system/testing"
 )
@@ -326,6 +327,34 @@ func TestResolveNotEnabled(t *testing.T) {
 	}
 }
 
+func TestResolveCachedResourceRequiresSignature(t *testing.T) {
+	repoURL, commitSHAs := createTestRepo(t, []commitForRepo{{Filename: "task.yaml", Content: "unsigned"}})
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "allowed-signers", Namespace: system.Namespace()},
+		Data:       map[string][]byte{gitresolution.AllowedSignersSSHKey: []byte("tekton@example.com ssh-ed25519 AAAA")},
+	}
+	resolver := &Resolver{kubeClient: fakek8s.NewSimpleClientset(secret)}
+	req := &v1beta1.ResolutionRequestSpec{Params: toParams(map[string]string{
+		gitresolution.UrlParam:      repoURL,
+		gitresolution.RevisionParam: commitSHAs[0],
+		gitresolution.PathParam:     "task.yaml",
+	})}
+
+	// The commit SHA is cached as an immutable reference.
+	if _, err := resolver.Resolve(t.Context(), req); err != nil {
+		t.Fatalf("Resolve() returned error: %v", err)
+	}
+
+	ctx := resolutionframework.InjectResolverConfigToContext(t.Context(), map[string]string{
+		gitresolution.RequireSignatureKey:         "true",
+		gitresolution.AllowedSignersSecretNameKey: "allowed-signers",
+	})
+	_, err := resolver.Resolve(ctx, req)
+	if reason, _ := common.ReasonError(err); reason != common.ReasonSignatureVerificationFailed {
+		t.Fatalf("Expected the cached unsigned commit to fail verification, got %v", err)
+	}
+}
+
 type params struct {
 	url        string
 	revision   string
@@ -17,8 +17,10 @@ import (
 	"context"
 	"errors"
 
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/framework"
+	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/framework/cache"
 	"github.com/tektoncd/pipeline/pkg/resolution/common"
 	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/http"
@@ -37,10 +39,12 @@ const (
 	configMapName              = "http-resolver-config"
 	defaultHttpTimeoutValue    = "1m"
 	defaultBasicAuthSecretKey  = "password" // default key in the HTTP password secret
+	digestParam                = "digest"
 )
 
 var _ framework.Resolver = (*Resolver)(nil)
 var _ resolutionframework.ConfigWatcher = (*Resolver)(nil)
+var _ cache.ImmutabilityChecker = (*Resolver)(nil)
 
 // Resolver implements a framework.Resolver that can fetch files from an HTTP URL
 type Resolver struct {
@@ -76,7 +80,21 @@ func (r *Resolver) Validate(ctx context.Context, req *v1beta1.ResolutionRequestS
 	return http.ValidateParams(ctx, req.Params)
 }
 
+// IsImmutable implements ImmutabilityChecker.IsImmutable
+// Returns true if the digest parameter is set, since the content is then
+// verified against it.
+func (r *Resolver) IsImmutable(params []v1.Param) bool {
+	for _, param := range params {
+		if param.Name == digestParam {
+			return param.Value.StringVal != ""
+		}
+	}
+	return false
+}
+
 // Resolve uses the given params to resolve the requested file or resource.
+// Cached resources are revalidated with conditional requests once their TTL
+// has passed.
 func (r *Resolver) Resolve(ctx context.Context, req *v1beta1.ResolutionRequestSpec) (resolutionframework.ResolvedResource, error) {
 	if http.IsDisabled(ctx) {
 		return nil, errors.New(disabledError)
@@ -87,5 +105,27 @@ func (r *Resolver) Resolve(ctx context.Context, req *v1beta1.ResolutionRequestSp
 		return nil, err
 	}
 
+	if cache.ShouldUse(ctx, r, req.Params, LabelValueHttpResolverType) {
+		// Content fetched with the secrets of one namespace must not be
+		// returned to requests from other namespaces.
+		cacheParams := req.Params
+		if http.UsesRequestSecrets(params) {
+			cacheParams = cache.WithKeyScope(req.Params, map[string]string{"namespace": common.RequestNamespace(ctx)})
+		}
+		return cache.GetFromCacheOrRevalidate(
+			ctx,
+			r,
+			cacheParams,
+			LabelValueHttpResolverType,
+			func(stale resolutionframework.ResolvedResource) (resolutionframework.ResolvedResource, bool, error) {
+				if stale == nil {
+					resource, err := http.FetchHttpResource(ctx, params, r.kubeClient, r.logger)
+					return resource, true, err
+				}
+				return http.RevalidateHttpResource(ctx, params, r.kubeClient, r.logger, stale)
+			},
+		)
+	}
+
 	return http.FetchHttpResource(ctx, params, r.kubeClient, r.logger)
 }
@@ -45,6 +45,8 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	logtesting "knative.dev/pkg/logging/testing"
 	"knative.dev/pkg/system"
 	_ "knative.dev/pkg/system/testing"
 )
@@ -214,6 +216,112 @@ func TestResolve(t *testing.T) {
 	}
 }
 
+func TestIsImmutable(t *testing.T) {
+	resolver := Resolver{}
+	for _, tc := range []struct {
+		name     string
+		params   map[string]string
+		expected bool
+	}{
+		{name: "url only", params: map[string]string{httpresolution.UrlParam: "https://example.com/task.yaml"}},
+		{name: "empty digest", params: map[string]string{httpresolution.UrlParam: "https://example.com/task.yaml", "digest": ""}},
+		{name: "digest", params: map[string]string{httpresolution.UrlParam: "https://example.com/task.yaml", "digest": "sha256:abcdef"}, expected: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := resolver.IsImmutable(toParams(tc.params)); got != tc.expected {
+				t.Errorf("IsImmutable() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestResolveRevalidatesCachedResource(t *testing.T) {
+	ctx, _ := ttesting.SetupFakeContext(t)
+	resolver := Resolver{}
+	if err := resolver.Initialize(ctx); err != nil {
+		t.Fatalf("failed to initialize resolver: %v", err)
+	}
+
+	// Cached entries become stale immediately, so every request after the
+	// first one is a conditional request.
+	ctx = resolutionframework.InjectResolverConfigToContext(t.Context(), map[string]string{
+		httpresolution.TimeoutKey: defaultHttpTimeoutValue,
+		"cache-ttl":               "1ns",
+	})
+
+	var requests, notModified int
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, "task")
+	}))
+	defer svr.Close()
+
+	req := v1beta1.ResolutionRequestSpec{Params: toParams(map[string]string{
+		httpresolution.UrlParam: svr.URL,
+		"cache":                 "always",
+	})}
+	for range 3 {
+		output, err := resolver.Resolve(ctx, &req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(output.Data()) != "task" {
+			t.Fatalf("expected output 'task' but got '%s'", output.Data())
+		}
+	}
+	if requests != 3 || notModified != 2 {
+		t.Errorf("expected 3 requests of which 2 not modified, got %d and %d", requests, notModified)
+	}
+}
+
+func TestResolveCachesCredentialedResourcesPerNamespace(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "user" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, "task")
+	}))
+	defer svr.Close()
+
+	// Only namespace a has the secret referenced by the request.
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "auth-secret", Namespace: "a"},
+		Data:       map[string][]byte{defaultBasicAuthSecretKey: []byte("secret")},
+	}
+	resolver := Resolver{kubeClient: fakek8s.NewSimpleClientset(secret), logger: logtesting.TestLogger(t)}
+	sum := sha256.Sum256([]byte("task"))
+	req := v1beta1.ResolutionRequestSpec{Params: toParams(map[string]string{
+		httpresolution.UrlParam:              svr.URL,
+		httpresolution.HttpBasicAuthUsername: "user",
+		httpresolution.HttpBasicAuthSecret:   "auth-secret",
+		digestParam:                          "sha256:" + hex.EncodeToString(sum[:]),
+	})}
+	ctx := resolutionframework.InjectResolverConfigToContext(t.Context(), map[string]string{
+		httpresolution.TimeoutKey: defaultHttpTimeoutValue,
+	})
+
+	output, err := resolver.Resolve(resolutioncommon.InjectRequestNamespace(ctx, "a"), &req)
+	if err != nil {
+		t.Fatalf("unexpected error resolving from namespace a: %v", err)
+	}
+	if string(output.Data()) != "task" {
+		t.Fatalf("expected output 'task' but got '%s'", output.Data())
+	}
+
+	// The same params from namespace b are not served from the cache, so
+	// resolution fails since b has no such secret.
+	if _, err := resolver.Resolve(resolutioncommon.InjectRequestNamespace(ctx, "b"), &req); err == nil {
+		t.Fatal("expected an error resolving with a secret missing from namespace b")
+	}
+}
+
 func TestResolveNotEnabled(t *testing.T) {
 	ctx, _ := ttesting.SetupFakeContext(t)
 
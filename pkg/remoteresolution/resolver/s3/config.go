@@ -0,0 +1,46 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+const (
+	// TimeoutKey is the configuration field name for controlling
+	// the maximum duration of fetching an object.
+	TimeoutKey = "fetch-timeout"
+
+	// DefaultRegionKey is the configuration field name for the region used
+	// when a request does not specify one.
+	DefaultRegionKey = "default-region"
+
+	// DefaultEndpointKey is the configuration field name for the URL of the
+	// S3-compatible service used when a request does not specify one.
+	DefaultEndpointKey = "default-endpoint"
+
+	// CredentialsSecretNameKey is the configuration field name for the secret
+	// in the resolvers namespace holding the access key used for requests to
+	// the default endpoint that do not specify their own credentials.
+	CredentialsSecretNameKey = "credentials-secret-name"
+
+	// AccessKeyIDKey is the key in a credentials secret holding the access key ID.
+	AccessKeyIDKey = "access-key-id"
+
+	// SecretAccessKeyKey is the key in a credentials secret holding the secret access key.
+	SecretAccessKeyKey = "secret-access-key"
+
+	// SessionTokenKey is the optional key in a credentials secret holding a
+	// session token for temporary credentials.
+	SessionTokenKey = "session-token"
+)
@@ -0,0 +1,238 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// emptyPayloadHash is the SHA-256 of the empty body of GET requests.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	// maxErrorBodySize limits how much of an error response is read.
+	maxErrorBodySize = 64 * 1024
+
+	signingService = "s3"
+)
+
+// s3Error is the body of an S3 error response.
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// url returns the URL of the object, using a virtual-hosted-style URL for
+// Amazon S3 and a path-style URL for other endpoints. Bucket names with dots
+// also use path-style URLs, since their virtual host does not match the
+// wildcard certificate of Amazon S3.
+func (obj *objectRequest) url() *url.URL {
+	var u url.URL
+	virtualHosted := obj.endpoint == nil && !strings.Contains(obj.bucket, ".")
+	switch {
+	case obj.endpoint != nil:
+		u = *obj.endpoint
+	case virtualHosted:
+		u = url.URL{Scheme: "https", Host: obj.bucket + ".s3." + obj.region + ".amazonaws.com"}
+	default:
+		u = url.URL{Scheme: "https", Host: "s3." + obj.region + ".amazonaws.com"}
+	}
+	if !virtualHosted {
+		u.RawPath = strings.TrimSuffix(u.EscapedPath(), "/") + "/" + uriEncode(obj.bucket, false)
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + obj.bucket
+	}
+	u.Path += "/" + obj.key
+	u.RawPath = strings.TrimSuffix(u.RawPath, "/") + "/" + uriEncode(obj.key, true)
+	if obj.version != "" {
+		u.RawQuery = url.Values{"versionId": []string{obj.version}}.Encode()
+	}
+	return &u
+}
+
+// uriEncode escapes s as required by the canonical request of Signature
+// Version 4, which unlike url.PathEscape encodes every byte other than the
+// unreserved characters.
+func uriEncode(s string, keepSlash bool) string {
+	var b strings.Builder
+	for i := range len(s) {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', keepSlash && c == '/':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// getCredentials reads the access key from the secret referenced by ref.
+func getCredentials(ctx context.Context, ref secretRef, kubeclient kubernetes.Interface, logger *zap.SugaredLogger) (aws.Credentials, error) {
+	secret, err := kubeclient.CoreV1().Secrets(ref.namespace).Get(ctx, ref.name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			notFoundErr := fmt.Errorf("cannot get credentials, secret %s not found in namespace %s", ref.name, ref.namespace)
+			logger.Info(notFoundErr)
+			return aws.Credentials{}, notFoundErr
+		}
+		wrappedErr := fmt.Errorf("error reading credentials from secret %s in namespace %s: %w", ref.name, ref.namespace, err)
+		logger.Info(wrappedErr)
+		return aws.Credentials{}, wrappedErr
+	}
+	creds := aws.Credentials{
+		AccessKeyID:     strings.TrimSpace(string(secret.Data[AccessKeyIDKey])),
+		SecretAccessKey: strings.TrimSpace(string(secret.Data[SecretAccessKeyKey])),
+		SessionToken:    strings.TrimSpace(string(secret.Data[SessionTokenKey])),
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return aws.Credentials{}, fmt.Errorf("secret %s in namespace %s must have both a %s and a %s key", ref.name, ref.namespace, AccessKeyIDKey, SecretAccessKeyKey)
+	}
+	return creds, nil
+}
+
+func makeHttpClient(ctx context.Context) (*http.Client, error) {
+	conf := resolutionframework.GetResolverConfigFromContext(ctx)
+	timeout, _ := time.ParseDuration(defaultTimeoutValue)
+	if v, ok := conf[TimeoutKey]; ok {
+		var err error
+		timeout, err = time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing timeout value %s: %w", v, err)
+		}
+	}
+	return &http.Client{Timeout: timeout}, nil
+}
+
+// fetchObject gets the object described by obj, signing the request with
+// Signature Version 4 if credentials are configured. If the service returns
+// a full object SHA-256 checksum, the content is verified against it.
+func fetchObject(ctx context.Context, obj *objectRequest, kubeclient kubernetes.Interface, logger *zap.SugaredLogger) (resolutionframework.ResolvedResource, error) {
+	httpClient, err := makeHttpClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	objectURL := obj.url()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, objectURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("constructing request: %w", err)
+	}
+	req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+	req.Header.Set("X-Amz-Checksum-Mode", "ENABLED")
+
+	if obj.credentials != nil {
+		creds, err := getCredentials(ctx, *obj.credentials, kubeclient, logger)
+		if err != nil {
+			return nil, err
+		}
+		signer := v4.NewSigner(func(o *v4.SignerOptions) {
+			o.DisableURIPathEscaping = true
+		})
+		if err := signer.SignHTTP(ctx, creds, req, emptyPayloadHash, signingService, obj.region, time.Now()); err != nil {
+			return nil, fmt.Errorf("error signing request: %w", err)
+		}
+	}
+
+	// #nosec G704 -- URL cannot be constant in this case.
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching object: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, objectError(obj, resp)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, resolutionframework.MaxResolvedResourceSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+	if len(body) > resolutionframework.MaxResolvedResourceSize {
+		return nil, fmt.Errorf("object %s in bucket %s is larger than %d bytes", obj.key, obj.bucket, resolutionframework.MaxResolvedResourceSize)
+	}
+	if err := verifyChecksum(resp.Header, body); err != nil {
+		return nil, fmt.Errorf("error validating object %s in bucket %s: %w", obj.key, obj.bucket, err)
+	}
+
+	versionID := resp.Header.Get("X-Amz-Version-Id")
+	// Objects in buckets that never had versioning enabled have no version.
+	if versionID == "null" {
+		versionID = ""
+	}
+
+	objectURL.RawQuery = ""
+	return &resolvedS3Resource{
+		url:       objectURL.String(),
+		content:   body,
+		versionID: versionID,
+	}, nil
+}
+
+// objectError returns the error for an unsuccessful response, including the
+// code and message of the S3 error if the body holds one.
+func objectError(obj *objectRequest, resp *http.Response) error {
+	what := fmt.Sprintf("object %s in bucket %s", obj.key, obj.bucket)
+	if obj.version != "" {
+		what = fmt.Sprintf("version %s of %s", obj.version, what)
+	}
+	var s3Err s3Error
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err := xml.Unmarshal(body, &s3Err); err == nil && s3Err.Code != "" {
+		return fmt.Errorf("error fetching %s: %s: %s", what, s3Err.Code, s3Err.Message)
+	}
+	return fmt.Errorf("error fetching %s: %s", what, resp.Status)
+}
+
+// verifyChecksum compares body with the SHA-256 checksum returned by the
+// service. Checksums of multipart uploads are composed of the checksums of
+// the parts and cannot be verified against the content.
+func verifyChecksum(header http.Header, body []byte) error {
+	checksum := header.Get("X-Amz-Checksum-Sha256")
+	if checksum == "" || header.Get("X-Amz-Checksum-Type") == "COMPOSITE" || strings.Contains(checksum, "-") {
+		return nil
+	}
+	expected, err := base64.StdEncoding.DecodeString(checksum)
+	if err != nil {
+		return fmt.Errorf("error decoding checksum %s: %w", checksum, err)
+	}
+	sum := sha256.Sum256(body)
+	if subtle.ConstantTimeCompare(expected, sum[:]) != 1 {
+		return fmt.Errorf("SHA-256 checksum mismatch, expected %s, got %s", checksum, base64.StdEncoding.EncodeToString(sum[:]))
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+const (
+	// BucketParam is the name of the bucket holding the object
+	BucketParam string = "bucket"
+
+	// KeyParam is the key of the object to fetch
+	KeyParam string = "key"
+
+	// VersionParam is the version ID of the object to fetch, defaults to the latest version
+	VersionParam string = "version"
+
+	// RegionParam is the region of the bucket, used to sign the request
+	RegionParam string = "region"
+
+	// EndpointParam is the URL of an S3-compatible service to fetch the object from
+	EndpointParam string = "endpoint"
+
+	// CredentialsSecretParam is the reference to a secret in the PipelineRun or TaskRun namespace
+	// holding the access key used to sign the request
+	CredentialsSecretParam string = "credentials-secret"
+)
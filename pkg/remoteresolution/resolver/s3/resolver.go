@@ -0,0 +1,274 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	resolverconfig "github.com/tektoncd/pipeline/pkg/apis/config/resolver"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/framework"
+	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/framework/cache"
+	"github.com/tektoncd/pipeline/pkg/resolution/common"
+	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// LabelValueS3ResolverType is the value to use for the
+	// resolution.tekton.dev/type label on resource requests
+	LabelValueS3ResolverType = "s3"
+	disabledError            = "cannot handle resolution request, enable-s3-resolver feature flag not true"
+	s3ResolverName           = "S3"
+	configMapName            = "s3-resolver-config"
+	defaultTimeoutValue      = "1m"
+	defaultRegion            = "us-east-1"
+
+	// versionIDDigestKey is the key of the object's version ID in the
+	// digest of the RefSource.
+	versionIDDigestKey = "versionId"
+)
+
+var _ framework.Resolver = (*Resolver)(nil)
+var _ resolutionframework.ConfigWatcher = (*Resolver)(nil)
+var _ cache.ImmutabilityChecker = (*Resolver)(nil)
+
+// Resolver implements a framework.Resolver that can fetch objects from
+// Amazon S3 and S3-compatible object storage.
+type Resolver struct {
+	kubeClient kubernetes.Interface
+	logger     *zap.SugaredLogger
+}
+
+// Initialize sets up the resolver's kubernetes client and logger.
+func (r *Resolver) Initialize(ctx context.Context) error {
+	r.kubeClient = kubeclient.Get(ctx)
+	r.logger = logging.FromContext(ctx)
+	return nil
+}
+
+// GetName returns a string name to refer to this resolver by.
+func (r *Resolver) GetName(_ context.Context) string {
+	return s3ResolverName
+}
+
+// GetConfigName returns the name of the s3 resolver's configmap.
+func (r *Resolver) GetConfigName(_ context.Context) string {
+	return configMapName
+}
+
+// GetSelector returns a map of labels to match requests to this resolver.
+func (r *Resolver) GetSelector(_ context.Context) map[string]string {
+	return map[string]string{
+		common.LabelKeyResolverType: LabelValueS3ResolverType,
+	}
+}
+
+// Validate ensures parameters from a request are as expected.
+func (r *Resolver) Validate(ctx context.Context, req *v1beta1.ResolutionRequestSpec) error {
+	if isDisabled(ctx) {
+		return errors.New(disabledError)
+	}
+	_, err := parseParams(ctx, req.Params)
+	return err
+}
+
+// IsImmutable implements ImmutabilityChecker.IsImmutable
+// Returns true if the version parameter is set, since a version of an
+// object cannot be modified.
+func (r *Resolver) IsImmutable(params []pipelinev1.Param) bool {
+	for _, param := range params {
+		if param.Name == VersionParam {
+			return param.Value.StringVal != ""
+		}
+	}
+	return false
+}
+
+// Resolve uses the given params to fetch the requested object.
+func (r *Resolver) Resolve(ctx context.Context, req *v1beta1.ResolutionRequestSpec) (resolutionframework.ResolvedResource, error) {
+	if isDisabled(ctx) {
+		return nil, errors.New(disabledError)
+	}
+
+	obj, err := parseParams(ctx, req.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	if cache.ShouldUse(ctx, r, req.Params, LabelValueS3ResolverType) {
+		// Objects fetched with the credentials of one namespace must not be
+		// returned to requests from other namespaces.
+		cacheParams := req.Params
+		if slices.ContainsFunc(req.Params, func(p pipelinev1.Param) bool { return p.Name == CredentialsSecretParam }) {
+			cacheParams = cache.WithKeyScope(req.Params, map[string]string{"namespace": common.RequestNamespace(ctx)})
+		}
+		return cache.GetFromCacheOrResolve(
+			ctx,
+			r,
+			cacheParams,
+			LabelValueS3ResolverType,
+			func() (resolutionframework.ResolvedResource, error) {
+				return fetchObject(ctx, obj, r.kubeClient, r.logger)
+			},
+		)
+	}
+	return fetchObject(ctx, obj, r.kubeClient, r.logger)
+}
+
+func isDisabled(ctx context.Context) bool {
+	cfg := resolverconfig.FromContextOrDefaults(ctx)
+	return !cfg.FeatureFlags.EnableS3Resolver
+}
+
+// objectRequest describes the object to fetch and how to authenticate.
+type objectRequest struct {
+	bucket  string
+	key     string
+	version string
+	region  string
+	// endpoint is the URL of the S3-compatible service, or nil for Amazon S3.
+	endpoint *url.URL
+	// credentials is the secret holding the access key, or nil to send
+	// anonymous requests.
+	credentials *secretRef
+}
+
+// secretRef references a secret holding an access key.
+type secretRef struct {
+	namespace string
+	name      string
+}
+
+// parseParams validates params and fills in the defaults from the resolver
+// configuration.
+func parseParams(ctx context.Context, params []pipelinev1.Param) (*objectRequest, error) {
+	paramsMap := make(map[string]string)
+	for _, p := range params {
+		paramsMap[p.Name] = p.Value.StringVal
+	}
+	conf := resolutionframework.GetResolverConfigFromContext(ctx)
+
+	var missingParams []string
+	for _, param := range []string{BucketParam, KeyParam} {
+		if paramsMap[param] == "" {
+			missingParams = append(missingParams, param)
+		}
+	}
+	if len(missingParams) > 0 {
+		return nil, fmt.Errorf("missing required s3 resolver params: %s", strings.Join(missingParams, ", "))
+	}
+
+	obj := &objectRequest{
+		bucket:  paramsMap[BucketParam],
+		key:     strings.TrimPrefix(paramsMap[KeyParam], "/"),
+		version: paramsMap[VersionParam],
+		region:  paramsMap[RegionParam],
+	}
+	if strings.Contains(obj.bucket, "/") {
+		return nil, fmt.Errorf("invalid bucket name %s", obj.bucket)
+	}
+	if obj.key == "" {
+		return nil, fmt.Errorf("invalid object key %s", paramsMap[KeyParam])
+	}
+	if obj.region == "" {
+		obj.region = conf[DefaultRegionKey]
+	}
+	if obj.region == "" {
+		obj.region = defaultRegion
+	}
+
+	endpoint := paramsMap[EndpointParam]
+	if endpoint == "" {
+		endpoint = conf[DefaultEndpointKey]
+	}
+	if endpoint != "" {
+		u, err := url.ParseRequestURI(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse endpoint %s: %w", endpoint, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("endpoint %s is not a valid http(s) url", endpoint)
+		}
+		if u.RawQuery != "" || u.Fragment != "" {
+			return nil, fmt.Errorf("endpoint %s must not have a query or fragment", endpoint)
+		}
+		obj.endpoint = u
+	}
+
+	// The credentials from the resolver configuration are only sent to the
+	// default endpoint, so that requests cannot send them to a server of
+	// their choosing.
+	if name, ok := paramsMap[CredentialsSecretParam]; ok {
+		if name == "" {
+			return nil, fmt.Errorf("value %s cannot be empty", CredentialsSecretParam)
+		}
+		obj.credentials = &secretRef{namespace: common.RequestNamespace(ctx), name: name}
+	} else if name := conf[CredentialsSecretNameKey]; name != "" && paramsMap[EndpointParam] == "" {
+		obj.credentials = &secretRef{namespace: os.Getenv("SYSTEM_NAMESPACE"), name: name}
+	}
+
+	return obj, nil
+}
+
+// resolvedS3Resource wraps the data we want to return to Pipelines
+type resolvedS3Resource struct {
+	url       string
+	content   []byte
+	versionID string
+}
+
+var _ resolutionframework.ResolvedResource = &resolvedS3Resource{}
+
+// Data returns the content of the object.
+func (rr *resolvedS3Resource) Data() []byte {
+	return rr.content
+}
+
+// Annotations returns nil, objects are not annotated.
+func (rr *resolvedS3Resource) Annotations() map[string]string {
+	return nil
+}
+
+// RefSource is the source reference of the remote data that records the URL
+// of the object along with its checksum and, for versioned buckets, its
+// version ID.
+func (rr *resolvedS3Resource) RefSource() *pipelinev1.RefSource {
+	sum := sha256.Sum256(rr.content)
+	digest := map[string]string{
+		"sha256": hex.EncodeToString(sum[:]),
+	}
+	if rr.versionID != "" {
+		digest[versionIDDigestKey] = rr.versionID
+	}
+	return &pipelinev1.RefSource{
+		URI:    rr.url,
+		Digest: digest,
+	}
+}
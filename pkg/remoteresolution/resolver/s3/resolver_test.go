@@ -0,0 +1,539 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/google/go-cmp/cmp"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/resolution/common"
+	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	frameworktesting "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/system"
+	_ "knative.dev/pkg/system/testing"
+)
+
+const sampleTask = `---
+kind: Task
+apiVersion: tekton.dev/v1
+metadata:
+  name: foo
+spec:
+  steps:
+  - name: step1
+    image: scratch`
+
+type objectVersion struct {
+	id      string
+	content string
+	// checksum overrides the SHA-256 checksum returned for the version.
+	checksum string
+}
+
+// fakeS3 is a minimal S3-compatible service serving path-style GET requests.
+// If credentials are set, requests must be signed with them.
+type fakeS3 struct {
+	region      string
+	credentials *aws.Credentials
+	// objects maps "bucket/key" to the versions of the object, latest last.
+	objects map[string][]objectVersion
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if f.credentials != nil {
+		if err := f.verifySignature(r); err != nil {
+			writeS3Error(w, http.StatusForbidden, "AccessDenied", err.Error())
+			return
+		}
+	}
+	versions := f.objects[strings.TrimPrefix(r.URL.Path, "/")]
+	if len(versions) == 0 {
+		writeS3Error(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+		return
+	}
+	version := versions[len(versions)-1]
+	if id := r.URL.Query().Get("versionId"); id != "" {
+		found := false
+		for _, v := range versions {
+			if v.id == id {
+				version, found = v, true
+			}
+		}
+		if !found {
+			writeS3Error(w, http.StatusNotFound, "NoSuchVersion", "The specified version does not exist.")
+			return
+		}
+	}
+	if version.id != "" {
+		w.Header().Set("X-Amz-Version-Id", version.id)
+	}
+	if r.Header.Get("X-Amz-Checksum-Mode") == "ENABLED" {
+		checksum := version.checksum
+		if checksum == "" {
+			sum := sha256.Sum256([]byte(version.content))
+			checksum = base64.StdEncoding.EncodeToString(sum[:])
+		}
+		w.Header().Set("X-Amz-Checksum-Sha256", checksum)
+	}
+	fmt.Fprint(w, version.content)
+}
+
+// verifySignature signs a copy of r with the expected credentials and
+// compares the signatures.
+func (f *fakeS3) verifySignature(r *http.Request) error {
+	auth := r.Header.Get("Authorization")
+	if auth == "" {
+		return fmt.Errorf("request is not signed")
+	}
+	signingTime, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return err
+	}
+	expected, err := http.NewRequestWithContext(r.Context(), r.Method, "http://"+r.Host+r.RequestURI, nil)
+	if err != nil {
+		return err
+	}
+	for _, h := range []string{"X-Amz-Content-Sha256", "X-Amz-Checksum-Mode"} {
+		expected.Header.Set(h, r.Header.Get(h))
+	}
+	signer := v4.NewSigner(func(o *v4.SignerOptions) {
+		o.DisableURIPathEscaping = true
+	})
+	if err := signer.SignHTTP(r.Context(), *f.credentials, expected, r.Header.Get("X-Amz-Content-Sha256"), "s3", f.region, signingTime); err != nil {
+		return err
+	}
+	if expected.Header.Get("Authorization") != auth {
+		return fmt.Errorf("signature does not match")
+	}
+	return nil
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>%s</Code><Message>%s</Message></Error>`, code, message)
+}
+
+func sha256Hex(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestGetSelector(t *testing.T) {
+	resolver := Resolver{}
+	sel := resolver.GetSelector(t.Context())
+	if typ, has := sel[common.LabelKeyResolverType]; !has {
+		t.Fatalf("unexpected selector: %v", sel)
+	} else if typ != LabelValueS3ResolverType {
+		t.Fatalf("unexpected type: %q", typ)
+	}
+}
+
+func TestGetName(t *testing.T) {
+	resolver := Resolver{}
+	ctx := t.Context()
+
+	if d := cmp.Diff(s3ResolverName, resolver.GetName(ctx)); d != "" {
+		t.Errorf("invalid name: %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(configMapName, resolver.GetConfigName(ctx)); d != "" {
+		t.Errorf("invalid config map name: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		params      map[string]string
+		expectedErr string
+	}{
+		{
+			name:   "valid",
+			params: map[string]string{BucketParam: "tasks", KeyParam: "build/task.yaml"},
+		}, {
+			name:   "valid with endpoint and version",
+			params: map[string]string{BucketParam: "tasks", KeyParam: "task.yaml", VersionParam: "v1", EndpointParam: "https://minio.example.com:9000"},
+		}, {
+			name:        "missing bucket and key",
+			params:      map[string]string{RegionParam: "eu-west-1"},
+			expectedErr: "missing required s3 resolver params: bucket, key",
+		}, {
+			name:        "invalid bucket",
+			params:      map[string]string{BucketParam: "tasks/build", KeyParam: "task.yaml"},
+			expectedErr: "invalid bucket name tasks/build",
+		}, {
+			name:        "invalid key",
+			params:      map[string]string{BucketParam: "tasks", KeyParam: "/"},
+			expectedErr: "invalid object key /",
+		}, {
+			name:        "endpoint is not http",
+			params:      map[string]string{BucketParam: "tasks", KeyParam: "task.yaml", EndpointParam: "ftp://minio.example.com"},
+			expectedErr: "endpoint ftp://minio.example.com is not a valid http(s) url",
+		}, {
+			name:        "endpoint with query",
+			params:      map[string]string{BucketParam: "tasks", KeyParam: "task.yaml", EndpointParam: "https://minio.example.com?x=y"},
+			expectedErr: "endpoint https://minio.example.com?x=y must not have a query or fragment",
+		}, {
+			name:        "empty credentials secret",
+			params:      map[string]string{BucketParam: "tasks", KeyParam: "task.yaml", CredentialsSecretParam: ""},
+			expectedErr: "value credentials-secret cannot be empty",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := Resolver{}
+			ctx := frameworktesting.ContextWithS3ResolverEnabled(t.Context())
+			err := resolver.Validate(ctx, &v1beta1.ResolutionRequestSpec{Params: toParams(tc.params)})
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error validating params: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expectedErr {
+				t.Fatalf("expected err '%v' but got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestIsImmutable(t *testing.T) {
+	resolver := Resolver{}
+	for _, tc := range []struct {
+		name     string
+		params   map[string]string
+		expected bool
+	}{
+		{name: "latest version", params: map[string]string{BucketParam: "tasks", KeyParam: "task.yaml"}},
+		{name: "empty version", params: map[string]string{BucketParam: "tasks", KeyParam: "task.yaml", VersionParam: ""}},
+		{name: "version", params: map[string]string{BucketParam: "tasks", KeyParam: "task.yaml", VersionParam: "v1"}, expected: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := resolver.IsImmutable(toParams(tc.params)); got != tc.expected {
+				t.Errorf("IsImmutable() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestObjectURL(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		obj      objectRequest
+		endpoint string
+		expected string
+	}{
+		{
+			name:     "amazon s3",
+			obj:      objectRequest{bucket: "tasks", key: "build/task.yaml", region: "eu-west-1"},
+			expected: "https://tasks.s3.eu-west-1.amazonaws.com/build/task.yaml",
+		}, {
+			name:     "amazon s3 bucket with dots",
+			obj:      objectRequest{bucket: "tekton.tasks", key: "build/task.yaml", region: "eu-west-1"},
+			expected: "https://s3.eu-west-1.amazonaws.com/tekton.tasks/build/task.yaml",
+		}, {
+			name:     "endpoint with version",
+			obj:      objectRequest{bucket: "tasks", key: "task.yaml", version: "3/L4kqtJl40Nr8X8gdRQBpUMLUo", region: "us-east-1"},
+			endpoint: "http://minio.example.com:9000",
+			expected: "http://minio.example.com:9000/tasks/task.yaml?versionId=3%2FL4kqtJl40Nr8X8gdRQBpUMLUo",
+		}, {
+			name:     "endpoint with path",
+			obj:      objectRequest{bucket: "tasks", key: "task.yaml", region: "us-east-1"},
+			endpoint: "https://storage.example.com/s3/",
+			expected: "https://storage.example.com/s3/tasks/task.yaml",
+		}, {
+			name:     "key with reserved characters",
+			obj:      objectRequest{bucket: "tasks", key: "release 1.0/task+(x).yaml", region: "us-east-1"},
+			endpoint: "http://minio.example.com",
+			expected: "http://minio.example.com/tasks/release%201.0/task%2B%28x%29.yaml",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := tc.obj
+			if tc.endpoint != "" {
+				req, err := parseParams(t.Context(), toParams(map[string]string{BucketParam: "b", KeyParam: "k", EndpointParam: tc.endpoint}))
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				obj.endpoint = req.endpoint
+			}
+			if d := cmp.Diff(tc.expected, obj.url().String()); d != "" {
+				t.Errorf("unexpected url: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	requestCreds := aws.Credentials{AccessKeyID: "request-key", SecretAccessKey: "request-secret", SessionToken: "request-token"}
+	configCreds := aws.Credentials{AccessKeyID: "config-key", SecretAccessKey: "config-secret"}
+	secrets := []*corev1.Secret{{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3-creds", Namespace: "foo"},
+		Data: map[string][]byte{
+			AccessKeyIDKey:     []byte(requestCreds.AccessKeyID),
+			SecretAccessKeyKey: []byte(requestCreds.SecretAccessKey),
+			SessionTokenKey:    []byte(requestCreds.SessionToken),
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "s3-creds", Namespace: system.Namespace()},
+		Data: map[string][]byte{
+			AccessKeyIDKey:     []byte(configCreds.AccessKeyID),
+			SecretAccessKeyKey: []byte(configCreds.SecretAccessKey),
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "incomplete-creds", Namespace: "foo"},
+		Data:       map[string][]byte{AccessKeyIDKey: []byte("key")},
+	}}
+	objects := map[string][]objectVersion{
+		"tasks/build/task.yaml": {
+			{id: "v1", content: "old task"},
+			{id: "v2", content: sampleTask},
+		},
+		"tasks/release 1.0/task+x.yaml": {{content: sampleTask}},
+		"tasks/corrupt.yaml":            {{content: sampleTask, checksum: base64.StdEncoding.EncodeToString([]byte("not the checksum"))}},
+		"tasks/large.yaml":              {{content: strings.Repeat("a", resolutionframework.MaxResolvedResourceSize+1)}},
+	}
+
+	tests := []struct {
+		name              string
+		params            map[string]string
+		conf              map[string]string
+		serverCredentials *aws.Credentials
+		expectedContent   string
+		expectedVersionID string
+		expectedErr       string
+	}{
+		{
+			name:              "latest version of public object",
+			params:            map[string]string{BucketParam: "tasks", KeyParam: "build/task.yaml"},
+			expectedContent:   sampleTask,
+			expectedVersionID: "v2",
+		}, {
+			name:              "specific version",
+			params:            map[string]string{BucketParam: "tasks", KeyParam: "build/task.yaml", VersionParam: "v1"},
+			expectedContent:   "old task",
+			expectedVersionID: "v1",
+		}, {
+			name:              "signed request for unversioned object with reserved characters",
+			params:            map[string]string{BucketParam: "tasks", KeyParam: "release 1.0/task+x.yaml", RegionParam: "eu-west-1", CredentialsSecretParam: "s3-creds"},
+			serverCredentials: &requestCreds,
+			expectedContent:   sampleTask,
+		}, {
+			name:              "signed with request credentials",
+			params:            map[string]string{BucketParam: "tasks", KeyParam: "build/task.yaml", RegionParam: "eu-west-1", CredentialsSecretParam: "s3-creds"},
+			serverCredentials: &requestCreds,
+			expectedContent:   sampleTask,
+			expectedVersionID: "v2",
+		}, {
+			name:              "signed with config credentials",
+			params:            map[string]string{BucketParam: "tasks", KeyParam: "build/task.yaml"},
+			conf:              map[string]string{DefaultRegionKey: "eu-west-1", CredentialsSecretNameKey: "s3-creds"},
+			serverCredentials: &configCreds,
+			expectedContent:   sampleTask,
+			expectedVersionID: "v2",
+		}, {
+			name:              "config credentials are not sent to requested endpoint",
+			params:            map[string]string{BucketParam: "tasks", KeyParam: "build/task.yaml", EndpointParam: "endpoint"},
+			conf:              map[string]string{DefaultRegionKey: "eu-west-1", CredentialsSecretNameKey: "s3-creds"},
+			serverCredentials: &configCreds,
+			expectedErr:       "error fetching object build/task.yaml in bucket tasks: AccessDenied: request is not signed",
+		}, {
+			name:              "wrong region",
+			params:            map[string]string{BucketParam: "tasks", KeyParam: "build/task.yaml", CredentialsSecretParam: "s3-creds"},
+			serverCredentials: &requestCreds,
+			expectedErr:       "error fetching object build/task.yaml in bucket tasks: AccessDenied: signature does not match",
+		}, {
+			name:        "missing object",
+			params:      map[string]string{BucketParam: "tasks", KeyParam: "missing.yaml"},
+			expectedErr: "error fetching object missing.yaml in bucket tasks: NoSuchKey: The specified key does not exist.",
+		}, {
+			name:        "missing version",
+			params:      map[string]string{BucketParam: "tasks", KeyParam: "build/task.yaml", VersionParam: "v3"},
+			expectedErr: "error fetching version v3 of object build/task.yaml in bucket tasks: NoSuchVersion: The specified version does not exist.",
+		}, {
+			name:        "checksum mismatch",
+			params:      map[string]string{BucketParam: "tasks", KeyParam: "corrupt.yaml"},
+			expectedErr: "error validating object corrupt.yaml in bucket tasks: SHA-256 checksum mismatch",
+		}, {
+			name:        "object too large",
+			params:      map[string]string{BucketParam: "tasks", KeyParam: "large.yaml"},
+			expectedErr: "object large.yaml in bucket tasks is larger than 1048576 bytes",
+		}, {
+			name:        "missing secret",
+			params:      map[string]string{BucketParam: "tasks", KeyParam: "build/task.yaml", CredentialsSecretParam: "missing"},
+			expectedErr: "cannot get credentials, secret missing not found in namespace foo",
+		}, {
+			name:        "incomplete secret",
+			params:      map[string]string{BucketParam: "tasks", KeyParam: "build/task.yaml", CredentialsSecretParam: "incomplete-creds"},
+			expectedErr: "secret incomplete-creds in namespace foo must have both a access-key-id and a secret-access-key key",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svr := httptest.NewServer(&fakeS3{region: "eu-west-1", credentials: tc.serverCredentials, objects: objects})
+			defer svr.Close()
+
+			conf := map[string]string{DefaultEndpointKey: svr.URL}
+			for k, v := range tc.conf {
+				conf[k] = v
+			}
+			params := map[string]string{}
+			for k, v := range tc.params {
+				params[k] = v
+			}
+			if params[EndpointParam] != "" {
+				params[EndpointParam] = svr.URL
+			}
+
+			var kubeObjects []runtime.Object
+			for _, s := range secrets {
+				kubeObjects = append(kubeObjects, s)
+			}
+			resolver := Resolver{
+				kubeClient: fakek8s.NewSimpleClientset(kubeObjects...),
+				logger:     logtesting.TestLogger(t),
+			}
+			ctx := frameworktesting.ContextWithS3ResolverEnabled(t.Context())
+			ctx = resolutionframework.InjectResolverConfigToContext(ctx, conf)
+			ctx = common.InjectRequestNamespace(ctx, "foo")
+
+			output, err := resolver.Resolve(ctx, &v1beta1.ResolutionRequestSpec{Params: toParams(params)})
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("expected error containing '%v' but got '%v'", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.expectedContent, string(output.Data())); d != "" {
+				t.Errorf("unexpected content: %s", diff.PrintWantGot(d))
+			}
+			expectedDigest := map[string]string{"sha256": sha256Hex(tc.expectedContent)}
+			if tc.expectedVersionID != "" {
+				expectedDigest[versionIDDigestKey] = tc.expectedVersionID
+			}
+			if d := cmp.Diff(expectedDigest, output.RefSource().Digest); d != "" {
+				t.Errorf("unexpected digest: %s", diff.PrintWantGot(d))
+			}
+			if !strings.HasPrefix(output.RefSource().URI, svr.URL+"/tasks/") {
+				t.Errorf("unexpected uri %s", output.RefSource().URI)
+			}
+		})
+	}
+}
+
+func TestResolveCachesCredentialedObjectsPerNamespace(t *testing.T) {
+	creds := aws.Credentials{AccessKeyID: "request-key", SecretAccessKey: "request-secret"}
+	svr := httptest.NewServer(&fakeS3{region: "eu-west-1", credentials: &creds, objects: map[string][]objectVersion{
+		"tasks/task.yaml": {{id: "v1", content: sampleTask}},
+	}})
+	defer svr.Close()
+
+	// Only namespace foo has the secret referenced by the request.
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3-creds", Namespace: "foo"},
+		Data: map[string][]byte{
+			AccessKeyIDKey:     []byte(creds.AccessKeyID),
+			SecretAccessKeyKey: []byte(creds.SecretAccessKey),
+		},
+	}
+	resolver := Resolver{
+		kubeClient: fakek8s.NewSimpleClientset(secret),
+		logger:     logtesting.TestLogger(t),
+	}
+	ctx := frameworktesting.ContextWithS3ResolverEnabled(t.Context())
+	ctx = resolutionframework.InjectResolverConfigToContext(ctx, map[string]string{DefaultEndpointKey: svr.URL})
+	req := &v1beta1.ResolutionRequestSpec{Params: toParams(map[string]string{
+		BucketParam:            "tasks",
+		KeyParam:               "task.yaml",
+		VersionParam:           "v1",
+		RegionParam:            "eu-west-1",
+		CredentialsSecretParam: "s3-creds",
+	})}
+
+	output, err := resolver.Resolve(common.InjectRequestNamespace(ctx, "foo"), req)
+	if err != nil {
+		t.Fatalf("unexpected error resolving from namespace foo: %v", err)
+	}
+	if d := cmp.Diff(sampleTask, string(output.Data())); d != "" {
+		t.Errorf("unexpected content: %s", diff.PrintWantGot(d))
+	}
+
+	// The same params from namespace bar are not served from the cache, so
+	// resolution fails since bar has no such secret.
+	_, err = resolver.Resolve(common.InjectRequestNamespace(ctx, "bar"), req)
+	if err == nil || !strings.Contains(err.Error(), "secret s3-creds not found in namespace bar") {
+		t.Fatalf("expected missing secret error for namespace bar but got '%v'", err)
+	}
+}
+
+func TestResolveNotEnabled(t *testing.T) {
+	resolver := Resolver{}
+	ctx := frameworktesting.ContextWithS3ResolverDisabled(context.Background())
+	req := &v1beta1.ResolutionRequestSpec{Params: toParams(map[string]string{BucketParam: "tasks", KeyParam: "task.yaml"})}
+
+	_, err := resolver.Resolve(ctx, req)
+	if err == nil {
+		t.Fatalf("expected disabled err")
+	}
+	if d := cmp.Diff(disabledError, err.Error()); d != "" {
+		t.Errorf("unexpected error: %s", diff.PrintWantGot(d))
+	}
+	err = resolver.Validate(ctx, req)
+	if err == nil {
+		t.Fatalf("expected disabled err")
+	}
+	if d := cmp.Diff(disabledError, err.Error()); d != "" {
+		t.Errorf("unexpected error: %s", diff.PrintWantGot(d))
+	}
+}
+
+func toParams(m map[string]string) []pipelinev1.Param {
+	var params []pipelinev1.Param
+
+	for k, v := range m {
+		params = append(params, pipelinev1.Param{
+			Name:  k,
+			Value: *pipelinev1.NewStructuredValues(v),
+		})
+	}
+
+	return params
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap holding the retention
+	// policy of ResolutionRequests.
+	ConfigMapName = "resolution-request-retention-config"
+
+	maxAgeConfigMapKey               = "max-age"
+	maxCountPerNamespaceConfigMapKey = "max-count-per-namespace"
+	deleteAfterConsumedConfigMapKey  = "delete-after-consumed"
+)
+
+// Policy is the retention policy of completed ResolutionRequests. The zero
+// value keeps ResolutionRequests until they are garbage collected with
+// their owner.
+type Policy struct {
+	// MaxAge is how long a ResolutionRequest is kept after its resolution
+	// completed. Zero disables the limit.
+	MaxAge time.Duration
+	// MaxCountPerNamespace is how many completed ResolutionRequests are
+	// kept in each namespace, the most recently completed first. Zero
+	// disables the limit.
+	MaxCountPerNamespace int
+	// DeleteAfterConsumed deletes a ResolutionRequest once every TaskRun
+	// and PipelineRun owning it is done.
+	DeleteAfterConsumed bool
+}
+
+// Enabled returns whether the policy deletes any ResolutionRequest.
+func (p *Policy) Enabled() bool {
+	return p.MaxAge > 0 || p.MaxCountPerNamespace > 0 || p.DeleteAfterConsumed
+}
+
+// NewPolicyFromConfigMap returns the Policy configured by cm.
+func NewPolicyFromConfigMap(cm *corev1.ConfigMap) (*Policy, error) {
+	p := &Policy{}
+	if v, ok := cm.Data[maxAgeConfigMapKey]; ok && v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid value %q for %s: must be a non-negative duration", v, maxAgeConfigMapKey)
+		}
+		p.MaxAge = d
+	}
+	if v, ok := cm.Data[maxCountPerNamespaceConfigMapKey]; ok && v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid value %q for %s: must be a non-negative integer", v, maxCountPerNamespaceConfigMapKey)
+		}
+		p.MaxCountPerNamespace = n
+	}
+	if v, ok := cm.Data[deleteAfterConsumedConfigMapKey]; ok && v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for %s: must be a boolean", v, deleteAfterConsumedConfigMapKey)
+		}
+		p.DeleteAfterConsumed = b
+	}
+	return p, nil
+}
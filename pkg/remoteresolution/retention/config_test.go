@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestNewPolicyFromConfigMap(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		data    map[string]string
+		want    *Policy
+		wantErr string
+	}{{
+		name: "empty",
+		data: map[string]string{},
+		want: &Policy{},
+	}, {
+		name: "all set",
+		data: map[string]string{
+			"max-age":                 "24h",
+			"max-count-per-namespace": "100",
+			"delete-after-consumed":   "true",
+		},
+		want: &Policy{MaxAge: 24 * time.Hour, MaxCountPerNamespace: 100, DeleteAfterConsumed: true},
+	}, {
+		name:    "invalid max age",
+		data:    map[string]string{"max-age": "-1h"},
+		wantErr: `invalid value "-1h" for max-age: must be a non-negative duration`,
+	}, {
+		name:    "invalid max count",
+		data:    map[string]string{"max-count-per-namespace": "many"},
+		wantErr: `invalid value "many" for max-count-per-namespace: must be a non-negative integer`,
+	}, {
+		name:    "invalid delete after consumed",
+		data:    map[string]string{"delete-after-consumed": "yes please"},
+		wantErr: `invalid value "yes please" for delete-after-consumed: must be a boolean`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewPolicyFromConfigMap(&corev1.ConfigMap{Data: tc.data})
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected error %q but got: %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("unexpected policy %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
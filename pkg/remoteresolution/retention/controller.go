@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"context"
+
+	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	rrclient "github.com/tektoncd/pipeline/pkg/client/resolution/injection/client"
+	rrinformer "github.com/tektoncd/pipeline/pkg/client/resolution/injection/informers/resolution/v1beta1/resolutionrequest"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// ReconcilerModifier is a func that can access and modify a reconciler
+// in the moments before it is started. It allows for things like
+// injecting a test clock.
+type ReconcilerModifier = func(reconciler *Reconciler)
+
+// NewController returns a knative controller deleting completed
+// ResolutionRequests according to the retention policy of the
+// resolution-request-retention-config ConfigMap.
+func NewController(modifiers ...ReconcilerModifier) func(context.Context, configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		logger := logging.FromContext(ctx)
+		rrInformer := rrinformer.Get(ctx)
+
+		r := &Reconciler{
+			LeaderAwareFuncs:           framework.LeaderAwareFuncs(rrInformer.Lister()),
+			resolutionRequestLister:    rrInformer.Lister(),
+			resolutionRequestClientSet: rrclient.Get(ctx),
+			pipelineClientSet:          pipelineclient.Get(ctx),
+		}
+		for _, mod := range modifiers {
+			mod(r)
+		}
+		if r.Clock == nil {
+			r.Clock = clock.RealClock{}
+		}
+
+		impl := controller.NewContext(ctx, r, controller.ControllerOptions{
+			WorkQueueName: "TektonResolutionRequestRetention",
+			Logger:        logger,
+		})
+
+		// Changing the policy may expire requests that were kept so
+		// far, so they are all checked again.
+		r.configStore = configmap.NewUntypedStore(
+			"retention",
+			logger.Named("config-store"),
+			configmap.Constructors{
+				ConfigMapName: NewPolicyFromConfigMap,
+			},
+			func(string, any) {
+				impl.GlobalResync(rrInformer.Informer())
+			},
+		)
+		r.configStore.WatchConfigs(cmw)
+
+		_, err := rrInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: impl.Enqueue,
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				impl.Enqueue(newObj)
+			},
+		})
+		if err != nil {
+			logger.Panicf("Couldn't register ResolutionRequest informer event handler: %w", err)
+		}
+
+		return impl
+	}
+}
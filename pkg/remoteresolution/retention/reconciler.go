@@ -0,0 +1,246 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	pipelineclientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	rrclient "github.com/tektoncd/pipeline/pkg/client/resolution/clientset/versioned"
+	rrv1beta1 "github.com/tektoncd/pipeline/pkg/client/resolution/listers/resolution/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+)
+
+// consumedRecheckInterval is how often a ResolutionRequest whose owners
+// are still running is checked again when DeleteAfterConsumed is set.
+const consumedRecheckInterval = time.Minute
+
+// Reconciler deletes completed ResolutionRequests according to the
+// retention Policy.
+type Reconciler struct {
+	// Implements reconciler.LeaderAware
+	reconciler.LeaderAwareFuncs
+
+	// Clock is used by the reconciler to track the passage of time
+	// and can be overridden for tests.
+	Clock clock.PassiveClock
+
+	resolutionRequestLister    rrv1beta1.ResolutionRequestLister
+	resolutionRequestClientSet rrclient.Interface
+	pipelineClientSet          pipelineclientset.Interface
+
+	configStore *configmap.UntypedStore
+}
+
+var _ reconciler.LeaderAware = &Reconciler{}
+
+// Reconcile receives the string key of a ResolutionRequest object and
+// deletes it, or the oldest completed ResolutionRequests of its
+// namespace, when the retention Policy says so. A request that will
+// expire later is requeued for that time.
+func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return controller.NewPermanentError(fmt.Errorf("invalid resource key %q: %w", key, err))
+	}
+
+	policy := r.policy()
+	if !policy.Enabled() {
+		return nil
+	}
+
+	rr, err := r.resolutionRequestLister.ResolutionRequests(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !rr.IsDone() {
+		return nil
+	}
+
+	if policy.MaxCountPerNamespace > 0 {
+		deleted, err := r.pruneNamespace(ctx, namespace, rr, policy.MaxCountPerNamespace)
+		if err != nil || deleted {
+			return err
+		}
+	}
+
+	var requeueAfter time.Duration
+	if policy.MaxAge > 0 {
+		remaining := policy.MaxAge - r.Clock.Since(completionTime(rr))
+		if remaining <= 0 {
+			return r.delete(ctx, rr, fmt.Sprintf("completed more than %s ago", policy.MaxAge))
+		}
+		requeueAfter = remaining
+	}
+	if policy.DeleteAfterConsumed {
+		consumed, err := r.consumed(ctx, rr)
+		if err != nil {
+			return err
+		}
+		if consumed {
+			return r.delete(ctx, rr, "consumed by its owners")
+		}
+		if hasRunOwner(rr) && (requeueAfter == 0 || requeueAfter > consumedRecheckInterval) {
+			requeueAfter = consumedRecheckInterval
+		}
+	}
+	if requeueAfter > 0 {
+		return controller.NewRequeueAfter(requeueAfter)
+	}
+	return nil
+}
+
+// policy returns the retention Policy from the watched ConfigMap.
+func (r *Reconciler) policy() *Policy {
+	if p, ok := r.configStore.UntypedLoad(ConfigMapName).(*Policy); ok && p != nil {
+		return p
+	}
+	return &Policy{}
+}
+
+// pruneNamespace deletes the completed ResolutionRequests of namespace
+// beyond the max most recently completed ones. It returns whether rr was
+// deleted.
+func (r *Reconciler) pruneNamespace(ctx context.Context, namespace string, rr *v1beta1.ResolutionRequest, maxCount int) (bool, error) {
+	all, err := r.resolutionRequestLister.ResolutionRequests(namespace).List(labels.Everything())
+	if err != nil {
+		return false, err
+	}
+	var done []*v1beta1.ResolutionRequest
+	for _, elt := range all {
+		if elt.IsDone() && elt.DeletionTimestamp == nil {
+			done = append(done, elt)
+		}
+	}
+	if len(done) <= maxCount {
+		return false, nil
+	}
+
+	sort.Slice(done, func(i, j int) bool {
+		ti, tj := completionTime(done[i]), completionTime(done[j])
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return done[i].Name < done[j].Name
+	})
+	deleted := false
+	reason := fmt.Sprintf("more than %d completed ResolutionRequests in namespace %s", maxCount, namespace)
+	for _, elt := range done[maxCount:] {
+		if err := r.delete(ctx, elt, reason); err != nil {
+			return deleted, err
+		}
+		if elt.UID == rr.UID {
+			deleted = true
+		}
+	}
+	return deleted, nil
+}
+
+// consumed returns whether rr is owned by TaskRuns or PipelineRuns that
+// are all done or no longer exist.
+func (r *Reconciler) consumed(ctx context.Context, rr *v1beta1.ResolutionRequest) (bool, error) {
+	if !hasRunOwner(rr) {
+		return false, nil
+	}
+	for _, ref := range rr.OwnerReferences {
+		var owner interface {
+			metav1.Object
+			IsDone() bool
+		}
+		var err error
+		switch ownerKind(ref) {
+		case pipeline.TaskRunControllerName:
+			owner, err = r.pipelineClientSet.TektonV1().TaskRuns(rr.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		case pipeline.PipelineRunControllerName:
+			owner, err = r.pipelineClientSet.TektonV1().PipelineRuns(rr.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		default:
+			continue
+		}
+		switch {
+		case apierrors.IsNotFound(err):
+			continue
+		case err != nil:
+			return false, err
+		case owner.GetUID() != ref.UID:
+			// The owner was deleted and another run was created
+			// with the same name.
+			continue
+		case !owner.IsDone():
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// delete deletes rr unless it was replaced in the meantime.
+func (r *Reconciler) delete(ctx context.Context, rr *v1beta1.ResolutionRequest, reason string) error {
+	logging.FromContext(ctx).Infof("Deleting ResolutionRequest %s/%s: %s", rr.Namespace, rr.Name, reason)
+	err := r.resolutionRequestClientSet.ResolutionV1beta1().ResolutionRequests(rr.Namespace).Delete(ctx, rr.Name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &rr.UID},
+	})
+	if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+		return fmt.Errorf("failed to delete ResolutionRequest %s/%s: %w", rr.Namespace, rr.Name, err)
+	}
+	return nil
+}
+
+// completionTime returns when the resolution of rr completed, or when rr
+// was created if it has no Succeeded condition.
+func completionTime(rr *v1beta1.ResolutionRequest) time.Time {
+	if c := rr.Status.GetCondition(apis.ConditionSucceeded); c != nil && !c.LastTransitionTime.Inner.IsZero() {
+		return c.LastTransitionTime.Inner.Time
+	}
+	return rr.CreationTimestamp.Time
+}
+
+// hasRunOwner returns whether rr is owned by a TaskRun or a PipelineRun.
+func hasRunOwner(rr *v1beta1.ResolutionRequest) bool {
+	for _, ref := range rr.OwnerReferences {
+		switch ownerKind(ref) {
+		case pipeline.TaskRunControllerName, pipeline.PipelineRunControllerName:
+			return true
+		}
+	}
+	return false
+}
+
+// ownerKind returns the kind of a Tekton owner, or "" for owners outside
+// of the tekton.dev group.
+func ownerKind(ref metav1.OwnerReference) string {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil || gv.Group != pipeline.GroupName {
+		return ""
+	}
+	return ref.Kind
+}
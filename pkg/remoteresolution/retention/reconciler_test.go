@@ -0,0 +1,256 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	resolverconfig "github.com/tektoncd/pipeline/pkg/apis/config/resolver"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/pkg/remoteresolution/retention"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	testclock "k8s.io/utils/clock/testing"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	cminformer "knative.dev/pkg/configmap/informer"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/system"
+	_ "knative.dev/pkg/system/testing"
+)
+
+var now = time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+func TestReconcile(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		config       map[string]string
+		requests     []*v1beta1.ResolutionRequest
+		taskRuns     []*v1.TaskRun
+		pipelineRuns []*v1.PipelineRun
+		key          string
+		wantRemain   []string
+		wantRequeue  time.Duration
+	}{{
+		name:       "disabled",
+		config:     map[string]string{},
+		requests:   []*v1beta1.ResolutionRequest{completedRequest("foo", "rr", 48*time.Hour)},
+		key:        "foo/rr",
+		wantRemain: []string{"foo/rr"},
+	}, {
+		name:     "max age passed",
+		config:   map[string]string{"max-age": "1h"},
+		requests: []*v1beta1.ResolutionRequest{completedRequest("foo", "rr", 2*time.Hour)},
+		key:      "foo/rr",
+	}, {
+		name:        "max age not passed",
+		config:      map[string]string{"max-age": "1h"},
+		requests:    []*v1beta1.ResolutionRequest{completedRequest("foo", "rr", 20*time.Minute)},
+		key:         "foo/rr",
+		wantRemain:  []string{"foo/rr"},
+		wantRequeue: 40 * time.Minute,
+	}, {
+		name:       "in progress requests are kept",
+		config:     map[string]string{"max-age": "1h", "max-count-per-namespace": "1", "delete-after-consumed": "true"},
+		requests:   []*v1beta1.ResolutionRequest{inProgressRequest("foo", "rr", 2*time.Hour)},
+		key:        "foo/rr",
+		wantRemain: []string{"foo/rr"},
+	}, {
+		name:   "max count per namespace",
+		config: map[string]string{"max-count-per-namespace": "2"},
+		requests: []*v1beta1.ResolutionRequest{
+			completedRequest("foo", "oldest", 3*time.Hour),
+			completedRequest("foo", "older", 2*time.Hour),
+			completedRequest("foo", "newest", time.Hour),
+			inProgressRequest("foo", "running", 4*time.Hour),
+			completedRequest("bar", "other", 4*time.Hour),
+		},
+		key:        "foo/newest",
+		wantRemain: []string{"bar/other", "foo/newest", "foo/older", "foo/running"},
+	}, {
+		name:   "max count per namespace deletes the reconciled request",
+		config: map[string]string{"max-count-per-namespace": "1", "max-age": "1h"},
+		requests: []*v1beta1.ResolutionRequest{
+			completedRequest("foo", "old", 40*time.Minute),
+			completedRequest("foo", "new", 10*time.Minute),
+		},
+		key:        "foo/old",
+		wantRemain: []string{"foo/new"},
+	}, {
+		name:     "consumed by done task run",
+		config:   map[string]string{"delete-after-consumed": "true"},
+		requests: []*v1beta1.ResolutionRequest{withOwner(completedRequest("foo", "rr", time.Minute), "TaskRun", "tr", "tr-uid")},
+		taskRuns: []*v1.TaskRun{taskRun("foo", "tr", "tr-uid", corev1.ConditionTrue)},
+		key:      "foo/rr",
+	}, {
+		name:        "not consumed by running task run",
+		config:      map[string]string{"delete-after-consumed": "true"},
+		requests:    []*v1beta1.ResolutionRequest{withOwner(completedRequest("foo", "rr", time.Minute), "TaskRun", "tr", "tr-uid")},
+		taskRuns:    []*v1.TaskRun{taskRun("foo", "tr", "tr-uid", corev1.ConditionUnknown)},
+		key:         "foo/rr",
+		wantRemain:  []string{"foo/rr"},
+		wantRequeue: time.Minute,
+	}, {
+		name:         "not consumed by running pipeline run",
+		config:       map[string]string{"delete-after-consumed": "true", "max-age": "30s"},
+		requests:     []*v1beta1.ResolutionRequest{withOwner(completedRequest("foo", "rr", 10*time.Second), "PipelineRun", "pr", "pr-uid")},
+		pipelineRuns: []*v1.PipelineRun{pipelineRun("foo", "pr", "pr-uid", corev1.ConditionUnknown)},
+		key:          "foo/rr",
+		wantRemain:   []string{"foo/rr"},
+		wantRequeue:  20 * time.Second,
+	}, {
+		name:     "owner replaced by another run",
+		config:   map[string]string{"delete-after-consumed": "true"},
+		requests: []*v1beta1.ResolutionRequest{withOwner(completedRequest("foo", "rr", time.Minute), "TaskRun", "tr", "tr-uid")},
+		taskRuns: []*v1.TaskRun{taskRun("foo", "tr", "other-uid", corev1.ConditionUnknown)},
+		key:      "foo/rr",
+	}, {
+		name:     "owner deleted",
+		config:   map[string]string{"delete-after-consumed": "true"},
+		requests: []*v1beta1.ResolutionRequest{withOwner(completedRequest("foo", "rr", time.Minute), "PipelineRun", "pr", "pr-uid")},
+		key:      "foo/rr",
+	}, {
+		name:       "requests without run owners are not consumed",
+		config:     map[string]string{"delete-after-consumed": "true"},
+		requests:   []*v1beta1.ResolutionRequest{completedRequest("foo", "rr", 48*time.Hour)},
+		key:        "foo/rr",
+		wantRemain: []string{"foo/rr"},
+	}, {
+		name:   "missing request",
+		config: map[string]string{"max-age": "1h"},
+		key:    "foo/missing",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, _ := ttesting.SetupFakeContext(t)
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			d := test.Data{
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      retention.ConfigMapName,
+						Namespace: resolverconfig.ResolversNamespace(system.Namespace()),
+					},
+					Data: tc.config,
+				}},
+				ResolutionRequests: tc.requests,
+				TaskRuns:           tc.taskRuns,
+				PipelineRuns:       tc.pipelineRuns,
+			}
+			c, _ := test.SeedTestData(t, ctx, d)
+			cmw := cminformer.NewInformedWatcher(c.Kube, resolverconfig.ResolversNamespace(system.Namespace()))
+			ctl := retention.NewController(func(r *retention.Reconciler) {
+				r.Clock = testclock.NewFakePassiveClock(now)
+			})(ctx, cmw)
+			if err := cmw.Start(ctx.Done()); err != nil {
+				t.Fatalf("error starting configmap watcher: %v", err)
+			}
+
+			err := ctl.Reconciler.Reconcile(ctx, tc.key)
+			if tc.wantRequeue > 0 {
+				if ok, after := controller.IsRequeueKey(err); !ok || after != tc.wantRequeue {
+					t.Errorf("expected a requeue after %s but got: %v", tc.wantRequeue, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			list, err := c.ResolutionRequests.ResolutionV1beta1().ResolutionRequests("").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error listing ResolutionRequests: %v", err)
+			}
+			var remain []string
+			for _, rr := range list.Items {
+				remain = append(remain, rr.Namespace+"/"+rr.Name)
+			}
+			sort.Strings(remain)
+			if d := cmp.Diff(tc.wantRemain, remain); d != "" {
+				t.Errorf("unexpected remaining ResolutionRequests %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func completedRequest(namespace, name string, completedAgo time.Duration) *v1beta1.ResolutionRequest {
+	rr := inProgressRequest(namespace, name, completedAgo+time.Minute)
+	rr.Status.Conditions = duckv1.Conditions{{
+		Type:               apis.ConditionSucceeded,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: apis.VolatileTime{Inner: metav1.NewTime(now.Add(-completedAgo))},
+	}}
+	return rr
+}
+
+func inProgressRequest(namespace, name string, createdAgo time.Duration) *v1beta1.ResolutionRequest {
+	return &v1beta1.ResolutionRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			UID:               types.UID(namespace + "-" + name),
+			CreationTimestamp: metav1.NewTime(now.Add(-createdAgo)),
+		},
+		Status: v1beta1.ResolutionRequestStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionUnknown,
+				}},
+			},
+		},
+	}
+}
+
+func withOwner(rr *v1beta1.ResolutionRequest, kind, name, uid string) *v1beta1.ResolutionRequest {
+	rr.OwnerReferences = append(rr.OwnerReferences, metav1.OwnerReference{
+		APIVersion: "tekton.dev/v1",
+		Kind:       kind,
+		Name:       name,
+		UID:        types.UID(uid),
+	})
+	return rr
+}
+
+func taskRun(namespace, name, uid string, status corev1.ConditionStatus) *v1.TaskRun {
+	return &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: types.UID(uid)},
+		Status: v1.TaskRunStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: status}},
+			},
+		},
+	}
+}
+
+func pipelineRun(namespace, name, uid string, status corev1.ConditionStatus) *v1.PipelineRun {
+	return &v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: types.UID(uid)},
+		Status: v1.PipelineRunStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: status}},
+			},
+		},
+	}
+}
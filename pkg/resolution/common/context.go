@@ -49,9 +49,13 @@ func RequestNamespace(ctx context.Context) string {
 	return ""
 }
 
+// nameContextKey is distinct from contextKey so that the name and the
+// namespace of a request are stored under different keys.
+type nameContextKey struct{}
+
 // requestNameContextKey is the key stored in a context alongside
 // the string name of a resolution request.
-var requestNameContextKey = contextKey{}
+var requestNameContextKey = nameContextKey{}
 
 // InjectRequestName returns a new context with a request-scoped
 // name. This value may only be set once per request; subsequent
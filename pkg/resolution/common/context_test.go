@@ -63,3 +63,15 @@ func TestRequestName(t *testing.T) {
 		t.Fatalf("expected empty namespace returned if no value was previously injected")
 	}
 }
+
+func TestRequestNameAndNamespace(t *testing.T) {
+	ctx := t.Context()
+	ctx = common.InjectRequestNamespace(ctx, "foo")
+	ctx = common.InjectRequestName(ctx, "bar")
+	if common.RequestNamespace(ctx) != "foo" {
+		t.Fatalf("expected namespace to be stored separately from name")
+	}
+	if common.RequestName(ctx) != "bar" {
+		t.Fatalf("expected name to be stored separately from namespace")
+	}
+}
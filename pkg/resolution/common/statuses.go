@@ -22,6 +22,11 @@ const (
 	// no issues with the parameters of a request and that a
 	// resolver is working on the ResolutionRequest.
 	ReasonResolutionInProgress = "ResolutionInProgress"
+
+	// ReasonResolutionRetrying is used to indicate that an attempt to
+	// resolve a ResolutionRequest failed and that it will be attempted
+	// again.
+	ReasonResolutionRetrying = "ResolutionRetrying"
 )
 
 // happy reasons
@@ -40,4 +45,16 @@ const (
 	// ReasonResolutionTimedOut indicates that a resolver did not
 	// manage to respond to a ResolutionRequest within a timeout.
 	ReasonResolutionTimedOut = "ResolutionTimedOut"
+
+	// ReasonResolutionDenied indicates that a ResolutionRequest was
+	// rejected by the admission policy of the resolvers.
+	ReasonResolutionDenied = "ResolutionDenied"
+
+	// ReasonSignatureVerificationFailed indicates that the resolved
+	// resource is not signed by any of the allowed signers.
+	ReasonSignatureVerificationFailed = "SignatureVerificationFailed"
+
+	// ReasonResourceNotFound indicates that the requested resource does
+	// not exist.
+	ReasonResourceNotFound = "ResourceNotFound"
 )
@@ -41,4 +41,8 @@ var (
 	// ResolverAnnotationAPIVersion is the resolver annotation used to
 	// indicate the "apiVersion" of resource.
 	ResolverAnnotationAPIVersion = resolution.GroupName + "/" + BundleAnnotationAPIVersion
+
+	// ResolverAnnotationReferrers is the resolver annotation listing the
+	// artifacts, such as signatures and SBOMs, that refer to the bundle.
+	ResolverAnnotationReferrers = resolution.GroupName + "/bundle-referrers"
 )
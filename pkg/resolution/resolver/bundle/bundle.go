@@ -16,6 +16,7 @@ package bundle
 import (
 	"archive/tar"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -25,15 +26,36 @@ import (
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
 )
 
 const (
 	// MaximumBundleObjects defines the maximum number of objects in a bundle
 	MaximumBundleObjects = 20
+
+	// MaximumIndexBundles defines the maximum number of bundles in an image index
+	MaximumIndexBundles = 10
+
+	// ociEmptyMediaType is the media type of the empty config of OCI 1.1
+	// artifact manifests.
+	ociEmptyMediaType types.MediaType = "application/vnd.oci.empty.v1+json"
+
+	// dockerReferenceTypeAnnotation marks the manifests of image indexes
+	// that describe another manifest, like build attestations.
+	dockerReferenceTypeAnnotation = "vnd.docker.reference.type"
 )
 
+// Referrer describes an artifact, such as a signature or an SBOM, that refers
+// to a bundle.
+type Referrer struct {
+	Digest       string `json:"digest"`
+	ArtifactType string `json:"artifactType,omitempty"`
+}
+
 // RequestOptions are the options used to request a resource from
 // a remote bundle.
 type RequestOptions struct {
@@ -73,24 +95,152 @@ func (br *ResolvedResource) RefSource() *pipelinev1.RefSource {
 
 // GetEntry accepts a keychain and options for the request and returns
 // either a successfully resolved bundle entry or an error.
+//
+// The bundle may be an image index grouping several bundles, in which case
+// the entry is looked up in each of them.
 func GetEntry(ctx context.Context, keychain authn.Keychain, opts RequestOptions) (*ResolvedResource, error) {
-	uri, img, err := retrieveImage(ctx, keychain, opts.Bundle)
+	return getEntry(ctx, keychain, opts, nil)
+}
+
+// getEntry is GetEntry verifying the signatures of the bundle with v, if set,
+// before reading it.
+func getEntry(ctx context.Context, keychain authn.Keychain, opts RequestOptions, v *bundleVerifier) (*ResolvedResource, error) {
+	imgRef, err := name.ParseReference(opts.Bundle)
+	if err != nil {
+		return nil, fmt.Errorf("cannot retrieve the oci image: %s is an unparseable image reference: %w", opts.Bundle, err)
+	}
+	remoteOpts := remoteOptions(ctx, keychain)
+	desc, err := remote.Get(imgRef, remoteOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("cannot retrieve the oci image: %w", err)
 	}
+	h := desc.Digest
+	if v != nil {
+		if err := v.verify(ctx, imgRef.Context().Digest(h.String()), remoteOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	images, err := bundleImages(desc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tekton bundle %s, error: %w", opts.Bundle, err)
+	}
+
+	var found *bundleEntry
+	for _, img := range images {
+		entry, err := findEntry(img, opts)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tekton bundle %s, error: %w", opts.Bundle, err)
+		}
+		if entry == nil {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("found more than one object in image index with kind: %s and name: %s", opts.Kind, opts.EntryName)
+		}
+		found = entry
+	}
+	if found == nil {
+		return nil, fmt.Errorf("could not find object in image with kind: %s and name: %s", opts.Kind, opts.EntryName)
+	}
+
+	annotations := map[string]string{
+		ResolverAnnotationKind:       found.kind,
+		ResolverAnnotationName:       found.name,
+		ResolverAnnotationAPIVersion: found.apiVersion,
+	}
+	if discoverReferrers(ctx) {
+		referrers, err := listReferrers(imgRef.Context().Digest(h.String()), remoteOpts)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list the referrers of the oci image: %w", err)
+		}
+		if referrers != "" {
+			annotations[ResolverAnnotationReferrers] = referrers
+		}
+	}
+
+	return &ResolvedResource{
+		data:        found.data,
+		annotations: annotations,
+		source: &pipelinev1.RefSource{
+			URI: imgRef.Context().Name(),
+			Digest: map[string]string{
+				h.Algorithm: h.Hex,
+			},
+			EntryPoint: opts.EntryName,
+		},
+	}, nil
+}
+
+// bundleEntry is an object found in a bundle.
+type bundleEntry struct {
+	kind       string
+	name       string
+	apiVersion string
+	data       []byte
+}
 
-	h, err := img.Digest()
+// remoteOptions returns the options used to fetch bundles from the registry.
+func remoteOptions(ctx context.Context, keychain authn.Keychain) []remote.Option {
+	opts := []remote.Option{remote.WithAuthFromKeychain(keychain), remote.WithContext(ctx)}
+	if customRetryBackoff, err := GetBundleResolverBackoff(ctx); err == nil {
+		opts = append(opts, remote.WithRetryBackoff(customRetryBackoff))
+	}
+	return opts
+}
+
+// bundleImages returns the bundle described by desc or, if desc is an image
+// index, the bundles it groups. Attestation manifests, which some build
+// tools add to indexes, are skipped.
+func bundleImages(desc *remote.Descriptor) ([]v1.Image, error) {
+	if !desc.MediaType.IsIndex() {
+		img, err := desc.Image()
+		if err != nil {
+			return nil, fmt.Errorf("could not read image: %w", err)
+		}
+		return []v1.Image{img}, nil
+	}
+
+	idx, err := desc.ImageIndex()
 	if err != nil {
-		return nil, fmt.Errorf("cannot get the oci digest: %w", err)
+		return nil, fmt.Errorf("could not read image index: %w", err)
+	}
+	indexManifest, err := idx.IndexManifest()
+	if err != nil {
+		return nil, fmt.Errorf("could not parse image index: %w", err)
+	}
+	var images []v1.Image
+	for _, m := range indexManifest.Manifests {
+		if !m.MediaType.IsImage() || m.Annotations[dockerReferenceTypeAnnotation] == "attestation-manifest" {
+			continue
+		}
+		if len(images) == MaximumIndexBundles {
+			return nil, fmt.Errorf("image index contained more than the maximum %d allowed bundles", MaximumIndexBundles)
+		}
+		img, err := idx.Image(m.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("could not read image %s in index: %w", m.Digest, err)
+		}
+		images = append(images, img)
 	}
+	return images, nil
+}
 
+// findEntry returns the object of img matching opts, or nil if img holds no
+// such object.
+func findEntry(img v1.Image, opts RequestOptions) (*bundleEntry, error) {
 	manifest, err := img.Manifest()
 	if err != nil {
 		return nil, fmt.Errorf("could not parse image manifest: %w", err)
 	}
+	rawManifest, err := img.RawManifest()
+	if err != nil {
+		return nil, fmt.Errorf("could not read image manifest: %w", err)
+	}
+	artifact := isArtifactManifest(rawManifest, manifest)
 
-	if err := checkImageCompliance(manifest); err != nil {
-		return nil, fmt.Errorf("invalid tekton bundle %s, error: %w", opts.Bundle, err)
+	if err := checkImageCompliance(manifest, artifact); err != nil {
+		return nil, err
 	}
 
 	layers, err := img.Layers()
@@ -98,66 +248,85 @@ func GetEntry(ctx context.Context, keychain authn.Keychain, opts RequestOptions)
 		return nil, fmt.Errorf("could not read image layers: %w", err)
 	}
 
-	layerMap := map[string]v1.Layer{}
-	for _, l := range layers {
-		digest, err := l.Digest()
-		if err != nil {
-			return nil, fmt.Errorf("failed to find digest for layer: %w", err)
-		}
-		layerMap[digest.String()] = l
-	}
-
 	for idx, l := range manifest.Layers {
-		lKind := l.Annotations[BundleAnnotationKind]
-		lName := l.Annotations[BundleAnnotationName]
-
-		if strings.ToLower(opts.Kind) == strings.ToLower(lKind) && opts.EntryName == lName {
-			obj, err := readTarLayer(layerMap[l.Digest.String()])
+		entry := &bundleEntry{
+			kind:       l.Annotations[BundleAnnotationKind],
+			name:       l.Annotations[BundleAnnotationName],
+			apiVersion: l.Annotations[BundleAnnotationAPIVersion],
+		}
+		if artifact && !hasBundleAnnotations(l) {
+			// Layers of artifacts pushed with generic tooling are identified
+			// by the object they hold.
+			entry.data, err = readLayer(layers[idx])
 			if err != nil {
-				// This could still be a raw layer so try to read it as that instead.
-				obj, _ = readRawLayer(layers[idx])
+				return nil, fmt.Errorf("the layer %v could not be read: %w", idx, err)
+			}
+			if err := entry.describeFromContent(); err != nil {
+				return nil, fmt.Errorf("the layer %v has no %s annotation and %w", idx, BundleAnnotationKind, err)
+			}
+		}
+
+		if strings.EqualFold(opts.Kind, entry.kind) && opts.EntryName == entry.name {
+			if entry.data == nil {
+				// This could still be a raw layer, which readLayer falls back to.
+				entry.data, _ = readLayer(layers[idx])
 			}
-			return &ResolvedResource{
-				data: obj,
-				annotations: map[string]string{
-					ResolverAnnotationKind:       lKind,
-					ResolverAnnotationName:       lName,
-					ResolverAnnotationAPIVersion: l.Annotations[BundleAnnotationAPIVersion],
-				},
-				source: &pipelinev1.RefSource{
-					URI: uri,
-					Digest: map[string]string{
-						h.Algorithm: h.Hex,
-					},
-					EntryPoint: opts.EntryName,
-				},
-			}, nil
+			return entry, nil
 		}
 	}
-	return nil, fmt.Errorf("could not find object in image with kind: %s and name: %s", opts.Kind, opts.EntryName)
+	return nil, nil //nolint:nilnil // no entry in this bundle is not an error
 }
 
-// retrieveImage will fetch the image's url, contents and manifest.
-func retrieveImage(ctx context.Context, keychain authn.Keychain, ref string) (string, v1.Image, error) {
-	imgRef, err := name.ParseReference(ref)
+// describeFromContent sets the kind, name and apiVersion of the entry from
+// the object it holds.
+func (e *bundleEntry) describeFromContent() error {
+	var obj struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+		Metadata   struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+	}
+	if err := yaml.Unmarshal(e.data, &obj); err != nil || obj.APIVersion == "" || obj.Kind == "" || obj.Metadata.Name == "" {
+		return errors.New("does not hold a Kubernetes object")
+	}
+	gv, err := schema.ParseGroupVersion(obj.APIVersion)
 	if err != nil {
-		return "", nil, fmt.Errorf("%s is an unparseable image reference: %w", ref, err)
+		return fmt.Errorf("holds an object with an invalid apiVersion: %w", err)
 	}
-	customRetryBackoff, err := GetBundleResolverBackoff(ctx)
-	if err == nil {
-		img, err := remote.Image(imgRef, remote.WithAuthFromKeychain(keychain), remote.WithContext(ctx),
-			remote.WithRetryBackoff(customRetryBackoff))
+	e.kind = strings.ToLower(obj.Kind)
+	e.name = obj.Metadata.Name
+	e.apiVersion = gv.Version
+	return nil
+}
 
-		return imgRef.Context().Name(), img, err
-	} else {
-		img, err := remote.Image(imgRef, remote.WithAuthFromKeychain(keychain), remote.WithContext(ctx))
+// isArtifactManifest returns true if the manifest is an OCI 1.1 artifact
+// manifest, which declares an artifactType or has the empty config.
+func isArtifactManifest(rawManifest []byte, manifest *v1.Manifest) bool {
+	var m struct {
+		ArtifactType string `json:"artifactType"`
+	}
+	if err := json.Unmarshal(rawManifest, &m); err == nil && m.ArtifactType != "" {
+		return true
+	}
+	return manifest.Config.MediaType == ociEmptyMediaType
+}
 
-		return imgRef.Context().Name(), img, err
+// hasBundleAnnotations returns true if the layer has any of the Tekton Bundle
+// annotations.
+func hasBundleAnnotations(l v1.Descriptor) bool {
+	for _, a := range []string{BundleAnnotationKind, BundleAnnotationName, BundleAnnotationAPIVersion} {
+		if _, ok := l.Annotations[a]; ok {
+			return true
+		}
 	}
+	return false
 }
 
 // checkImageCompliance will perform common checks to ensure the Tekton Bundle is compliant to our spec.
-func checkImageCompliance(manifest *v1.Manifest) error {
+// Layers of artifact manifests may omit the annotations, they are then
+// identified by the object they hold.
+func checkImageCompliance(manifest *v1.Manifest, artifact bool) error {
 	// Check the manifest's layers to ensure there are a maximum of 10.
 	if len(manifest.Layers) > MaximumBundleObjects {
 		return fmt.Errorf("contained more than the maximum %d allow objects", MaximumBundleObjects)
@@ -165,6 +334,10 @@ func checkImageCompliance(manifest *v1.Manifest) error {
 
 	// Ensure each layer complies to the spec.
 	for i, l := range manifest.Layers {
+		if artifact && !hasBundleAnnotations(l) {
+			continue
+		}
+
 		if _, ok := l.Annotations[BundleAnnotationAPIVersion]; !ok {
 			return fmt.Errorf("the layer %v does not contain a %s annotation", i, BundleAnnotationAPIVersion)
 		}
@@ -185,6 +358,42 @@ func checkImageCompliance(manifest *v1.Manifest) error {
 	return nil
 }
 
+// listReferrers returns the artifacts referring to the image d, such as
+// signatures and SBOMs, as a JSON list of their digests and artifact types,
+// or an empty string if there are none.
+func listReferrers(d name.Digest, opts []remote.Option) (string, error) {
+	idx, err := remote.Referrers(d, opts...)
+	if err != nil {
+		return "", err
+	}
+	indexManifest, err := idx.IndexManifest()
+	if err != nil {
+		return "", err
+	}
+	if len(indexManifest.Manifests) == 0 {
+		return "", nil
+	}
+	referrers := make([]Referrer, 0, len(indexManifest.Manifests))
+	for _, m := range indexManifest.Manifests {
+		referrers = append(referrers, Referrer{Digest: m.Digest.String(), ArtifactType: m.ArtifactType})
+	}
+	b, err := json.Marshal(referrers)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// readLayer reads the contents of a layer, which is either a tarball holding
+// a single file or the raw object.
+func readLayer(layer v1.Layer) ([]byte, error) {
+	data, err := readTarLayer(layer)
+	if err != nil {
+		return readRawLayer(layer)
+	}
+	return data, nil
+}
+
 // Utility function to read out the contents of an image layer, assumed to be a tarball, as bytes.
 func readTarLayer(layer v1.Layer) ([]byte, error) {
 	rc, err := layer.Uncompressed()
@@ -0,0 +1,469 @@
+/*
+Copyright 2025 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/elliptic"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/partial"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/sigstore/sigstore/pkg/signature"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	fakepipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client/fake"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/bundle"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	artifactType  = "application/vnd.tekton.bundle.v1"
+	signatureType = "application/vnd.dev.cosign.artifact.sig.v1+json"
+)
+
+func TestGetEntryFromIndexAndArtifacts(t *testing.T) {
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := u.Host + "/bundles"
+
+	task := &pipelinev1.Task{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "Task"},
+		ObjectMeta: metav1.ObjectMeta{Name: "build"},
+	}
+	pipeline := &pipelinev1.Pipeline{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "Pipeline"},
+		ObjectMeta: metav1.ObjectMeta{Name: "release"},
+	}
+	taskYAML, err := yaml.Marshal(task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pipelineYAML, err := yaml.Marshal(pipeline)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	taskBundle := pushImage(t, repo+":task", task)
+	pipelineBundle := pushImage(t, repo+":pipeline", pipeline)
+	index := pushIndex(t, repo+":index", taskBundle, pipelineBundle)
+	pushIndex(t, repo+":duplicate", taskBundle, taskBundle)
+
+	// An artifact pushed with generic tooling, without Tekton annotations.
+	artifact := pushArtifact(t, repo+":artifact", artifactType, nil, map[string]string{"org.opencontainers.image.title": "task.yaml"}, taskYAML)
+	pushArtifact(t, repo+":not-an-object", artifactType, nil, nil, []byte("just text"))
+	signature := pushArtifact(t, repo+":signature", signatureType, &artifact, nil, []byte("signature"))
+
+	for _, tc := range []struct {
+		name                string
+		bundle              string
+		kind                string
+		entry               string
+		conf                map[string]string
+		expectedData        []byte
+		expectedDigest      v1.Hash
+		expectedAnnotations map[string]string
+		expectedErr         string
+	}{{
+		name:           "pipeline from image index",
+		bundle:         repo + ":index",
+		kind:           "pipeline",
+		entry:          "release",
+		expectedData:   pipelineYAML,
+		expectedDigest: index,
+		expectedAnnotations: map[string]string{
+			bundle.ResolverAnnotationKind:       "pipeline",
+			bundle.ResolverAnnotationName:       "release",
+			bundle.ResolverAnnotationAPIVersion: "v1",
+		},
+	}, {
+		name:        "entry in more than one bundle of index",
+		bundle:      repo + ":duplicate",
+		kind:        "task",
+		entry:       "build",
+		expectedErr: "found more than one object in image index with kind: task and name: build",
+	}, {
+		name:           "artifact manifest identified by content",
+		bundle:         repo + "@" + artifact.Digest.String(),
+		kind:           "Task",
+		entry:          "build",
+		expectedData:   taskYAML,
+		expectedDigest: artifact.Digest,
+		expectedAnnotations: map[string]string{
+			bundle.ResolverAnnotationKind:       "task",
+			bundle.ResolverAnnotationName:       "build",
+			bundle.ResolverAnnotationAPIVersion: "v1",
+		},
+	}, {
+		name:           "referrers of artifact",
+		bundle:         repo + ":artifact",
+		kind:           "task",
+		entry:          "build",
+		conf:           map[string]string{bundle.ConfigDiscoverReferrers: "true"},
+		expectedData:   taskYAML,
+		expectedDigest: artifact.Digest,
+		expectedAnnotations: map[string]string{
+			bundle.ResolverAnnotationKind:       "task",
+			bundle.ResolverAnnotationName:       "build",
+			bundle.ResolverAnnotationAPIVersion: "v1",
+			bundle.ResolverAnnotationReferrers:  fmt.Sprintf(`[{"digest":%q,"artifactType":%q}]`, signature.Digest, signatureType),
+		},
+	}, {
+		name:        "artifact layer without object",
+		bundle:      repo + ":not-an-object",
+		kind:        "task",
+		entry:       "build",
+		expectedErr: "the layer 0 has no dev.tekton.image.kind annotation and does not hold a Kubernetes object",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := framework.InjectResolverConfigToContext(t.Context(), tc.conf)
+			resolved, err := bundle.GetEntry(ctx, authn.DefaultKeychain, bundle.RequestOptions{
+				Bundle:    tc.bundle,
+				Kind:      tc.kind,
+				EntryName: tc.entry,
+			})
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(string(tc.expectedData), string(resolved.Data())); d != "" {
+				t.Errorf("unexpected data: %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.expectedAnnotations, resolved.Annotations()); d != "" {
+				t.Errorf("unexpected annotations: %s", diff.PrintWantGot(d))
+			}
+			expectedRefSource := &pipelinev1.RefSource{
+				URI:        repo,
+				Digest:     map[string]string{tc.expectedDigest.Algorithm: tc.expectedDigest.Hex},
+				EntryPoint: tc.entry,
+			}
+			if d := cmp.Diff(expectedRefSource, resolved.RefSource()); d != "" {
+				t.Errorf("unexpected RefSource: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestResolveRequestVerifiesSignatures(t *testing.T) {
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := u.Host + "/signed"
+	unsigned := u.Host + "/unsigned"
+	copied := u.Host + "/copied"
+
+	task := &pipelinev1.Task{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "Task"},
+		ObjectMeta: metav1.ObjectMeta{Name: "build"},
+	}
+	signer, _, pub, err := test.GenerateKeys(elliptic.P256(), crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, otherPub, err := test.GenerateKeys(elliptic.P256(), crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signedDigest := imageDigest(t, pushImage(t, signed+":task", task))
+	pushSignature(t, signed, signedDigest, signedDigest, signer)
+	pushImage(t, unsigned+":task", task)
+	// The signature of another image copied to the signature tag of this one.
+	copiedDigest := imageDigest(t, pushImage(t, copied+":task", &pipelinev1.Task{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "Task"},
+		ObjectMeta: metav1.ObjectMeta{Name: "build", Labels: map[string]string{"copy": "true"}},
+	}))
+	pushSignature(t, copied, copiedDigest, signedDigest, signer)
+
+	policy := func(pattern string, key []byte, mode v1alpha1.ModeType) *v1alpha1.VerificationPolicy {
+		return &v1alpha1.VerificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "bundles", Namespace: "foo"},
+			Spec: v1alpha1.VerificationPolicySpec{
+				Resources:   []v1alpha1.ResourcePattern{{Pattern: pattern}},
+				Authorities: []v1alpha1.Authority{{Name: "key", Key: &v1alpha1.KeyRef{Data: string(key)}}},
+				Mode:        mode,
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name        string
+		bundle      string
+		policy      *v1alpha1.VerificationPolicy
+		expectedErr string
+	}{{
+		name:   "signed bundle",
+		bundle: signed + ":task",
+		policy: policy(signed, pub, v1alpha1.ModeEnforce),
+	}, {
+		name:        "signed with another key",
+		bundle:      signed + ":task",
+		policy:      policy(signed, otherPub, v1alpha1.ModeEnforce),
+		expectedErr: "fails verification against VerificationPolicy bundles: no signature of the image is valid for the keys of the policy",
+	}, {
+		name:   "signed with another key in warn mode",
+		bundle: signed + ":task",
+		policy: policy(signed, otherPub, v1alpha1.ModeWarn),
+	}, {
+		name:        "unsigned bundle",
+		bundle:      unsigned + ":task",
+		policy:      policy(unsigned, pub, v1alpha1.ModeEnforce),
+		expectedErr: "fails verification against VerificationPolicy bundles: cannot retrieve the signatures of the image",
+	}, {
+		name:   "unsigned bundle without matching policy",
+		bundle: unsigned + ":task",
+		policy: policy(signed, pub, v1alpha1.ModeEnforce),
+	}, {
+		name:        "signature of another image",
+		bundle:      copied + ":task",
+		policy:      policy(copied, pub, v1alpha1.ModeEnforce),
+		expectedErr: "no signature of the image is valid for the keys of the policy",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, _ := ttesting.SetupFakeContext(t)
+			ctx = framework.InjectResolverConfigToContext(ctx, map[string]string{
+				bundle.ConfigServiceAccount:   "default",
+				bundle.ConfigVerifySignatures: "true",
+			})
+			ctx = common.InjectRequestNamespace(ctx, "foo")
+			if _, err := fakepipelineclient.Get(ctx).TektonV1alpha1().VerificationPolicies("foo").Create(ctx, tc.policy, metav1.CreateOptions{}); err != nil {
+				t.Fatal(err)
+			}
+
+			resolved, err := bundle.ResolveRequest(ctx, fakekubeclient.Get(ctx), &v1beta1.ResolutionRequestSpec{
+				Params: []pipelinev1.Param{{
+					Name:  bundle.ParamBundle,
+					Value: *pipelinev1.NewStructuredValues(tc.bundle),
+				}, {
+					Name:  bundle.ParamName,
+					Value: *pipelinev1.NewStructuredValues("build"),
+				}, {
+					Name:  bundle.ParamKind,
+					Value: *pipelinev1.NewStructuredValues("task"),
+				}},
+			})
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.bundle == signed+":task" {
+				if d := cmp.Diff(map[string]string{signedDigest.Algorithm: signedDigest.Hex}, resolved.RefSource().Digest); d != "" {
+					t.Errorf("unexpected RefSource digest: %s", diff.PrintWantGot(d))
+				}
+			}
+		})
+	}
+}
+
+func parseReference(t *testing.T, ref string) name.Reference {
+	t.Helper()
+	r, err := name.ParseReference(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// pushImage pushes a bundle holding obj and returns its image.
+func pushImage(t *testing.T, ref string, obj runtime.Object) v1.Image {
+	t.Helper()
+	digestRef, err := test.CreateImage(ref, obj)
+	if err != nil {
+		t.Fatalf("couldn't push the image: %v", err)
+	}
+	img, err := remote.Image(parseReference(t, digestRef))
+	if err != nil {
+		t.Fatalf("couldn't fetch the image: %v", err)
+	}
+	return img
+}
+
+func imageDigest(t *testing.T, img v1.Image) v1.Hash {
+	t.Helper()
+	digest, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return digest
+}
+
+// pushSignature pushes a cosign signature of the image with the digest signed
+// to the signature tag of the image with the digest target in repo.
+func pushSignature(t *testing.T, repo string, target, signed v1.Hash, signer signature.Signer) {
+	t.Helper()
+	payload, err := json.Marshal(map[string]any{
+		"critical": map[string]any{
+			"identity": map[string]string{"docker-reference": repo},
+			"image":    map[string]string{"docker-manifest-digest": signed.String()},
+			"type":     "cosign container image signature",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := signer.SignMessage(bytes.NewReader(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tag := fmt.Sprintf("%s:%s-%s.sig", repo, target.Algorithm, target.Hex)
+	pushArtifact(t, tag, signatureType, nil, map[string]string{"dev.cosignproject.cosign/signature": base64.StdEncoding.EncodeToString(sig)}, payload)
+}
+
+// pushIndex pushes an image index of images and returns its digest.
+func pushIndex(t *testing.T, ref string, images ...v1.Image) v1.Hash {
+	t.Helper()
+	addenda := make([]mutate.IndexAddendum, 0, len(images))
+	for _, img := range images {
+		addenda = append(addenda, mutate.IndexAddendum{Add: img})
+	}
+	idx := mutate.AppendManifests(mutate.IndexMediaType(empty.Index, types.OCIImageIndex), addenda...)
+	if err := remote.WriteIndex(parseReference(t, ref), idx); err != nil {
+		t.Fatalf("couldn't push the image index: %v", err)
+	}
+	digest, err := idx.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return digest
+}
+
+// pushArtifact pushes an OCI 1.1 artifact manifest with the empty config and
+// a single layer holding content, like ORAS does, and returns its descriptor.
+func pushArtifact(t *testing.T, ref, artifactType string, subject *v1.Descriptor, layerAnnotations map[string]string, content []byte) v1.Descriptor {
+	t.Helper()
+	r := parseReference(t, ref)
+	// The fallback referrers tag of registries without the referrers API
+	// reports the config media type as artifact type, so the config of
+	// artifacts with a subject has the artifact type, like ORAS used to.
+	configType := types.MediaType("application/vnd.oci.empty.v1+json")
+	if subject != nil {
+		configType = types.MediaType(artifactType)
+	}
+	config := uploadBlob(t, r.Context(), configType, []byte("{}"))
+	layer := uploadBlob(t, r.Context(), "application/vnd.oci.image.layer.v1.tar", content)
+	layer.Annotations = layerAnnotations
+
+	manifest, err := json.Marshal(map[string]any{
+		"schemaVersion": 2,
+		"mediaType":     types.OCIManifestSchema1,
+		"artifactType":  artifactType,
+		"config":        config,
+		"layers":        []v1.Descriptor{layer},
+		"subject":       subject,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Put(r, rawManifest(manifest)); err != nil {
+		t.Fatalf("couldn't push the artifact: %v", err)
+	}
+	digest, _, err := v1.SHA256(bytes.NewReader(manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v1.Descriptor{MediaType: types.OCIManifestSchema1, Size: int64(len(manifest)), Digest: digest}
+}
+
+// uploadBlob uploads content to repo and returns its descriptor.
+func uploadBlob(t *testing.T, repo name.Repository, mediaType types.MediaType, content []byte) v1.Descriptor {
+	t.Helper()
+	layer, err := partial.CompressedToLayer(rawBlob{content: content, mediaType: mediaType})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.WriteLayer(repo, layer); err != nil {
+		t.Fatalf("couldn't upload blob: %v", err)
+	}
+	digest, err := layer.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v1.Descriptor{MediaType: mediaType, Size: int64(len(content)), Digest: digest}
+}
+
+// rawBlob is a blob stored as is, without compression.
+type rawBlob struct {
+	content   []byte
+	mediaType types.MediaType
+}
+
+func (b rawBlob) Digest() (v1.Hash, error) {
+	h, _, err := v1.SHA256(bytes.NewReader(b.content))
+	return h, err
+}
+
+func (b rawBlob) Compressed() (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(b.content)), nil
+}
+
+func (b rawBlob) Size() (int64, error) {
+	return int64(len(b.content)), nil
+}
+
+func (b rawBlob) MediaType() (types.MediaType, error) {
+	return b.mediaType, nil
+}
+
+// rawManifest is a manifest pushed as is.
+type rawManifest []byte
+
+func (m rawManifest) RawManifest() ([]byte, error) {
+	return m, nil
+}
+
+func (m rawManifest) MediaType() (types.MediaType, error) {
+	return types.OCIManifestSchema1, nil
+}
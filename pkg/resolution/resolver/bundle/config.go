@@ -54,8 +54,31 @@ const (
 	// the maximum duration to try when backing off
 	ConfigBackoffCap  = "backoff-cap"
 	DefaultBackoffCap = 10 * time.Second
+	// ConfigDiscoverReferrers is the configuration field name for controlling
+	// whether the artifacts referring to a bundle, such as signatures and SBOMs,
+	// are listed in the annotations of the resolved resource
+	ConfigDiscoverReferrers = "discover-referrers"
+	// ConfigVerifySignatures is the configuration field name for controlling
+	// whether the cosign signatures of bundles are verified against the
+	// VerificationPolicies of the request namespace
+	ConfigVerifySignatures = "verify-signatures"
 )
 
+// discoverReferrers returns true if the referrers of bundles should be listed.
+func discoverReferrers(ctx context.Context) bool {
+	conf := framework.GetResolverConfigFromContext(ctx)
+	discover, _ := strconv.ParseBool(conf[ConfigDiscoverReferrers])
+	return discover
+}
+
+// VerifySignatures returns true if the signatures of bundles should be
+// verified against the VerificationPolicies of the request namespace.
+func VerifySignatures(ctx context.Context) bool {
+	conf := framework.GetResolverConfigFromContext(ctx)
+	verify, _ := strconv.ParseBool(conf[ConfigVerifySignatures])
+	return verify
+}
+
 // GetBundleResolverBackoff returns a remote.Backoff to
 // be passed when resolving remote images. This can be configured with the
 // backoff-duration, backoff-factor, backoff-jitter, backoff-steps, and backoff-cap
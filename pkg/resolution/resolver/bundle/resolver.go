@@ -112,7 +112,14 @@ func ResolveRequest(ctx context.Context, kubeClientSet kubernetes.Interface, req
 	if err != nil {
 		return nil, err
 	}
-	return GetEntry(ctx, kc, opts)
+	var v *bundleVerifier
+	if VerifySignatures(ctx) {
+		v, err = newBundleVerifier(ctx, kubeClientSet, namespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return getEntry(ctx, kc, opts, v)
 }
 
 func ValidateParams(ctx context.Context, params []v1.Param) error {
@@ -0,0 +1,225 @@
+/*
+Copyright 2025 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"github.com/tektoncd/pipeline/pkg/trustedresources/verifier"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// cosignSignatureAnnotation is the annotation of the layers of a cosign
+	// signature image holding the base64 encoded signature of the layer.
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	// maxSignatures is the maximum number of signatures read from the
+	// signature image of a bundle.
+	maxSignatures = 10
+)
+
+// simpleSigningPayload is the part of a cosign simple signing payload
+// naming the signed image digest.
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// cosignSignature is a signature of a bundle with the payload it signs.
+type cosignSignature struct {
+	payload   []byte
+	signature []byte
+	digest    string
+}
+
+// bundleVerifier verifies the cosign signatures of bundles against the
+// VerificationPolicies of the request namespace.
+type bundleVerifier struct {
+	k8s      kubernetes.Interface
+	policies []*v1alpha1.VerificationPolicy
+}
+
+// newBundleVerifier returns a bundleVerifier for the VerificationPolicies in
+// namespace.
+func newBundleVerifier(ctx context.Context, k8s kubernetes.Interface, namespace string) (*bundleVerifier, error) {
+	list, err := pipelineclient.Get(ctx).TektonV1alpha1().VerificationPolicies(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list the VerificationPolicies in namespace %s: %w", namespace, err)
+	}
+	policies := make([]*v1alpha1.VerificationPolicy, 0, len(list.Items))
+	for i := range list.Items {
+		policies = append(policies, &list.Items[i])
+	}
+	return &bundleVerifier{k8s: k8s, policies: policies}, nil
+}
+
+// verify verifies the signatures of the bundle image d against the policies
+// matching its repository. The image must satisfy all the matching "enforce"
+// policies, while failing "warn" policies are only logged. Bundles matching
+// no policy are not verified.
+func (v *bundleVerifier) verify(ctx context.Context, d name.Digest, remoteOpts []remote.Option) error {
+	policies, err := v.matchedPolicies(d.Context().Name())
+	if err != nil {
+		return err
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+
+	logger := logging.FromContext(ctx)
+	signatures, fetchErr := cosignSignatures(d, remoteOpts)
+	for _, p := range policies {
+		err := fetchErr
+		if err == nil {
+			err = verifyWithPolicy(ctx, v.k8s, p, d, signatures)
+		}
+		if err == nil {
+			continue
+		}
+		err = fmt.Errorf("bundle %s fails verification against VerificationPolicy %s: %w", d, p.Name, err)
+		if p.Spec.Mode == v1alpha1.ModeWarn {
+			logger.Warn(err.Error())
+			continue
+		}
+		return err
+	}
+	return nil
+}
+
+// matchedPolicies returns the policies with a resource pattern matching the
+// repository of a bundle.
+func (v *bundleVerifier) matchedPolicies(repository string) ([]*v1alpha1.VerificationPolicy, error) {
+	var matched []*v1alpha1.VerificationPolicy
+	for _, p := range v.policies {
+		for _, r := range p.Spec.Resources {
+			matching, err := regexp.MatchString(r.Pattern, repository)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %q in VerificationPolicy %s: %w", r.Pattern, p.Name, err)
+			}
+			if matching {
+				matched = append(matched, p)
+				break
+			}
+		}
+	}
+	return matched, nil
+}
+
+// verifyWithPolicy returns nil if any signature of d is valid for any key of
+// the policy.
+func verifyWithPolicy(ctx context.Context, k8s kubernetes.Interface, p *v1alpha1.VerificationPolicy, d name.Digest, signatures []cosignSignature) error {
+	verifiers, err := verifier.FromPolicy(ctx, k8s, p)
+	if err != nil {
+		return fmt.Errorf("failed to get verifiers from policy: %w", err)
+	}
+	for _, s := range signatures {
+		if s.digest != d.DigestStr() {
+			continue
+		}
+		for _, sv := range verifiers {
+			if err := sv.VerifySignature(bytes.NewReader(s.signature), bytes.NewReader(s.payload), options.WithContext(ctx)); err == nil {
+				return nil
+			}
+		}
+	}
+	return errors.New("no signature of the image is valid for the keys of the policy")
+}
+
+// cosignSignatures returns the signatures of the image d stored by cosign in
+// the sha256-<hex>.sig tag of its repository.
+func cosignSignatures(d name.Digest, remoteOpts []remote.Option) ([]cosignSignature, error) {
+	tag := d.Context().Tag(strings.ReplaceAll(d.DigestStr(), ":", "-") + ".sig")
+	img, err := remote.Image(tag, remoteOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot retrieve the signatures of the image: %w", err)
+	}
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the manifest of the signatures of the image: %w", err)
+	}
+
+	var signatures []cosignSignature
+	for _, l := range manifest.Layers {
+		encoded, ok := l.Annotations[cosignSignatureAnnotation]
+		if !ok {
+			continue
+		}
+		if len(signatures) == maxSignatures {
+			break
+		}
+		sig, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature in layer %s: %w", l.Digest, err)
+		}
+		layer, err := img.LayerByDigest(l.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("cannot retrieve the signature payload %s: %w", l.Digest, err)
+		}
+		payload, err := readPayload(layer)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read the signature payload %s: %w", l.Digest, err)
+		}
+		var p simpleSigningPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, fmt.Errorf("invalid signature payload %s: %w", l.Digest, err)
+		}
+		signatures = append(signatures, cosignSignature{
+			payload:   payload,
+			signature: sig,
+			digest:    p.Critical.Image.DockerManifestDigest,
+		})
+	}
+	return signatures, nil
+}
+
+// readPayload reads a signature payload of at most
+// framework.MaxResolvedResourceSize bytes.
+func readPayload(layer v1.Layer) ([]byte, error) {
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+	payload, err := io.ReadAll(io.LimitReader(rc, framework.MaxResolvedResourceSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) > framework.MaxResolvedResourceSize {
+		return nil, fmt.Errorf("payload is larger than %d bytes", framework.MaxResolvedResourceSize)
+	}
+	return payload, nil
+}
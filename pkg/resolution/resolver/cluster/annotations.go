@@ -24,4 +24,7 @@ var (
 	ResourceNameAnnotation = resolution.GroupName + "/name"
 	// ResourceNamespaceAnnotation is the annotation key for the fetched resource's namespace
 	ResourceNamespaceAnnotation = resolution.GroupName + "/namespace"
+	// GrantedNamespacesAnnotation is the annotation key of resources for the comma-separated list of
+	// namespaces which may resolve them from other namespaces when grants are enforced, or "*" for all
+	GrantedNamespacesAnnotation = resolution.GroupName + "/granted-namespaces"
 )
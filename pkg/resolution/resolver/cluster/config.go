@@ -28,4 +28,7 @@ const (
 	// BlockedNamespacesKey is the key in the config map for an optional comma-separated list of namespaces which the
 	// resolver is blocked from accessing. Defaults to empty, meaning no namespaces are blocked.
 	BlockedNamespacesKey = "blocked-namespaces"
+	// EnforceGrantsKey is the key in the config map for an optional setting requiring resources to grant access
+	// to the namespaces resolving them from other namespaces. Defaults to false.
+	EnforceGrantsKey = "enforce-grants"
 )
@@ -23,4 +23,10 @@ const (
 	NameParam = "name"
 	// NamespaceParam is the parameter for the namespace containing the object
 	NamespaceParam = "namespace"
+	// SelectorParam is the parameter for a label selector matching the object,
+	// used instead of its name
+	SelectorParam = "selector"
+	// VersionParam is the parameter for a version constraint on the version label
+	// of the objects matching the selector, used instead of its name
+	VersionParam = "version"
 )
@@ -22,8 +22,10 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
+	goversion "github.com/hashicorp/go-version"
 	resolverconfig "github.com/tektoncd/pipeline/pkg/apis/config/resolver"
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
@@ -32,6 +34,7 @@ import (
 	common "github.com/tektoncd/pipeline/pkg/resolution/common"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"knative.dev/pkg/logging"
 	"sigs.k8s.io/yaml"
 )
@@ -48,6 +51,13 @@ const (
 	ClusterResolverName string = "Cluster"
 
 	ConfigMapName = "cluster-resolver-config"
+
+	// VersionLabel is the label holding the version of resources selected
+	// with the version param.
+	VersionLabel = "app.kubernetes.io/version"
+
+	uidDigestKey             = "uid"
+	resourceVersionDigestKey = "resourceVersion"
 )
 
 var supportedKinds = []string{"task", "pipeline", "stepaction"}
@@ -116,13 +126,27 @@ func ResolveFromParams(ctx context.Context, origParams []pipelinev1.Param, pipel
 	var spec []byte
 	var sha256Checksum []byte
 	var uid string
+	var selected metav1.Object
 	groupVersion := pipelinev1.SchemeGroupVersion.String()
+	byName := params[NameParam] != ""
 
 	switch params[KindParam] {
 	case "stepaction":
-		stepaction, err := pipelineClientSet.TektonV1beta1().StepActions(params[NamespaceParam]).Get(ctx, params[NameParam], metav1.GetOptions{})
+		var stepaction *pipelinev1beta1.StepAction
+		if byName {
+			stepaction, err = pipelineClientSet.TektonV1beta1().StepActions(params[NamespaceParam]).Get(ctx, params[NameParam], metav1.GetOptions{})
+		} else {
+			var list *pipelinev1beta1.StepActionList
+			if list, err = pipelineClientSet.TektonV1beta1().StepActions(params[NamespaceParam]).List(ctx, listOptions(params)); err == nil {
+				stepaction, err = selectObject(params, list.Items)
+				selected = stepaction
+			}
+		}
 		if err != nil {
-			logger.Infof("failed to load stepaction %s from namespace %s: %v", params[NameParam], params[NamespaceParam], err)
+			logger.Infof("failed to load stepaction %s from namespace %s: %v", describeLookup(params), params[NamespaceParam], err)
+			return nil, err
+		}
+		if err := checkGrant(ctx, params[KindParam], stepaction); err != nil {
 			return nil, err
 		}
 		uid, data, sha256Checksum, spec, err = fetchStepaction(ctx, pipelinev1beta1.SchemeGroupVersion.String(), stepaction, params)
@@ -130,9 +154,21 @@ func ResolveFromParams(ctx context.Context, origParams []pipelinev1.Param, pipel
 			return nil, err
 		}
 	case "task":
-		task, err := pipelineClientSet.TektonV1().Tasks(params[NamespaceParam]).Get(ctx, params[NameParam], metav1.GetOptions{})
+		var task *pipelinev1.Task
+		if byName {
+			task, err = pipelineClientSet.TektonV1().Tasks(params[NamespaceParam]).Get(ctx, params[NameParam], metav1.GetOptions{})
+		} else {
+			var list *pipelinev1.TaskList
+			if list, err = pipelineClientSet.TektonV1().Tasks(params[NamespaceParam]).List(ctx, listOptions(params)); err == nil {
+				task, err = selectObject(params, list.Items)
+				selected = task
+			}
+		}
 		if err != nil {
-			logger.Infof("failed to load task %s from namespace %s: %v", params[NameParam], params[NamespaceParam], err)
+			logger.Infof("failed to load task %s from namespace %s: %v", describeLookup(params), params[NamespaceParam], err)
+			return nil, err
+		}
+		if err := checkGrant(ctx, params[KindParam], task); err != nil {
 			return nil, err
 		}
 		uid, data, sha256Checksum, spec, err = fetchTask(ctx, groupVersion, task, params)
@@ -140,9 +176,21 @@ func ResolveFromParams(ctx context.Context, origParams []pipelinev1.Param, pipel
 			return nil, err
 		}
 	case "pipeline":
-		pipeline, err := pipelineClientSet.TektonV1().Pipelines(params[NamespaceParam]).Get(ctx, params[NameParam], metav1.GetOptions{})
+		var pipeline *pipelinev1.Pipeline
+		if byName {
+			pipeline, err = pipelineClientSet.TektonV1().Pipelines(params[NamespaceParam]).Get(ctx, params[NameParam], metav1.GetOptions{})
+		} else {
+			var list *pipelinev1.PipelineList
+			if list, err = pipelineClientSet.TektonV1().Pipelines(params[NamespaceParam]).List(ctx, listOptions(params)); err == nil {
+				pipeline, err = selectObject(params, list.Items)
+				selected = pipeline
+			}
+		}
 		if err != nil {
-			logger.Infof("failed to load pipeline %s from namespace %s: %v", params[NameParam], params[NamespaceParam], err)
+			logger.Infof("failed to load pipeline %s from namespace %s: %v", describeLookup(params), params[NamespaceParam], err)
+			return nil, err
+		}
+		if err := checkGrant(ctx, params[KindParam], pipeline); err != nil {
 			return nil, err
 		}
 		uid, data, sha256Checksum, spec, err = fetchPipeline(ctx, groupVersion, pipeline, params)
@@ -154,14 +202,19 @@ func ResolveFromParams(ctx context.Context, origParams []pipelinev1.Param, pipel
 		return nil, fmt.Errorf("unknown or invalid resource kind %s", params[KindParam])
 	}
 
-	return &ResolvedClusterResource{
+	resource := &ResolvedClusterResource{
 		Content:    data,
 		Spec:       spec,
 		Name:       params[NameParam],
 		Namespace:  params[NamespaceParam],
 		Identifier: fmt.Sprintf("/apis/%s/namespaces/%s/%s/%s@%s", groupVersion, params[NamespaceParam], params[KindParam], params[NameParam], uid),
 		Checksum:   sha256Checksum,
-	}, nil
+	}
+	if selected != nil {
+		resource.UID = uid
+		resource.ResourceVersion = selected.GetResourceVersion()
+	}
+	return resource, nil
 }
 
 // ResolvedClusterResource implements framework.ResolvedResource and returns
@@ -182,6 +235,11 @@ type ResolvedClusterResource struct {
 	Identifier string
 	// Sha256 Checksum of the cluster resource
 	Checksum []byte
+	// UID is the UID of the resource, set when it was selected by labels.
+	UID string
+	// ResourceVersion is the resourceVersion of the resource, set when it
+	// was selected by labels.
+	ResourceVersion string
 }
 
 var _ framework.ResolvedResource = &ResolvedClusterResource{}
@@ -201,12 +259,21 @@ func (r *ResolvedClusterResource) Annotations() map[string]string {
 
 // RefSource is the source reference of the remote data that records where the remote
 // file came from including the url, digest and the entrypoint.
+// Resources selected by labels also record the UID and resourceVersion of the
+// selected object in the digest.
 func (r ResolvedClusterResource) RefSource() *pipelinev1.RefSource {
+	digest := map[string]string{
+		"sha256": hex.EncodeToString(r.Checksum),
+	}
+	if r.UID != "" {
+		digest[uidDigestKey] = r.UID
+	}
+	if r.ResourceVersion != "" {
+		digest[resourceVersionDigestKey] = r.ResourceVersion
+	}
 	return &pipelinev1.RefSource{
-		URI: r.Identifier,
-		Digest: map[string]string{
-			"sha256": hex.EncodeToString(r.Checksum),
-		},
+		URI:    r.Identifier,
+		Digest: digest,
 	}
 }
 
@@ -235,10 +302,24 @@ func populateParamsWithDefaults(ctx context.Context, origParams []pipelinev1.Par
 		return nil, fmt.Errorf("unknown or unsupported resource kind '%s'", kindVal)
 	}
 
-	if pName, ok := paramsMap[NameParam]; !ok || pName.StringVal == "" {
+	params[NameParam] = paramsMap[NameParam].StringVal
+	params[SelectorParam] = paramsMap[SelectorParam].StringVal
+	params[VersionParam] = paramsMap[VersionParam].StringVal
+	switch {
+	case params[NameParam] != "" && (params[SelectorParam] != "" || params[VersionParam] != ""):
+		return nil, fmt.Errorf("%s cannot be used together with %s or %s", NameParam, SelectorParam, VersionParam)
+	case params[NameParam] == "" && params[SelectorParam] == "" && params[VersionParam] == "":
 		missingParams = append(missingParams, NameParam)
-	} else {
-		params[NameParam] = pName.StringVal
+	}
+	if params[SelectorParam] != "" {
+		if _, err := labels.Parse(params[SelectorParam]); err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", SelectorParam, params[SelectorParam], err)
+		}
+	}
+	if params[VersionParam] != "" {
+		if _, err := goversion.NewConstraint(params[VersionParam]); err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", VersionParam, params[VersionParam], err)
+		}
 	}
 
 	if pNS, ok := paramsMap[NamespaceParam]; !ok || pNS.StringVal == "" {
@@ -274,6 +355,110 @@ func populateParamsWithDefaults(ctx context.Context, origParams []pipelinev1.Par
 	return params, nil
 }
 
+// listOptions returns the options listing the objects matching the selector
+// and, if a version is requested, having a version label.
+func listOptions(params map[string]string) metav1.ListOptions {
+	selector := params[SelectorParam]
+	if params[VersionParam] != "" {
+		if selector != "" {
+			selector += ","
+		}
+		selector += VersionLabel
+	}
+	return metav1.ListOptions{LabelSelector: selector}
+}
+
+// describeLookup describes the object looked up with params in logs.
+func describeLookup(params map[string]string) string {
+	if params[NameParam] != "" {
+		return params[NameParam]
+	}
+	return fmt.Sprintf("matching selector %q and version %q", params[SelectorParam], params[VersionParam])
+}
+
+// selectObject returns the only item matching the selector or, if a version
+// is requested, the item with the highest version label satisfying it. The
+// name of the selected item is set in params.
+func selectObject[T any, PT interface {
+	*T
+	metav1.Object
+}](params map[string]string, items []T) (PT, error) {
+	var constraint goversion.Constraints
+	if params[VersionParam] != "" {
+		var err error
+		if constraint, err = goversion.NewConstraint(params[VersionParam]); err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", VersionParam, params[VersionParam], err)
+		}
+	}
+
+	var candidates []PT
+	var highest *goversion.Version
+	for i := range items {
+		item := PT(&items[i])
+		if constraint == nil {
+			candidates = append(candidates, item)
+			continue
+		}
+		v, err := goversion.NewVersion(item.GetLabels()[VersionLabel])
+		if err != nil || !constraint.Check(v) {
+			// Objects with an invalid version label are never selected.
+			continue
+		}
+		switch {
+		case highest == nil || v.GreaterThan(highest):
+			highest = v
+			candidates = []PT{item}
+		case v.Equal(highest):
+			candidates = append(candidates, item)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("no %s in namespace %s matches selector %q and version %q", params[KindParam], params[NamespaceParam], params[SelectorParam], params[VersionParam])
+	case 1:
+		params[NameParam] = candidates[0].GetName()
+		return candidates[0], nil
+	}
+	names := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		names = append(names, c.GetName())
+	}
+	slices.Sort(names)
+	if highest != nil {
+		return nil, fmt.Errorf("more than one %s in namespace %s matches selector %q with version %s: %s", params[KindParam], params[NamespaceParam], params[SelectorParam], highest.String(), strings.Join(names, ", "))
+	}
+	return nil, fmt.Errorf("more than one %s in namespace %s matches selector %q: %s", params[KindParam], params[NamespaceParam], params[SelectorParam], strings.Join(names, ", "))
+}
+
+// EnforcesGrants returns true if resources must grant access to the
+// namespaces resolving them from other namespaces.
+func EnforcesGrants(ctx context.Context) bool {
+	conf := framework.GetResolverConfigFromContext(ctx)
+	enforce, _ := strconv.ParseBool(conf[EnforceGrantsKey])
+	return enforce
+}
+
+// checkGrant returns an error if grants are enforced and the resource does
+// not grant access to the namespace of the request resolving it.
+func checkGrant(ctx context.Context, kind string, resource metav1.Object) error {
+	if !EnforcesGrants(ctx) {
+		return nil
+	}
+	requester := common.RequestNamespace(ctx)
+	if requester != "" && requester == resource.GetNamespace() {
+		return nil
+	}
+	if requester != "" {
+		for _, ns := range strings.Split(resource.GetAnnotations()[GrantedNamespacesAnnotation], ",") {
+			if ns = strings.TrimSpace(ns); ns == "*" || ns == requester {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%s %s in namespace %s does not grant access to namespace %q", kind, resource.GetName(), resource.GetNamespace(), requester)
+}
+
 func isInCommaSeparatedList(checkVal string, commaList string) bool {
 	for _, s := range strings.Split(commaList, ",") {
 		if s == checkVal {
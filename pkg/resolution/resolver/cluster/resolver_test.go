@@ -22,6 +22,7 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -30,6 +31,7 @@ import (
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	fakepipelineclientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
 	"github.com/tektoncd/pipeline/pkg/internal/resolution"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	common "github.com/tektoncd/pipeline/pkg/resolution/common"
@@ -40,6 +42,7 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/system"
 	_ "knative.dev/pkg/system/testing"
@@ -136,6 +139,31 @@ func TestValidateParamsFailure(t *testing.T) {
 				cluster.KindParam: "task",
 			},
 			expectedErr: "missing required cluster resolver params: name, namespace",
+		}, {
+			name: "name with selector",
+			params: map[string]string{
+				cluster.KindParam:      "task",
+				cluster.NamespaceParam: "foo",
+				cluster.NameParam:      "bar",
+				cluster.SelectorParam:  "app=bar",
+			},
+			expectedErr: "name cannot be used together with selector or version",
+		}, {
+			name: "invalid selector",
+			params: map[string]string{
+				cluster.KindParam:      "task",
+				cluster.NamespaceParam: "foo",
+				cluster.SelectorParam:  "app in bar",
+			},
+			expectedErr: `invalid selector "app in bar": unable to parse requirement: found 'bar' expected: '('`,
+		}, {
+			name: "invalid version",
+			params: map[string]string{
+				cluster.KindParam:      "task",
+				cluster.NamespaceParam: "foo",
+				cluster.VersionParam:   "latest",
+			},
+			expectedErr: `invalid version "latest": malformed constraint: latest`,
 		}, {
 			name: "not in allowed namespaces",
 			params: map[string]string{
@@ -500,6 +528,202 @@ func TestResolve(t *testing.T) {
 	}
 }
 
+func TestResolveEnforcesGrants(t *testing.T) {
+	task := func(name, granted string) *pipelinev1.Task {
+		tsk := &pipelinev1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "task-ns"},
+		}
+		if granted != "" {
+			tsk.Annotations = map[string]string{cluster.GrantedNamespacesAnnotation: granted}
+		}
+		return tsk
+	}
+	pipelineClientSet := fakepipelineclientset.NewSimpleClientset(
+		task("ungranted", ""),
+		task("granted", "other-ns, consumer-ns"),
+		task("public", "*"),
+	)
+
+	for _, tc := range []struct {
+		name             string
+		resourceName     string
+		requestNamespace string
+		enforceGrants    string
+		expectedErr      string
+	}{{
+		name:             "grants not enforced",
+		resourceName:     "ungranted",
+		requestNamespace: "consumer-ns",
+	}, {
+		name:             "no grant",
+		resourceName:     "ungranted",
+		requestNamespace: "consumer-ns",
+		enforceGrants:    "true",
+		expectedErr:      `task ungranted in namespace task-ns does not grant access to namespace "consumer-ns"`,
+	}, {
+		name:             "same namespace",
+		resourceName:     "ungranted",
+		requestNamespace: "task-ns",
+		enforceGrants:    "true",
+	}, {
+		name:             "granted namespace",
+		resourceName:     "granted",
+		requestNamespace: "consumer-ns",
+		enforceGrants:    "true",
+	}, {
+		name:             "other namespace",
+		resourceName:     "granted",
+		requestNamespace: "another-ns",
+		enforceGrants:    "true",
+		expectedErr:      `task granted in namespace task-ns does not grant access to namespace "another-ns"`,
+	}, {
+		name:             "granted to all namespaces",
+		resourceName:     "public",
+		requestNamespace: "another-ns",
+		enforceGrants:    "true",
+	}, {
+		name:          "unknown request namespace",
+		resourceName:  "public",
+		enforceGrants: "true",
+		expectedErr:   `task public in namespace task-ns does not grant access to namespace ""`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{
+				cluster.EnforceGrantsKey: tc.enforceGrants,
+			})
+			ctx = common.InjectRequestNamespace(ctx, tc.requestNamespace)
+			params := []pipelinev1.Param{{
+				Name:  cluster.KindParam,
+				Value: *pipelinev1.NewStructuredValues("task"),
+			}, {
+				Name:  cluster.NameParam,
+				Value: *pipelinev1.NewStructuredValues(tc.resourceName),
+			}, {
+				Name:  cluster.NamespaceParam,
+				Value: *pipelinev1.NewStructuredValues("task-ns"),
+			}}
+			_, err := cluster.ResolveFromParams(ctx, params, pipelineClientSet)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expectedErr {
+				t.Fatalf("expected error %q, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestResolveBySelector(t *testing.T) {
+	task := func(name, uid, resourceVersion string, labels map[string]string) *pipelinev1.Task {
+		return &pipelinev1.Task{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "task-ns",
+				UID:             types.UID(uid),
+				ResourceVersion: resourceVersion,
+				Labels:          labels,
+			},
+		}
+	}
+	pipelineClientSet := fakepipelineclientset.NewSimpleClientset(
+		task("build-0.1", "a1", "11", map[string]string{"app": "build", cluster.VersionLabel: "0.1.0"}),
+		task("build-0.2", "a2", "12", map[string]string{"app": "build", cluster.VersionLabel: "0.2.1"}),
+		task("build-1.0", "a3", "13", map[string]string{"app": "build", cluster.VersionLabel: "1.0.0"}),
+		task("build-nightly", "a4", "14", map[string]string{"app": "build", cluster.VersionLabel: "nightly"}),
+		task("test-1.0", "b1", "21", map[string]string{"app": "test", cluster.VersionLabel: "1.0.0"}),
+		task("test-1.0-copy", "b2", "22", map[string]string{"app": "test", cluster.VersionLabel: "v1.0.0"}),
+		task("deploy", "c1", "31", map[string]string{"app": "deploy"}),
+	)
+
+	for _, tc := range []struct {
+		name                    string
+		selector                string
+		version                 string
+		expectedName            string
+		expectedUID             string
+		expectedResourceVersion string
+		expectedErr             string
+	}{{
+		name:                    "single match",
+		selector:                "app=deploy",
+		expectedName:            "deploy",
+		expectedUID:             "c1",
+		expectedResourceVersion: "31",
+	}, {
+		name:                    "highest version in range",
+		selector:                "app=build",
+		version:                 ">= 0.1, < 1.0",
+		expectedName:            "build-0.2",
+		expectedUID:             "a2",
+		expectedResourceVersion: "12",
+	}, {
+		name:                    "highest version",
+		selector:                "app=build",
+		version:                 ">= 0",
+		expectedName:            "build-1.0",
+		expectedUID:             "a3",
+		expectedResourceVersion: "13",
+	}, {
+		name:        "multiple matches",
+		selector:    "app=build",
+		expectedErr: `more than one task in namespace task-ns matches selector "app=build": build-0.1, build-0.2, build-1.0, build-nightly`,
+	}, {
+		name:        "multiple matches of highest version",
+		selector:    "app=test",
+		version:     "~> 1.0",
+		expectedErr: `more than one task in namespace task-ns matches selector "app=test" with version 1.0.0: test-1.0, test-1.0-copy`,
+	}, {
+		name:        "no match",
+		selector:    "app=build",
+		version:     ">= 2.0",
+		expectedErr: `no task in namespace task-ns matches selector "app=build" and version ">= 2.0"`,
+	}, {
+		name:        "no version label",
+		selector:    "app=deploy",
+		version:     ">= 0",
+		expectedErr: `no task in namespace task-ns matches selector "app=deploy" and version ">= 0"`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			params := []pipelinev1.Param{{
+				Name:  cluster.KindParam,
+				Value: *pipelinev1.NewStructuredValues("task"),
+			}, {
+				Name:  cluster.NamespaceParam,
+				Value: *pipelinev1.NewStructuredValues("task-ns"),
+			}, {
+				Name:  cluster.SelectorParam,
+				Value: *pipelinev1.NewStructuredValues(tc.selector),
+			}, {
+				Name:  cluster.VersionParam,
+				Value: *pipelinev1.NewStructuredValues(tc.version),
+			}}
+			resolved, err := cluster.ResolveFromParams(t.Context(), params, pipelineClientSet)
+			if tc.expectedErr != "" {
+				if err == nil || err.Error() != tc.expectedErr {
+					t.Fatalf("expected error %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.expectedName, resolved.Annotations()[cluster.ResourceNameAnnotation]); d != "" {
+				t.Errorf("unexpected resource: %s", diff.PrintWantGot(d))
+			}
+			refSource := resolved.RefSource()
+			if d := cmp.Diff(fmt.Sprintf("/apis/tekton.dev/v1/namespaces/task-ns/task/%s@%s", tc.expectedName, tc.expectedUID), refSource.URI); d != "" {
+				t.Errorf("unexpected RefSource URI: %s", diff.PrintWantGot(d))
+			}
+			if refSource.Digest["uid"] != tc.expectedUID || refSource.Digest["resourceVersion"] != tc.expectedResourceVersion {
+				t.Errorf("expected uid %s and resourceVersion %s in RefSource digest, got %v", tc.expectedUID, tc.expectedResourceVersion, refSource.Digest)
+			}
+		})
+	}
+}
+
 func createRequest(kind, name, namespace string) *v1beta1.ResolutionRequest {
 	rr := &v1beta1.ResolutionRequest{
 		TypeMeta: metav1.TypeMeta{
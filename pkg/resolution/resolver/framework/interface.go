@@ -93,6 +93,11 @@ type TimedResolution interface {
 	GetResolutionTimeout(ctx context.Context, timeout time.Duration, params map[string]string) (time.Duration, error)
 }
 
+// MaxResolvedResourceSize is the largest resource, in bytes, that resolvers
+// read from a remote location. Resolved data is stored in the status of the
+// ResolutionRequest, so larger resources could not be returned anyway.
+const MaxResolvedResourceSize = 1024 * 1024
+
 // ResolvedResource returns the data and annotations of a successful
 // resource fetch.
 type ResolvedResource interface {
@@ -48,6 +48,40 @@ func ContextWithHttpResolverDisabled(ctx context.Context) context.Context {
 	return contextWithResolverDisabled(ctx, "enable-http-resolver")
 }
 
+// ContextWithS3ResolverDisabled returns a context containing a Config with the enable-s3-resolver feature flag disabled.
+func ContextWithS3ResolverDisabled(ctx context.Context) context.Context {
+	return contextWithResolverDisabled(ctx, "enable-s3-resolver")
+}
+
+// ContextWithS3ResolverEnabled returns a context containing a Config with the enable-s3-resolver feature flag enabled,
+// since the s3 resolver is disabled by default.
+func ContextWithS3ResolverEnabled(ctx context.Context) context.Context {
+	featureFlags, _ := resolverconfig.NewFeatureFlagsFromMap(map[string]string{
+		"enable-s3-resolver": "true",
+	})
+	cfg := &resolverconfig.Config{
+		FeatureFlags: featureFlags,
+	}
+	return resolverconfig.ToContext(ctx, cfg)
+}
+
+// ContextWithExternalResolverDisabled returns a context containing a Config with the enable-external-resolver feature flag disabled.
+func ContextWithExternalResolverDisabled(ctx context.Context) context.Context {
+	return contextWithResolverDisabled(ctx, "enable-external-resolver")
+}
+
+// ContextWithExternalResolverEnabled returns a context containing a Config with the enable-external-resolver feature flag
+// enabled, since the external resolver is disabled by default.
+func ContextWithExternalResolverEnabled(ctx context.Context) context.Context {
+	featureFlags, _ := resolverconfig.NewFeatureFlagsFromMap(map[string]string{
+		"enable-external-resolver": "true",
+	})
+	cfg := &resolverconfig.Config{
+		FeatureFlags: featureFlags,
+	}
+	return resolverconfig.ToContext(ctx, cfg)
+}
+
 func contextWithResolverDisabled(ctx context.Context, resolverFlag string) context.Context {
 	featureFlags, _ := resolverconfig.NewFeatureFlagsFromMap(map[string]string{
 		resolverFlag: "false",
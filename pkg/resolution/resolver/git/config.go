@@ -51,21 +51,43 @@ const (
 	APISecretKeyKey = "api-token-secret-key"
 	// APISecretNamespaceKey is the config map key for the token secret's namespace
 	APISecretNamespaceKey = "api-token-secret-namespace"
+
+	// MirrorPathKey is the config map key for the directory holding the bare
+	// repository mirrors used by git clone resolution, with or without
+	// credentials. Every request fetches into the mirror with its own
+	// credentials before reading from it. Mirrors are disabled when unset.
+	MirrorPathKey = "mirror-path"
+	// MirrorMaxBytesKey is the config map key for the disk budget shared by all
+	// repository mirrors, as a Kubernetes quantity (e.g. "2Gi").
+	MirrorMaxBytesKey = "mirror-max-bytes"
+
+	// RequireSignatureKey is the config map key which, when "true", requires
+	// resolved commits or tags to be signed by one of the allowed signers.
+	RequireSignatureKey = "require-signature"
+	// AllowedSignersSecretNameKey is the config map key for the name of the
+	// secret holding the allowed signers.
+	AllowedSignersSecretNameKey = "allowed-signers-secret-name"
+	// AllowedSignersSecretNamespaceKey is the config map key for the namespace
+	// of the secret holding the allowed signers.
+	AllowedSignersSecretNamespaceKey = "allowed-signers-secret-namespace"
 )
 
 type GitResolverConfig map[string]ScmConfig
 
 type ScmConfig struct {
-	Timeout            string `json:"fetch-timeout"`
-	URL                string `json:"default-url"`
-	Revision           string `json:"default-revision"`
-	Org                string `json:"default-org"`
-	ServerURL          string `json:"server-url"`
-	SCMType            string `json:"scm-type"`
-	GitToken           string `json:"git-token"`
-	APISecretName      string `json:"api-token-secret-name"`
-	APISecretKey       string `json:"api-token-secret-key"`
-	APISecretNamespace string `json:"api-token-secret-namespace"`
+	Timeout                       string `json:"fetch-timeout"`
+	URL                           string `json:"default-url"`
+	Revision                      string `json:"default-revision"`
+	Org                           string `json:"default-org"`
+	ServerURL                     string `json:"server-url"`
+	SCMType                       string `json:"scm-type"`
+	GitToken                      string `json:"git-token"`
+	APISecretName                 string `json:"api-token-secret-name"`
+	APISecretKey                  string `json:"api-token-secret-key"`
+	APISecretNamespace            string `json:"api-token-secret-namespace"`
+	RequireSignature              string `json:"require-signature"`
+	AllowedSignersSecretName      string `json:"allowed-signers-secret-name"`
+	AllowedSignersSecretNamespace string `json:"allowed-signers-secret-namespace"`
 }
 
 func GetGitResolverConfig(ctx context.Context) (GitResolverConfig, error) {
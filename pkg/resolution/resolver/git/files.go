@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// OutputFormatMultiDocument returns the resolved files as a single
+	// multi-document YAML stream.
+	OutputFormatMultiDocument = "multi-document"
+	// OutputFormatList returns the resolved files as the items of a
+	// Kubernetes List.
+	OutputFormatList = "list"
+
+	// maxResolvedFiles limits how many files a single pathInRepo may select.
+	maxResolvedFiles = 100
+)
+
+// repoFile is a file resolved from a repository.
+type repoFile struct {
+	path    string
+	content []byte
+}
+
+// cleanPathInRepo returns pathInRepo relative to the repository root.
+func cleanPathInRepo(pathInRepo string) string {
+	return strings.TrimPrefix(path.Join("/", pathInRepo), "/")
+}
+
+// isGlob returns true if pathInRepo contains any path.Match metacharacters.
+func isGlob(pathInRepo string) bool {
+	return strings.ContainsAny(pathInRepo, `*?[\`)
+}
+
+// listFiles returns the files of revision selected by pathInRepo, which is
+// either a single file, a directory whose YAML files are selected
+// recursively, or a glob as understood by path.Match. It is an error for
+// pathInRepo to select more than maxResolvedFiles files.
+func (repo *repository) listFiles(ctx context.Context, revision, pathInRepo string) ([]string, error) {
+	pattern := cleanPathInRepo(pathInRepo)
+
+	// Only list the part of the tree that can match.
+	prefix := pattern
+	if isGlob(pattern) {
+		prefix = ""
+		for _, segment := range strings.Split(pattern, "/") {
+			if isGlob(segment) {
+				break
+			}
+			prefix = path.Join(prefix, segment)
+		}
+	}
+
+	args := []string{"-r", "--name-only", "-z", revision}
+	if prefix != "" {
+		args = append(args, "--", prefix)
+	}
+	out, err := repo.execGit(ctx, "ls-tree", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, name := range strings.Split(string(out), "\x00") {
+		if name == "" {
+			continue
+		}
+		switch {
+		case isGlob(pattern):
+			if matched, _ := path.Match(pattern, name); matched {
+				files = append(files, name)
+			}
+		case name == pattern:
+			return []string{name}, nil
+		case (pattern == "" || strings.HasPrefix(name, pattern+"/")) && isYAML(name):
+			files = append(files, name)
+		}
+		if len(files) > maxResolvedFiles {
+			return nil, fmt.Errorf("path %q selects more than %d files", pathInRepo, maxResolvedFiles)
+		}
+	}
+	return files, nil
+}
+
+// sparsePattern returns the sparse-checkout pattern matching exactly the file
+// at name, escaping the characters that gitignore patterns treat specially.
+func sparsePattern(name string) string {
+	var b strings.Builder
+	b.WriteString("/")
+	for _, c := range name {
+		if strings.ContainsRune(`\*?[ `, c) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+func isYAML(name string) bool {
+	ext := path.Ext(name)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// isMultiFile returns true if files must be rendered with renderFiles rather
+// than returning the content of the single file at pathInRepo as is.
+func isMultiFile(files []repoFile, pathInRepo, outputFormat string) bool {
+	return outputFormat != "" || len(files) != 1 || files[0].path != cleanPathInRepo(pathInRepo)
+}
+
+// renderFiles combines the content of files into a multi-document YAML stream
+// or, for OutputFormatList, into a Kubernetes List.
+func renderFiles(files []repoFile, outputFormat string) ([]byte, error) {
+	if outputFormat == OutputFormatList {
+		return renderList(files)
+	}
+
+	var buf bytes.Buffer
+	for i, f := range files {
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(f.content)
+		if len(f.content) > 0 && !bytes.HasSuffix(f.content, []byte("\n")) {
+			buf.WriteString("\n")
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func renderList(files []repoFile) ([]byte, error) {
+	items := []json.RawMessage{}
+	for _, f := range files {
+		decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(f.content), 4096)
+		for {
+			var item json.RawMessage
+			if err := decoder.Decode(&item); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return nil, fmt.Errorf("error parsing file %q: %w", f.path, err)
+			}
+			// Empty documents, e.g. after a trailing separator, are skipped.
+			if len(item) == 0 || string(item) == "null" {
+				continue
+			}
+			items = append(items, item)
+		}
+	}
+
+	list, err := json.Marshal(map[string]any{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items":      items,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return yaml.JSONToYAML(list)
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+func TestListFiles(t *testing.T) {
+	repoPath, revisions := createTestRepo(t, []commitForRepo{
+		{Dir: "pipelines", Filename: "build.yaml", Content: "kind: Pipeline"},
+		{Dir: "pipelines/tasks", Filename: "clone.yaml", Content: "kind: Task"},
+		{Dir: "pipelines/tasks", Filename: "test.yml", Content: "kind: Task"},
+		{Dir: "pipelines", Filename: "README.md", Content: "docs"},
+	})
+	repo := &repository{directory: repoPath}
+	revision := revisions[len(revisions)-1]
+
+	tests := []struct {
+		name       string
+		pathInRepo string
+		expected   []string
+	}{
+		{name: "single file", pathInRepo: "pipelines/build.yaml", expected: []string{"pipelines/build.yaml"}},
+		{name: "single non-yaml file", pathInRepo: "./pipelines/README.md", expected: []string{"pipelines/README.md"}},
+		{name: "directory selects yaml files recursively", pathInRepo: "pipelines", expected: []string{"pipelines/build.yaml", "pipelines/tasks/clone.yaml", "pipelines/tasks/test.yml"}},
+		{name: "directory with trailing slash", pathInRepo: "pipelines/tasks/", expected: []string{"pipelines/tasks/clone.yaml", "pipelines/tasks/test.yml"}},
+		{name: "glob", pathInRepo: "pipelines/*/*.yaml", expected: []string{"pipelines/tasks/clone.yaml"}},
+		{name: "glob does not cross directories", pathInRepo: "pipelines/*.yaml", expected: []string{"pipelines/build.yaml"}},
+		{name: "no match", pathInRepo: "missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := repo.listFiles(t.Context(), revision, tt.pathInRepo)
+			if err != nil {
+				t.Fatalf("listFiles() returned error: %v", err)
+			}
+			if d := cmp.Diff(tt.expected, files); d != "" {
+				t.Errorf("listFiles() mismatch (-want +got): %s", d)
+			}
+		})
+	}
+}
+
+func TestListFilesLimit(t *testing.T) {
+	commits := make([]commitForRepo, 0, maxResolvedFiles+1)
+	for i := range maxResolvedFiles + 1 {
+		commits = append(commits, commitForRepo{Dir: "tasks", Filename: fmt.Sprintf("task-%d.yaml", i), Content: "kind: Task"})
+	}
+	repoPath, revisions := createTestRepo(t, commits)
+	repo := &repository{directory: repoPath}
+
+	_, err := repo.listFiles(t.Context(), revisions[len(revisions)-1], "tasks")
+	if err == nil || !strings.Contains(err.Error(), "selects more than") {
+		t.Errorf("Expected an error for too many files, got %v", err)
+	}
+}
+
+func TestSparsePattern(t *testing.T) {
+	tests := map[string]string{
+		"tasks/task.yaml":      "/tasks/task.yaml",
+		"tasks/[draft] *.yaml": `/tasks/\[draft]\ \*.yaml`,
+		`tasks/a\b?.yaml`:      `/tasks/a\\b\?.yaml`,
+	}
+	for name, expected := range tests {
+		if got := sparsePattern(name); got != expected {
+			t.Errorf("sparsePattern(%q) = %q, want %q", name, got, expected)
+		}
+	}
+}
+
+func TestRenderFiles(t *testing.T) {
+	files := []repoFile{
+		{path: "pipeline.yaml", content: []byte("kind: Pipeline\nmetadata:\n  name: build")},
+		{path: "tasks.yaml", content: []byte("kind: Task\nmetadata:\n  name: clone\n---\nkind: Task\nmetadata:\n  name: test\n---\n")},
+	}
+
+	tests := []struct {
+		name         string
+		outputFormat string
+		expected     string
+	}{
+		{
+			name:         "multi-document",
+			outputFormat: OutputFormatMultiDocument,
+			expected: `kind: Pipeline
+metadata:
+  name: build
+---
+kind: Task
+metadata:
+  name: clone
+---
+kind: Task
+metadata:
+  name: test
+---
+`,
+		},
+		{
+			name:         "list",
+			outputFormat: OutputFormatList,
+			expected: `apiVersion: v1
+items:
+- kind: Pipeline
+  metadata:
+    name: build
+- kind: Task
+  metadata:
+    name: clone
+- kind: Task
+  metadata:
+    name: test
+kind: List
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := renderFiles(files, tt.outputFormat)
+			if err != nil {
+				t.Fatalf("renderFiles() returned error: %v", err)
+			}
+			if d := cmp.Diff(tt.expected, string(content)); d != "" {
+				t.Errorf("renderFiles() mismatch (-want +got): %s", d)
+			}
+		})
+	}
+}
+
+func TestResolveGitCloneMultipleFiles(t *testing.T) {
+	repoPath, revisions := createTestRepo(t, []commitForRepo{
+		{Dir: "pipelines", Filename: "build.yaml", Content: "kind: Pipeline"},
+		{Dir: "pipelines", Filename: "clone.yaml", Content: "kind: Task"},
+		{Dir: "pipelines", Filename: "deploy [draft].yaml", Content: "kind: Task"},
+	})
+
+	g := &GitResolver{Params: map[string]string{
+		UrlParam:      repoPath,
+		RevisionParam: "main",
+		PathParam:     "pipelines",
+	}}
+	resource, err := g.ResolveGitClone(t.Context())
+	if err != nil {
+		t.Fatalf("ResolveGitClone() returned error: %v", err)
+	}
+
+	if d := cmp.Diff("kind: Pipeline\n---\nkind: Task\n---\nkind: Task\n", string(resource.Data())); d != "" {
+		t.Errorf("Data mismatch (-want +got): %s", d)
+	}
+	expectedRefSource := &pipelinev1.RefSource{
+		URI:        "git+" + repoPath,
+		Digest:     map[string]string{"sha1": revisions[2]},
+		EntryPoint: `["pipelines/build.yaml","pipelines/clone.yaml","pipelines/deploy [draft].yaml"]`,
+	}
+	if d := cmp.Diff(expectedRefSource, resource.RefSource()); d != "" {
+		t.Errorf("RefSource mismatch (-want +got): %s", d)
+	}
+}
@@ -0,0 +1,278 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// defaultMirrorMaxBytes is the default disk budget shared by all mirrors.
+const defaultMirrorMaxBytes = 2 * 1024 * 1024 * 1024
+
+var (
+	mirrorStoresMu sync.Mutex
+	// mirrorStores holds one store per mirror root so that mirrors are shared
+	// by all resolution requests, which each create their own GitResolver.
+	mirrorStores = map[string]*mirrorStore{}
+)
+
+// mirrorStore maintains a bare, partial (blob-less) mirror per repository URL
+// below root. Mirrors are fetched incrementally and the least recently used
+// ones are removed when their total size exceeds maxBytes.
+type mirrorStore struct {
+	mu       sync.Mutex
+	root     string
+	maxBytes int64
+	mirrors  map[string]*mirror
+}
+
+// mirror is a single bare repository. Its lock serializes the git commands
+// run against it, since they share FETCH_HEAD. size and lastUsed are guarded
+// by the lock of the mirrorStore.
+type mirror struct {
+	mu       sync.Mutex
+	dir      string
+	size     int64
+	lastUsed time.Time
+}
+
+// getMirrorStore returns the mirror store configured in the git resolver
+// ConfigMap, or nil if mirrors are disabled.
+func getMirrorStore(ctx context.Context) (*mirrorStore, error) {
+	conf := framework.GetResolverConfigFromContext(ctx)
+	root := conf[MirrorPathKey]
+	if root == "" {
+		return nil, nil
+	}
+
+	maxBytes := int64(defaultMirrorMaxBytes)
+	if maxBytesStr := conf[MirrorMaxBytesKey]; maxBytesStr != "" {
+		parsed, err := resource.ParseQuantity(maxBytesStr)
+		if err != nil || parsed.Value() <= 0 {
+			return nil, fmt.Errorf("invalid %s %q in git resolver configmap", MirrorMaxBytesKey, maxBytesStr)
+		}
+		maxBytes = parsed.Value()
+	}
+
+	mirrorStoresMu.Lock()
+	defer mirrorStoresMu.Unlock()
+
+	store, ok := mirrorStores[root]
+	if !ok {
+		var err error
+		if store, err = newMirrorStore(root); err != nil {
+			return nil, err
+		}
+		mirrorStores[root] = store
+	}
+	store.mu.Lock()
+	store.maxBytes = maxBytes
+	store.mu.Unlock()
+	return store, nil
+}
+
+// newMirrorStore creates a store rooted at root, picking up mirrors left
+// behind by a previous process.
+func newMirrorStore(root string) (*mirrorStore, error) {
+	if err := os.MkdirAll(root, 0o700); err != nil {
+		return nil, fmt.Errorf("creating git mirror directory: %w", err)
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("reading git mirror directory: %w", err)
+	}
+
+	store := &mirrorStore{root: root, mirrors: map[string]*mirror{}}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		m := &mirror{dir: filepath.Join(root, e.Name())}
+		m.size = dirSize(m.dir)
+		if info, err := e.Info(); err == nil {
+			m.lastUsed = info.ModTime()
+		}
+		store.mirrors[e.Name()] = m
+	}
+	return store, nil
+}
+
+// resolve fetches revision from the remote into its mirror and returns the
+// full commit SHA along with the files selected by pathInRepo at that commit. The fetch
+// always runs with the credentials of the remote, so content fetched earlier
+// for another request is only returned if this request may fetch it too.
+// The signature of revision is verified unless signers is nil.
+func (s *mirrorStore) resolve(ctx context.Context, r remote, revision, pathInRepo string, signers *allowedSigners) (string, []repoFile, error) {
+	m := s.get(r.url)
+	m.mu.Lock()
+	defer func() {
+		size, now := dirSize(m.dir), time.Now()
+		_ = os.Chtimes(m.dir, now, now)
+		s.mu.Lock()
+		m.size, m.lastUsed = size, now
+		s.mu.Unlock()
+		m.mu.Unlock()
+		s.gc()
+	}()
+
+	repo := &repository{
+		url:       r.url,
+		username:  r.username,
+		password:  r.password,
+		directory: m.dir,
+		executor:  r.cmdExecutor,
+	}
+
+	if _, err := os.Stat(filepath.Join(m.dir, "config")); errors.Is(err, fs.ErrNotExist) {
+		if err := initMirror(ctx, repo); err != nil {
+			_ = os.RemoveAll(m.dir)
+			return "", nil, fmt.Errorf("creating git mirror: %w", err)
+		}
+	}
+
+	if _, err := repo.execGit(ctx, "fetch", "--filter=blob:none", "--depth=1", "origin", revision); err != nil {
+		if strings.Contains(err.Error(), "could not read Username") {
+			err = errors.New("fetch error: authentication required")
+		}
+		return "", nil, err
+	}
+
+	if signers != nil {
+		if err := repo.verifySignature(ctx, "FETCH_HEAD", signers); err != nil {
+			return "", nil, common.NewError(common.ReasonSignatureVerificationFailed, err)
+		}
+	}
+
+	// Annotated tags are peeled to the commit they point to.
+	fullRevision, err := repo.execGit(ctx, "rev-parse", "FETCH_HEAD^{commit}")
+	if err != nil {
+		return "", nil, err
+	}
+	sha := strings.TrimSpace(string(fullRevision))
+
+	paths, err := repo.listFiles(ctx, sha, pathInRepo)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(paths) == 0 {
+		return "", nil, fmt.Errorf("error opening file %q: %w", pathInRepo, errFileNotFound)
+	}
+
+	files := make([]repoFile, 0, len(paths))
+	for _, p := range paths {
+		// Reading the blob lazily fetches it from the promisor remote.
+		content, err := repo.execGit(ctx, "cat-file", "blob", sha+":"+p)
+		if err != nil {
+			return "", nil, err
+		}
+		files = append(files, repoFile{path: p, content: content})
+	}
+	return sha, files, nil
+}
+
+func initMirror(ctx context.Context, repo *repository) error {
+	if err := os.MkdirAll(repo.directory, 0o700); err != nil {
+		return err
+	}
+	if _, err := repo.execGit(ctx, "init", "--bare", "--quiet"); err != nil {
+		return err
+	}
+	_, err := repo.execGit(ctx, "remote", "add", "origin", repo.url)
+	return err
+}
+
+// get returns the mirror of url, creating its entry if needed.
+func (s *mirrorStore) get(url string) *mirror {
+	sum := sha256.Sum256([]byte(url))
+	name := hex.EncodeToString(sum[:])
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.mirrors[name]
+	if !ok {
+		m = &mirror{dir: filepath.Join(s.root, name)}
+		s.mirrors[name] = m
+	}
+	return m
+}
+
+// gc removes the least recently used mirrors until the total size of all
+// mirrors is within maxBytes. Mirrors that are in use are skipped.
+func (s *mirrorStore) gc() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.mirrors))
+	var total int64
+	for name, m := range s.mirrors {
+		names = append(names, name)
+		total += m.size
+	}
+	if total <= s.maxBytes {
+		return
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return s.mirrors[names[i]].lastUsed.Before(s.mirrors[names[j]].lastUsed)
+	})
+	for _, name := range names {
+		if total <= s.maxBytes {
+			return
+		}
+		m := s.mirrors[name]
+		if !m.mu.TryLock() {
+			continue
+		}
+		// The entry is kept so that a request already holding this mirror
+		// recreates it in place.
+		if err := os.RemoveAll(m.dir); err == nil {
+			total -= m.size
+			m.size = 0
+		}
+		m.mu.Unlock()
+	}
+}
+
+func dirSize(dir string) int64 {
+	var size int64
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil //nolint:nilerr // unreadable entries are not counted
+		}
+		if info, err := d.Info(); err == nil && !d.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+)
+
+func TestMirrorResolve(t *testing.T) {
+	repoPath, revisions := createTestRepo(t, []commitForRepo{
+		{Dir: "tasks", Filename: "task.yaml", Content: "first", Tag: "v1"},
+	})
+
+	store, err := newMirrorStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("newMirrorStore() returned error: %v", err)
+	}
+	store.maxBytes = defaultMirrorMaxBytes
+	r := remote{url: repoPath}
+
+	revision, files, err := store.resolve(t.Context(), r, "main", "./tasks/task.yaml", nil)
+	if err != nil {
+		t.Fatalf("resolve() returned error: %v", err)
+	}
+	if revision != revisions[0] || string(files[0].content) != "first" {
+		t.Errorf("resolve() = (%q, %q), want (%q, %q)", revision, files[0].content, revisions[0], "first")
+	}
+
+	// A new commit is fetched incrementally into the existing mirror.
+	newRevision := writeAndCommitToTestRepo(t, repoPath, "tasks", "task.yaml", []byte("second"))
+	revision, files, err = store.resolve(t.Context(), r, "main", "tasks/task.yaml", nil)
+	if err != nil {
+		t.Fatalf("resolve() returned error: %v", err)
+	}
+	if revision != newRevision || string(files[0].content) != "second" {
+		t.Errorf("resolve() = (%q, %q), want (%q, %q)", revision, files[0].content, newRevision, "second")
+	}
+
+	// Older revisions remain resolvable by tag.
+	revision, files, err = store.resolve(t.Context(), r, "v1", "tasks/task.yaml", nil)
+	if err != nil {
+		t.Fatalf("resolve() returned error: %v", err)
+	}
+	if revision != revisions[0] || string(files[0].content) != "first" {
+		t.Errorf("resolve() = (%q, %q), want (%q, %q)", revision, files[0].content, revisions[0], "first")
+	}
+
+	if len(store.mirrors) != 1 {
+		t.Errorf("Expected a single mirror for the repository, got %d", len(store.mirrors))
+	}
+
+	if _, _, err := store.resolve(t.Context(), r, "main", "missing.yaml", nil); !errors.Is(err, errFileNotFound) {
+		t.Errorf("Expected %q error for a missing file, got %v", errFileNotFound, err)
+	}
+}
+
+func TestMirrorGC(t *testing.T) {
+	repoA, _ := createTestRepo(t, []commitForRepo{{Filename: "a.yaml", Content: "a"}})
+	repoB, _ := createTestRepo(t, []commitForRepo{{Filename: "b.yaml", Content: "b"}})
+
+	store, err := newMirrorStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("newMirrorStore() returned error: %v", err)
+	}
+	store.maxBytes = defaultMirrorMaxBytes
+
+	if _, _, err := store.resolve(t.Context(), remote{url: repoA}, "main", "a.yaml", nil); err != nil {
+		t.Fatalf("resolve() returned error: %v", err)
+	}
+	mirrorA := store.get(repoA)
+	if _, _, err := store.resolve(t.Context(), remote{url: repoB}, "main", "b.yaml", nil); err != nil {
+		t.Fatalf("resolve() returned error: %v", err)
+	}
+
+	// Shrinking the budget to the size of the most recently used mirror
+	// evicts the other one.
+	store.maxBytes = store.get(repoB).size
+	store.gc()
+
+	if _, err := os.Stat(mirrorA.dir); !os.IsNotExist(err) {
+		t.Errorf("Expected mirror of %s to be removed, got %v", repoA, err)
+	}
+	if _, err := os.Stat(store.get(repoB).dir); err != nil {
+		t.Errorf("Expected mirror of %s to be kept, got %v", repoB, err)
+	}
+}
+
+func TestGetMirrorStore(t *testing.T) {
+	root := t.TempDir()
+	tests := []struct {
+		name        string
+		conf        map[string]string
+		expectStore bool
+		expectErr   bool
+	}{
+		{name: "mirrors disabled by default", conf: map[string]string{}},
+		{name: "mirror path", conf: map[string]string{MirrorPathKey: root}, expectStore: true},
+		{name: "mirror path and budget", conf: map[string]string{MirrorPathKey: root, MirrorMaxBytesKey: "1Gi"}, expectStore: true},
+		{name: "invalid budget", conf: map[string]string{MirrorPathKey: root, MirrorMaxBytesKey: "lots"}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := framework.InjectResolverConfigToContext(t.Context(), tt.conf)
+			store, err := getMirrorStore(ctx)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("getMirrorStore() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if (store != nil) != tt.expectStore {
+				t.Errorf("getMirrorStore() = %v, expectStore %v", store, tt.expectStore)
+			}
+		})
+	}
+}
@@ -26,7 +26,11 @@ const (
 	// RepoParam is the repository to use when using the SCM API approach
 	RepoParam = "repo"
 	// PathParam is the pathInRepo into the git repo where a file is located. This is used with both approaches.
+	// When cloning it can also be a directory or a glob selecting multiple files.
 	PathParam string = "pathInRepo"
+	// OutputFormatParam is an optional string selecting how multiple files selected by PathParam are returned
+	// when cloning: "multi-document" (default) or "list"
+	OutputFormatParam string = "outputFormat"
 	// RevisionParam is the git revision that a file should be fetched from. This is used with both approaches.
 	RevisionParam string = "revision"
 	// TokenParam is an optional reference to a secret name for SCM API authentication
@@ -43,6 +47,9 @@ const (
 	ScmTypeParam string = "scmType"
 	// serverURLParam is an optional string to the server URL for the SCM API to connect to
 	ServerURLParam string = "serverURL"
+	// VerifySignatureParam is an optional string which, when "true", requires the resolved commit or tag
+	// to be signed by one of the allowed signers configured in the git resolver configmap
+	VerifySignatureParam string = "verifySignature"
 	// ConfigKeyParam is an optional string to provid which scm configuration to use from git resolver configmap
 	ConfigKeyParam string = "configKey"
 )
@@ -25,8 +25,13 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/resolution/common"
 )
 
+// errFileNotFound is returned when the requested file is not in the repository.
+var errFileNotFound = common.NewError(common.ReasonResourceNotFound, errors.New("file does not exist"))
+
 type cmdExecutor = func(context.Context, string, ...string) *exec.Cmd
 
 type remote struct {
@@ -36,6 +41,9 @@ type remote struct {
 	cmdExecutor cmdExecutor
 }
 
+// clone makes a shallow, partial (blob-less) clone of the remote without
+// checking out any file. If the partial clone fails, for example because the
+// server does not support filters, it falls back to a plain shallow clone.
 func (r remote) clone(ctx context.Context) (*repository, func(), error) {
 	urlParts := strings.Split(r.url, "/")
 	repoName := urlParts[len(urlParts)-1]
@@ -55,9 +63,26 @@ func (r remote) clone(ctx context.Context) (*repository, func(), error) {
 		executor:  r.cmdExecutor,
 	}
 
+	_, err = repo.execGit(ctx, "clone", repo.url, tmpDir, "--depth=1", "--no-checkout", "--filter=blob:none")
+	if err == nil {
+		repo.partial = true
+		return repo, cleanupFunc, nil
+	}
+	if isAuthError(err) {
+		return nil, cleanupFunc, errors.New("clone error: authentication required")
+	}
+
+	// The failed clone may have left files behind, and git refuses to clone
+	// into a non-empty directory.
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return nil, cleanupFunc, err
+	}
+	if err := os.Mkdir(tmpDir, 0o700); err != nil {
+		return nil, cleanupFunc, err
+	}
 	_, err = repo.execGit(ctx, "clone", repo.url, tmpDir, "--depth=1", "--no-checkout")
 	if err != nil {
-		if strings.Contains(err.Error(), "could not read Username") {
+		if isAuthError(err) {
 			err = errors.New("clone error: authentication required")
 		}
 		return nil, cleanupFunc, err
@@ -65,12 +90,21 @@ func (r remote) clone(ctx context.Context) (*repository, func(), error) {
 	return repo, cleanupFunc, nil
 }
 
+func isAuthError(err error) bool {
+	return strings.Contains(err.Error(), "could not read Username")
+}
+
 type repository struct {
 	url       string
 	username  string
 	password  string
 	directory string
 	executor  cmdExecutor
+	// env holds additional environment variables for git commands.
+	env []string
+	// partial is true if the repository is a blob-less clone, whose blobs
+	// are fetched on checkout.
+	partial bool
 }
 
 func (repo *repository) currentRevision(ctx context.Context) (string, error) {
@@ -81,13 +115,40 @@ func (repo *repository) currentRevision(ctx context.Context) (string, error) {
 	return strings.TrimSpace(string(revisionSha)), nil
 }
 
-func (repo *repository) checkout(ctx context.Context, revision string) error {
+// sparseCheckout limits the files written by subsequent checkouts to files,
+// so that only the blobs of the requested files are fetched from a partial
+// clone. The files are matched literally, since they were already selected
+// by listFiles.
+func (repo *repository) sparseCheckout(ctx context.Context, files []string) error {
+	args := []string{"set", "--no-cone"}
+	for _, f := range files {
+		args = append(args, sparsePattern(f))
+	}
+	_, err := repo.execGit(ctx, "sparse-checkout", args...)
+	return err
+}
+
+func (repo *repository) fetch(ctx context.Context, revision string) error {
 	_, err := repo.execGit(ctx, "fetch", "origin", revision, "--depth=1")
+	return err
+}
+
+// fetchedRevision returns the full SHA of the commit fetched last. Annotated
+// tags are peeled to the commit they point to.
+func (repo *repository) fetchedRevision(ctx context.Context) (string, error) {
+	revisionSha, err := repo.execGit(ctx, "rev-parse", "FETCH_HEAD^{commit}")
 	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(revisionSha)), nil
+}
+
+func (repo *repository) checkout(ctx context.Context, revision string) error {
+	if err := repo.fetch(ctx, revision); err != nil {
 		return err
 	}
 
-	_, err = repo.execGit(ctx, "checkout", "FETCH_HEAD")
+	_, err := repo.execGit(ctx, "checkout", "FETCH_HEAD")
 	if err != nil {
 		return err
 	}
@@ -120,7 +181,7 @@ func (repo *repository) execGit(ctx context.Context, subCmd string, args ...stri
 	}
 
 	cmd := repo.executor(ctx, "git", append(configArgs, args...)...)
-	cmd.Env = append(cmd.Environ(), env...)
+	cmd.Env = append(append(cmd.Environ(), env...), repo.env...)
 
 	out, err := cmd.Output()
 	if err != nil {
@@ -141,7 +202,7 @@ func (repo *repository) getFileContent(path string) ([]byte, error) {
 	fileContents, err := os.ReadFile(filepath.Join(repo.directory, path))
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return nil, errors.New("file does not exist")
+			return nil, errFileNotFound
 		}
 		return nil, err
 	}
@@ -73,7 +73,7 @@ func TestClone(t *testing.T) {
 				expectedCmd = append(expectedCmd, "--config-env", "http.extraHeader=GIT_AUTH_HEADER")
 				expectedEnv = append(expectedEnv, "GIT_AUTH_HEADER=Authorization: Basic "+token)
 			}
-			expectedCmd = append(expectedCmd, "clone", test.url, repo.directory, "--depth=1", "--no-checkout")
+			expectedCmd = append(expectedCmd, "clone", test.url, repo.directory, "--depth=1", "--no-checkout", "--filter=blob:none")
 
 			if len(executions) != 1 {
 				t.Fatalf("Expected 1 command execution during cloning, got %d: %v", len(executions), executions)
@@ -170,3 +170,55 @@ func TestCheckout(t *testing.T) {
 		})
 	}
 }
+
+func TestCloneFallsBackToShallowClone(t *testing.T) {
+	executions := [][]string{}
+	executor := func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		executions = append(executions, args)
+		if slices.Contains(args, "--filter=blob:none") {
+			return exec.CommandContext(ctx, "sh", "-c", "echo 'fatal: filtering not recognized by server' >&2; exit 128")
+		}
+		return exec.CommandContext(ctx, "echo", append([]string{name}, args...)...)
+	}
+
+	repo, cleanup, err := remote{url: "https://github.com/tektoncd/pipeline", cmdExecutor: executor}.clone(t.Context())
+	defer cleanup()
+	if err != nil {
+		t.Fatalf("Error cloning repository: %v", err)
+	}
+	if repo.partial {
+		t.Error("Expected the fallback clone not to be partial")
+	}
+
+	if len(executions) != 2 {
+		t.Fatalf("Expected 2 command executions during cloning, got %d: %v", len(executions), executions)
+	}
+	expectedArgs := []string{"-C", repo.directory, "clone", "https://github.com/tektoncd/pipeline", repo.directory, "--depth=1", "--no-checkout"}
+	if !reflect.DeepEqual(executions[1], expectedArgs) {
+		t.Fatalf("Expected fallback clone command to be %v but got %v", expectedArgs, executions[1])
+	}
+}
+
+func TestResolveFromCloneWithoutSparseCheckout(t *testing.T) {
+	repoPath, revisions := createTestRepo(t, []commitForRepo{
+		{Filename: "task.yaml", Content: "kind: Task"},
+		{Filename: "pipeline.yaml", Content: "kind: Pipeline"},
+	})
+	executor := func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		if slices.Contains(args, "sparse-checkout") {
+			return exec.CommandContext(ctx, "sh", "-c", "echo 'git: sparse-checkout is not a git command' >&2; exit 1")
+		}
+		return exec.CommandContext(ctx, name, args...)
+	}
+
+	revision, files, err := resolveFromClone(t.Context(), remote{url: repoPath, cmdExecutor: executor}, "main", "task.yaml", nil)
+	if err != nil {
+		t.Fatalf("resolveFromClone() returned error: %v", err)
+	}
+	if revision != revisions[1] {
+		t.Errorf("Expected revision %q but got %q", revisions[1], revision)
+	}
+	if len(files) != 1 || files[0].path != "task.yaml" || string(files[0].content) != "kind: Task" {
+		t.Errorf("Expected task.yaml to be resolved, got %v", files)
+	}
+}
@@ -18,6 +18,7 @@ package git
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -219,36 +220,114 @@ func (g *GitResolver) ResolveGitClone(ctx context.Context) (framework.ResolvedRe
 	}
 
 	path := g.Params[PathParam]
+	outputFormat := g.Params[OutputFormatParam]
+	r := remote{url: repoURL, username: username, password: password}
 
-	repo, cleanupFunc, err := remote{url: repoURL, username: username, password: password}.clone(ctx)
-	defer cleanupFunc()
+	signers, err := g.getAllowedSigners(ctx, conf)
 	if err != nil {
-		return nil, fmt.Errorf("error resolving repository: %w", err)
+		return nil, common.NewError(common.ReasonSignatureVerificationFailed, err)
 	}
 
-	err = repo.checkout(ctx, revision)
+	mirrors, err := getMirrorStore(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	fullRevision, err := repo.currentRevision(ctx)
+	var fullRevision string
+	var files []repoFile
+	if mirrors != nil {
+		fullRevision, files, err = mirrors.resolve(ctx, r, revision, path, signers)
+	} else {
+		fullRevision, files, err = resolveFromClone(ctx, r, revision, path, signers)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	fileContents, err := repo.getFileContent(path)
-	if err != nil {
-		return nil, fmt.Errorf("error opening file %q: %w", path, err)
+	if !isMultiFile(files, path, outputFormat) {
+		return &resolvedGitResource{
+			Revision: fullRevision,
+			Content:  files[0].content,
+			URL:      repoURL,
+			Path:     path,
+		}, nil
 	}
 
+	content, err := renderFiles(files, outputFormat)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		paths = append(paths, f.path)
+	}
 	return &resolvedGitResource{
 		Revision: fullRevision,
-		Content:  fileContents,
-		URL:      repo.url,
+		Content:  content,
+		URL:      repoURL,
 		Path:     path,
+		Paths:    paths,
 	}, nil
 }
 
+// resolveFromClone returns the full commit SHA of revision and the files
+// selected by pathInRepo at that commit using a temporary partial clone. The
+// files are listed from the fetched tree and only they are checked out, unless
+// the clone is not partial or git cannot check out a subset of the files, in
+// which case the whole tree is checked out. The signature of revision is
+// verified unless signers is nil.
+func resolveFromClone(ctx context.Context, r remote, revision, pathInRepo string, signers *allowedSigners) (string, []repoFile, error) {
+	repo, cleanupFunc, err := r.clone(ctx)
+	defer cleanupFunc()
+	if err != nil {
+		return "", nil, fmt.Errorf("error resolving repository: %w", err)
+	}
+
+	if err := repo.fetch(ctx, revision); err != nil {
+		return "", nil, err
+	}
+
+	if signers != nil {
+		if err := repo.verifySignature(ctx, "FETCH_HEAD", signers); err != nil {
+			return "", nil, common.NewError(common.ReasonSignatureVerificationFailed, err)
+		}
+	}
+
+	fullRevision, err := repo.fetchedRevision(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	paths, err := repo.listFiles(ctx, fullRevision, pathInRepo)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(paths) == 0 {
+		return "", nil, fmt.Errorf("error opening file %q: %w", pathInRepo, errFileNotFound)
+	}
+
+	if repo.partial {
+		if err := repo.sparseCheckout(ctx, paths); err != nil {
+			logging.FromContext(ctx).Infof("Checking out the whole tree of %s, sparse checkout failed: %v", r.url, err)
+			// Do not leave a half-configured sparse checkout behind.
+			_, _ = repo.execGit(ctx, "config", "core.sparseCheckout", "false")
+		}
+	}
+	if _, err := repo.execGit(ctx, "checkout", fullRevision); err != nil {
+		return "", nil, err
+	}
+
+	files := make([]repoFile, 0, len(paths))
+	for _, p := range paths {
+		fileContents, err := repo.getFileContent(p)
+		if err != nil {
+			return "", nil, fmt.Errorf("error opening file %q: %w", p, err)
+		}
+		files = append(files, repoFile{path: p, content: fileContents})
+	}
+	return fullRevision, files, nil
+}
+
 // ResolveAPIGit resolves a git resource using the SCM API.
 func (g *GitResolver) ResolveAPIGit(ctx context.Context, clientFunc func(string, string, string, ...factory.ClientOptionFunc) (*scm.Client, error)) (framework.ResolvedResource, error) {
 	if g.ResolveAPIGitFunc != nil {
@@ -394,6 +473,28 @@ func PopulateDefaultParams(ctx context.Context, params []pipelinev1.Param) (map[
 		return nil, fmt.Errorf("missing required git resolver params: %s", strings.Join(missingParams, ", "))
 	}
 
+	switch paramsMap[OutputFormatParam] {
+	case "", OutputFormatMultiDocument, OutputFormatList:
+	default:
+		return nil, fmt.Errorf("invalid '%s' %q, must be one of: %s, %s", OutputFormatParam, paramsMap[OutputFormatParam], OutputFormatMultiDocument, OutputFormatList)
+	}
+	if paramsMap[OutputFormatParam] != "" && paramsMap[RepoParam] != "" {
+		return nil, fmt.Errorf("'%s' is only supported with '%s'", OutputFormatParam, UrlParam)
+	}
+	// The SCM API can only fetch the content of a single file.
+	if pathInRepo := paramsMap[PathParam]; paramsMap[RepoParam] != "" && (isGlob(pathInRepo) || strings.HasSuffix(pathInRepo, "/") || cleanPathInRepo(pathInRepo) == "") {
+		return nil, fmt.Errorf("'%s' %q must be a single file when '%s' is specified", PathParam, pathInRepo, RepoParam)
+	}
+
+	switch paramsMap[VerifySignatureParam] {
+	case "", "true", "false":
+	default:
+		return nil, fmt.Errorf("invalid '%s' %q, must be true or false", VerifySignatureParam, paramsMap[VerifySignatureParam])
+	}
+	if (paramsMap[VerifySignatureParam] == "true" || conf.RequireSignature == "true") && paramsMap[RepoParam] != "" {
+		return nil, fmt.Errorf("signature verification is only supported with '%s'", UrlParam)
+	}
+
 	// validate the url params if we are not using the SCM API
 	if paramsMap[RepoParam] == "" && paramsMap[OrgParam] == "" && !validateRepoURL(paramsMap[UrlParam]) {
 		return nil, fmt.Errorf("invalid git repository url: %s", paramsMap[UrlParam])
@@ -419,6 +520,9 @@ type resolvedGitResource struct {
 	Repo     string
 	Path     string
 	URL      string
+	// Paths lists every file combined into Content when multiple files
+	// were resolved.
+	Paths []string
 }
 
 var _ framework.ResolvedResource = &resolvedGitResource{}
@@ -449,14 +553,21 @@ func (r *resolvedGitResource) Annotations() map[string]string {
 }
 
 // RefSource is the source reference of the remote data that records where the remote
-// file came from including the url, digest and the entrypoint.
+// file came from including the url, digest and the entrypoint. When multiple files
+// were resolved the entrypoint is the JSON array of their paths.
 func (r *resolvedGitResource) RefSource() *pipelinev1.RefSource {
+	entryPoint := r.Path
+	if len(r.Paths) > 0 {
+		// Paths are file names and always marshal successfully.
+		paths, _ := json.Marshal(r.Paths)
+		entryPoint = string(paths)
+	}
 	return &pipelinev1.RefSource{
 		URI: spdxGit(r.URL),
 		Digest: map[string]string{
 			"sha1": r.Revision,
 		},
-		EntryPoint: r.Path,
+		EntryPoint: entryPoint,
 	}
 }
 
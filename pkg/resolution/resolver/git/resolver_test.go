@@ -193,6 +193,43 @@ func TestValidateParams_Failure(t *testing.T) {
 				RepoParam:     "foo",
 			},
 			expectedErr: "'org' is required when 'repo' is specified",
+		}, {
+			name: "invalid verifySignature",
+			params: map[string]string{
+				RevisionParam:        "abcd1234",
+				PathParam:            "/foo/bar",
+				UrlParam:             "http://foo",
+				VerifySignatureParam: "yes",
+			},
+			expectedErr: `invalid 'verifySignature' "yes", must be true or false`,
+		}, {
+			name: "verifySignature with repo",
+			params: map[string]string{
+				RevisionParam:        "abcd1234",
+				PathParam:            "/foo/bar",
+				OrgParam:             "abcd1234",
+				RepoParam:            "foo",
+				VerifySignatureParam: "true",
+			},
+			expectedErr: "signature verification is only supported with 'url'",
+		}, {
+			name: "glob with repo",
+			params: map[string]string{
+				RevisionParam: "abcd1234",
+				PathParam:     "tasks/*.yaml",
+				OrgParam:      "abcd1234",
+				RepoParam:     "foo",
+			},
+			expectedErr: `'pathInRepo' "tasks/*.yaml" must be a single file when 'repo' is specified`,
+		}, {
+			name: "directory with repo",
+			params: map[string]string{
+				RevisionParam: "abcd1234",
+				PathParam:     "tasks/",
+				OrgParam:      "abcd1234",
+				RepoParam:     "foo",
+			},
+			expectedErr: `'pathInRepo' "tasks/" must be a single file when 'repo' is specified`,
 		},
 	}
 
@@ -0,0 +1,194 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AllowedSignersSSHKey is the key in the allowed signers secret holding
+	// an ssh-keygen allowed signers file.
+	AllowedSignersSSHKey = "ssh-allowed-signers"
+	// AllowedSignersGPGKey is the key in the allowed signers secret holding
+	// ASCII-armored GPG public keys.
+	AllowedSignersGPGKey = "gpg-public-keys"
+)
+
+// allowedSigners are the keys trusted to sign resolved commits and tags.
+type allowedSigners struct {
+	ssh []byte
+	gpg []byte
+}
+
+// getAllowedSigners returns the allowed signers if signature verification is
+// required by the git resolver configuration or requested by the params, or
+// nil if the resolved revision does not need to be verified.
+func (g *GitResolver) getAllowedSigners(ctx context.Context, conf ScmConfig) (*allowedSigners, error) {
+	if conf.RequireSignature != "true" && g.Params[VerifySignatureParam] != "true" {
+		return nil, nil
+	}
+	name := conf.AllowedSignersSecretName
+	if name == "" {
+		return nil, fmt.Errorf("cannot verify signature, '%s' not specified in config", AllowedSignersSecretNameKey)
+	}
+	ns := conf.AllowedSignersSecretNamespace
+	if ns == "" {
+		ns = os.Getenv("SYSTEM_NAMESPACE")
+	}
+
+	secret, err := g.KubeClient.CoreV1().Secrets(ns).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error reading allowed signers from secret %s in namespace %s: %w", name, ns, err)
+	}
+	signers := &allowedSigners{
+		ssh: secret.Data[AllowedSignersSSHKey],
+		gpg: secret.Data[AllowedSignersGPGKey],
+	}
+	if len(signers.ssh) == 0 && len(signers.gpg) == 0 {
+		return nil, fmt.Errorf("secret %s in namespace %s has neither a %s nor a %s key", name, ns, AllowedSignersSSHKey, AllowedSignersGPGKey)
+	}
+	return signers, nil
+}
+
+// verifySignature returns an error unless ref, or the commit it points to,
+// carries a valid GPG or SSH signature from one of signers. An annotated tag
+// is accepted if either the tag or its commit is signed.
+func (repo *repository) verifySignature(ctx context.Context, ref string, signers *allowedSigners) error {
+	tmpDir, err := os.MkdirTemp("", "allowed-signers-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// An empty allowed signers file makes git reject SSH signatures with a
+	// clear message rather than complaining about missing configuration.
+	sshSignersFile := filepath.Join(tmpDir, "allowed_signers")
+	if err := os.WriteFile(sshSignersFile, signers.ssh, 0o600); err != nil {
+		return err
+	}
+
+	gnupgHome := filepath.Join(tmpDir, "gnupg")
+	if err := os.Mkdir(gnupgHome, 0o700); err != nil {
+		return err
+	}
+	if len(signers.gpg) > 0 {
+		if err := repo.importGPGKeys(ctx, gnupgHome, signers.gpg); err != nil {
+			return err
+		}
+	}
+
+	verifier := *repo
+	verifier.env = append(verifier.env,
+		"GNUPGHOME="+gnupgHome,
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=gpg.ssh.allowedSignersFile",
+		"GIT_CONFIG_VALUE_0="+sshSignersFile,
+	)
+
+	objectType, err := verifier.execGit(ctx, "cat-file", "-t", ref)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(string(objectType)) == "tag" {
+		if _, err := verifier.execGit(ctx, "verify-tag", ref); err == nil {
+			return nil
+		}
+	}
+
+	commit, err := verifier.execGit(ctx, "rev-parse", ref+"^{commit}")
+	if err != nil {
+		return err
+	}
+	sha := strings.TrimSpace(string(commit))
+	raw, err := verifier.execGit(ctx, "cat-file", "commit", sha)
+	if err != nil {
+		return err
+	}
+	if !isSignedCommit(raw) {
+		return fmt.Errorf("commit %s is not signed", sha)
+	}
+	if _, err := verifier.execGit(ctx, "verify-commit", sha); err != nil {
+		return fmt.Errorf("commit %s does not carry a valid signature from an allowed signer: %w", sha, err)
+	}
+	return nil
+}
+
+// isSignedCommit returns true if the headers of the raw commit object include
+// a signature. Commits in SHA-256 repositories carry it in a gpgsig-sha256
+// header rather than a gpgsig header.
+func isSignedCommit(raw []byte) bool {
+	headers, _, _ := strings.Cut(string(raw), "\n\n")
+	for _, line := range strings.Split(headers, "\n") {
+		// Continuation lines of multi-line headers start with a space.
+		if line == "" || line[0] == ' ' {
+			continue
+		}
+		name, _, _ := strings.Cut(line, " ")
+		if name == "gpgsig" || name == "gpgsig-sha256" {
+			return true
+		}
+	}
+	return false
+}
+
+// digest returns a digest of the allowed signers, which changes whenever a
+// signer is added or removed.
+func (s *allowedSigners) digest() string {
+	h := sha256.New()
+	h.Write(s.ssh)
+	h.Write([]byte{0})
+	h.Write(s.gpg)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// AllowedSignersDigest returns a digest of the signers the resolved revision
+// must be signed by, or an empty string if its signature is not verified.
+// Callers caching resolved resources include it in the cache key, so that
+// resources resolved without verification, or verified against other
+// signers, are not returned once verification is required.
+func (g *GitResolver) AllowedSignersDigest(ctx context.Context) (string, error) {
+	conf, err := GetScmConfigForParamConfigKey(ctx, g.Params)
+	if err != nil {
+		return "", err
+	}
+	signers, err := g.getAllowedSigners(ctx, conf)
+	if err != nil || signers == nil {
+		return "", err
+	}
+	return signers.digest(), nil
+}
+
+func (repo *repository) importGPGKeys(ctx context.Context, gnupgHome string, keys []byte) error {
+	keysFile := filepath.Join(gnupgHome, "allowed-signers.asc")
+	if err := os.WriteFile(keysFile, keys, 0o600); err != nil {
+		return err
+	}
+	cmd := repo.executor(ctx, "gpg", "--batch", "--homedir", gnupgHome, "--import", keysFile)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("error importing allowed GPG keys: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
@@ -0,0 +1,334 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/system"
+)
+
+// createSSHSigningKey generates an SSH key and returns the path of the private
+// key along with an allowed signers entry for it.
+func createSSHSigningKey(t *testing.T, principal string) (string, []byte) {
+	t.Helper()
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen is required to test SSH signatures")
+	}
+	key := filepath.Join(t.TempDir(), "id_ed25519")
+	if out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", principal, "-f", key).CombinedOutput(); err != nil {
+		t.Fatalf("couldn't generate ssh key: %q: %v", out, err)
+	}
+	pub, err := os.ReadFile(key + ".pub")
+	if err != nil {
+		t.Fatalf("couldn't read public key: %v", err)
+	}
+	return key, []byte(principal + " " + string(pub))
+}
+
+// createGPGSigningKey generates a GPG key in a new GNUPGHOME and returns the
+// home directory, the fingerprint of the key and its ASCII-armored public key.
+func createGPGSigningKey(t *testing.T, email string) (string, string, []byte) {
+	t.Helper()
+	if _, err := exec.LookPath("gpg"); err != nil {
+		t.Skip("gpg is required to test GPG signatures")
+	}
+	// The agent socket lives in the home directory, whose path must be short.
+	gnupgHome, err := os.MkdirTemp("", "gnupg-")
+	if err != nil {
+		t.Fatalf("couldn't create GNUPGHOME: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = exec.Command("gpgconf", "--homedir", gnupgHome, "--kill", "gpg-agent").Run()
+		os.RemoveAll(gnupgHome)
+	})
+	gpg := func(args ...string) []byte {
+		t.Helper()
+		out, err := exec.Command("gpg", append([]string{"--batch", "--homedir", gnupgHome}, args...)...).Output()
+		if err != nil {
+			t.Fatalf("gpg %v failed: %v", args, err)
+		}
+		return out
+	}
+
+	gpg("--passphrase", "", "--quick-gen-key", "Tekton <"+email+">", "ed25519", "sign", "never")
+	var fingerprint string
+	for _, line := range strings.Split(string(gpg("--list-secret-keys", "--with-colons")), "\n") {
+		if fields := strings.Split(line, ":"); fields[0] == "fpr" && len(fields) > 9 {
+			fingerprint = fields[9]
+			break
+		}
+	}
+	if fingerprint == "" {
+		t.Fatal("couldn't find the fingerprint of the generated key")
+	}
+	return gnupgHome, fingerprint, gpg("--armor", "--export", fingerprint)
+}
+
+// createSignedTestRepo returns a repository whose main branch has an unsigned
+// commit tagged "unsigned" followed by a commit signed with the signing
+// config tagged "signed-commit", and an annotated tag "signed-tag" signed with
+// the same config which points to the unsigned commit. gnupgHome is the GPG
+// home directory of GPG keys, if any.
+func createSignedTestRepo(t *testing.T, gnupgHome string, signing ...string) string {
+	t.Helper()
+	repoPath, _ := createTestRepo(t, []commitForRepo{
+		{Filename: "task.yaml", Content: "unsigned", Tag: "unsigned"},
+	})
+	getCmd := getGitCmd(t, repoPath)
+	gitCmd := func(args ...string) *exec.Cmd {
+		cmd := getCmd(args...)
+		if gnupgHome != "" {
+			cmd.Env = append(os.Environ(), "GNUPGHOME="+gnupgHome)
+		}
+		return cmd
+	}
+
+	if out, err := gitCmd(append(signing, "tag", "-s", "-m", "signed", "signed-tag", "unsigned")...).CombinedOutput(); err != nil {
+		t.Fatalf("couldn't sign tag: %q: %v", out, err)
+	}
+	if err := os.WriteFile(filepath.Join(repoPath, "task.yaml"), []byte("signed"), 0o600); err != nil {
+		t.Fatalf("couldn't write file: %v", err)
+	}
+	for _, args := range [][]string{
+		{"add", "task.yaml"},
+		append(signing, "commit", "-S", "-m", "signed commit"),
+		{"tag", "signed-commit"},
+	} {
+		if out, err := gitCmd(args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %q: %v", args, out, err)
+		}
+	}
+	return repoPath
+}
+
+// verifySignatureTest resolves revision with signers and expects the
+// resolution to fail with expectedErr, or to succeed if it is empty.
+type verifySignatureTest struct {
+	name        string
+	revision    string
+	signers     *allowedSigners
+	expectedErr string
+}
+
+// testVerifySignature runs tests against repoPath both from a temporary clone
+// and from a mirror.
+func testVerifySignature(t *testing.T, repoPath string, tests []verifySignatureTest) {
+	t.Helper()
+	store, err := newMirrorStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("newMirrorStore() returned error: %v", err)
+	}
+	store.maxBytes = defaultMirrorMaxBytes
+
+	for _, tt := range tests {
+		for name, resolve := range map[string]func() error{
+			"clone": func() error {
+				_, _, err := resolveFromClone(t.Context(), remote{url: repoPath}, tt.revision, "task.yaml", tt.signers)
+				return err
+			},
+			"mirror": func() error {
+				_, _, err := store.resolve(t.Context(), remote{url: repoPath}, tt.revision, "task.yaml", tt.signers)
+				return err
+			},
+		} {
+			t.Run(tt.name+" from "+name, func(t *testing.T) {
+				err := resolve()
+				if tt.expectedErr == "" {
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					return
+				}
+				if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.expectedErr, err)
+				}
+				if reason, _ := common.ReasonError(err); reason != common.ReasonSignatureVerificationFailed {
+					t.Errorf("expected reason %q, got %q", common.ReasonSignatureVerificationFailed, reason)
+				}
+			})
+		}
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	key, allowed := createSSHSigningKey(t, "tekton@example.com")
+	_, otherAllowed := createSSHSigningKey(t, "other@example.com")
+	repoPath := createSignedTestRepo(t, "", "-c", "gpg.format=ssh", "-c", "user.signingkey="+key)
+
+	testVerifySignature(t, repoPath, []verifySignatureTest{
+		{name: "signed commit", revision: "signed-commit", signers: &allowedSigners{ssh: allowed}},
+		{name: "signed tag of unsigned commit", revision: "signed-tag", signers: &allowedSigners{ssh: allowed}},
+		{name: "verification not required", revision: "unsigned"},
+		{name: "unsigned commit", revision: "unsigned", signers: &allowedSigners{ssh: allowed}, expectedErr: "is not signed"},
+		{name: "signer not allowed", revision: "signed-commit", signers: &allowedSigners{ssh: otherAllowed}, expectedErr: "does not carry a valid signature from an allowed signer"},
+		{name: "signed tag by signer not allowed", revision: "signed-tag", signers: &allowedSigners{ssh: otherAllowed}, expectedErr: "is not signed"},
+	})
+}
+
+func TestVerifySignatureGPG(t *testing.T) {
+	gnupgHome, fingerprint, allowed := createGPGSigningKey(t, "tekton@example.com")
+	_, _, otherAllowed := createGPGSigningKey(t, "other@example.com")
+	repoPath := createSignedTestRepo(t, gnupgHome, "-c", "gpg.format=openpgp", "-c", "user.signingkey="+fingerprint)
+
+	testVerifySignature(t, repoPath, []verifySignatureTest{
+		{name: "signed commit", revision: "signed-commit", signers: &allowedSigners{gpg: allowed}},
+		{name: "signed tag of unsigned commit", revision: "signed-tag", signers: &allowedSigners{gpg: allowed}},
+		{name: "unsigned commit", revision: "unsigned", signers: &allowedSigners{gpg: allowed}, expectedErr: "is not signed"},
+		{name: "signer not allowed", revision: "signed-commit", signers: &allowedSigners{gpg: otherAllowed}, expectedErr: "does not carry a valid signature from an allowed signer"},
+		{name: "signed tag by signer not allowed", revision: "signed-tag", signers: &allowedSigners{gpg: otherAllowed}, expectedErr: "is not signed"},
+	})
+}
+
+func TestVerifySignatureSHA256Repository(t *testing.T) {
+	key, allowed := createSSHSigningKey(t, "tekton@example.com")
+	repoPath := t.TempDir()
+	gitCmd := getGitCmd(t, repoPath)
+	if out, err := gitCmd("init", "-b", "main", "--object-format=sha256").CombinedOutput(); err != nil {
+		t.Skipf("SHA-256 repositories are not supported by this git: %q: %v", out, err)
+	}
+	if err := os.WriteFile(filepath.Join(repoPath, "task.yaml"), []byte("signed"), 0o600); err != nil {
+		t.Fatalf("couldn't write file: %v", err)
+	}
+	for _, args := range [][]string{
+		{"add", "task.yaml"},
+		{"-c", "gpg.format=ssh", "-c", "user.signingkey=" + key, "commit", "-S", "-m", "signed commit"},
+	} {
+		if out, err := gitCmd(args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %q: %v", args, out, err)
+		}
+	}
+
+	// Commits of SHA-256 repositories are signed in a gpgsig-sha256 header.
+	repo := &repository{directory: repoPath}
+	if err := repo.verifySignature(t.Context(), "main", &allowedSigners{ssh: allowed}); err != nil {
+		t.Errorf("verifySignature() returned error: %v", err)
+	}
+}
+
+func TestIsSignedCommit(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected bool
+	}{{
+		name:     "gpgsig",
+		raw:      "tree abc\nauthor a\ncommitter a\ngpgsig -----BEGIN PGP SIGNATURE-----\n \n -----END PGP SIGNATURE-----\n\nmessage\n",
+		expected: true,
+	}, {
+		name:     "gpgsig-sha256",
+		raw:      "tree abc\nauthor a\ncommitter a\ngpgsig-sha256 -----BEGIN SSH SIGNATURE-----\n -----END SSH SIGNATURE-----\n\nmessage\n",
+		expected: true,
+	}, {
+		name: "unsigned",
+		raw:  "tree abc\nauthor a\ncommitter a\n\nmessage\n",
+	}, {
+		name: "signature in continuation line",
+		raw:  "tree abc\nauthor a\nmergetag object abc\n gpgsig x\n\nmessage\n",
+	}, {
+		name: "signature in message",
+		raw:  "tree abc\nauthor a\ncommitter a\n\ngpgsig in the message\n",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSignedCommit([]byte(tt.raw)); got != tt.expected {
+				t.Errorf("isSignedCommit() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetAllowedSigners(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "allowed-signers", Namespace: system.Namespace()},
+		Data:       map[string][]byte{AllowedSignersSSHKey: []byte("tekton@example.com ssh-ed25519 AAAA")},
+	}
+
+	tests := []struct {
+		name          string
+		conf          map[string]string
+		params        map[string]string
+		expectSigners bool
+		expectedErr   string
+	}{
+		{
+			name: "not required",
+			conf: map[string]string{AllowedSignersSecretNameKey: "allowed-signers"},
+		}, {
+			name:          "required by config",
+			conf:          map[string]string{RequireSignatureKey: "true", AllowedSignersSecretNameKey: "allowed-signers"},
+			expectSigners: true,
+		}, {
+			name:          "requested by params",
+			conf:          map[string]string{AllowedSignersSecretNameKey: "allowed-signers"},
+			params:        map[string]string{VerifySignatureParam: "true"},
+			expectSigners: true,
+		}, {
+			name:          "params cannot disable config",
+			conf:          map[string]string{RequireSignatureKey: "true", AllowedSignersSecretNameKey: "allowed-signers"},
+			params:        map[string]string{VerifySignatureParam: "false"},
+			expectSigners: true,
+		}, {
+			name:          "per configKey",
+			conf:          map[string]string{"test." + RequireSignatureKey: "true", "test." + AllowedSignersSecretNameKey: "allowed-signers"},
+			params:        map[string]string{ConfigKeyParam: "test"},
+			expectSigners: true,
+		}, {
+			name:        "no secret configured",
+			conf:        map[string]string{RequireSignatureKey: "true"},
+			expectedErr: "cannot verify signature, 'allowed-signers-secret-name' not specified in config",
+		}, {
+			name:        "missing secret",
+			conf:        map[string]string{RequireSignatureKey: "true", AllowedSignersSecretNameKey: "does-not-exist"},
+			expectedErr: "error reading allowed signers from secret does-not-exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := framework.InjectResolverConfigToContext(t.Context(), tt.conf)
+			g := &GitResolver{KubeClient: fake.NewSimpleClientset(secret), Params: tt.params}
+			conf, err := GetScmConfigForParamConfigKey(ctx, tt.params)
+			if err != nil {
+				t.Fatalf("GetScmConfigForParamConfigKey() returned error: %v", err)
+			}
+
+			signers, err := g.getAllowedSigners(ctx, conf)
+			if tt.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (signers != nil) != tt.expectSigners {
+				t.Errorf("getAllowedSigners() = %v, expectSigners %v", signers, tt.expectSigners)
+			}
+		})
+	}
+}
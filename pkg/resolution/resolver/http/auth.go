@@ -0,0 +1,225 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	common "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// default key in the bearer token secret
+	defaultBearerTokenSecretKey = "token"
+
+	// default key in the CA bundle secret
+	defaultCABundleSecretKey = "ca.crt"
+)
+
+// secretRef references a secret and, optionally, a key within it.
+type secretRef struct {
+	namespace string
+	name      string
+	key       string
+}
+
+// getSecret returns the secret referenced by ref. what describes the content
+// of the secret in error messages.
+func getSecret(ctx context.Context, ref secretRef, what string, kubeclient kubernetes.Interface, logger *zap.SugaredLogger) (*corev1.Secret, error) {
+	secret, err := kubeclient.CoreV1().Secrets(ref.namespace).Get(ctx, ref.name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			notFoundErr := fmt.Errorf("cannot get %s, secret %s not found in namespace %s", what, ref.name, ref.namespace)
+			logger.Info(notFoundErr)
+			return nil, notFoundErr
+		}
+		wrappedErr := fmt.Errorf("error reading %s from secret %s in namespace %s: %w", what, ref.name, ref.namespace, err)
+		logger.Info(wrappedErr)
+		return nil, wrappedErr
+	}
+	return secret, nil
+}
+
+// getSecretValue returns the value of the key referenced by ref.
+func getSecretValue(ctx context.Context, ref secretRef, what string, kubeclient kubernetes.Interface, logger *zap.SugaredLogger) ([]byte, error) {
+	secret, err := getSecret(ctx, ref, what, kubeclient, logger)
+	if err != nil {
+		return nil, err
+	}
+	secretVal, ok := secret.Data[ref.key]
+	if !ok {
+		err := fmt.Errorf("cannot get %s, key %s not found in secret %s in namespace %s", what, ref.key, ref.name, ref.namespace)
+		logger.Info(err)
+		return nil, err
+	}
+	return secretVal, nil
+}
+
+// configSecretRef returns a reference to the secret named by nameKey in the
+// resolver configuration, in the namespace of the resolvers, or nil if
+// nameKey is not set.
+func configSecretRef(conf map[string]string, nameKey, keyKey, defaultKey string) *secretRef {
+	name := conf[nameKey]
+	if name == "" {
+		return nil
+	}
+	key := conf[keyKey]
+	if key == "" {
+		key = defaultKey
+	}
+	return &secretRef{namespace: os.Getenv("SYSTEM_NAMESPACE"), name: name, key: key}
+}
+
+// paramSecretRef returns a reference to the secret named by the nameParam
+// param, in the namespace of the request, or nil if nameParam is not set.
+func paramSecretRef(ctx context.Context, params map[string]string, nameParam, keyParam, defaultKey string) *secretRef {
+	name := params[nameParam]
+	if name == "" {
+		return nil
+	}
+	key := params[keyParam]
+	if key == "" {
+		key = defaultKey
+	}
+	return &secretRef{namespace: common.RequestNamespace(ctx), name: name, key: key}
+}
+
+// isCredentialHost returns true if the credentials from the resolver
+// configuration may be sent to targetURL, which must be one of the hosts
+// listed in CredentialHostsKey.
+func isCredentialHost(conf map[string]string, targetURL string) bool {
+	u, err := url.Parse(targetURL)
+	if err != nil {
+		return false
+	}
+	for _, host := range strings.Split(conf[CredentialHostsKey], ",") {
+		host = strings.TrimSpace(host)
+		if host != "" && (host == u.Host || host == u.Hostname()) {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesRequestSecrets returns true if params reference any secret in the
+// namespace of the request, which makes the resolved content depend on the
+// namespace the request was made from.
+func UsesRequestSecrets(params map[string]string) bool {
+	for _, p := range []string{HttpBasicAuthSecret, HttpBearerTokenSecret, HttpClientCertSecret, HttpCABundleSecret} {
+		if params[p] != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// bearerTokenSecretRef returns the secret holding the bearer token to send
+// with the request, if any. A token referenced by the params takes precedence
+// over the one from the resolver configuration, which is not used together
+// with basic auth.
+func bearerTokenSecretRef(ctx context.Context, params map[string]string) *secretRef {
+	if ref := paramSecretRef(ctx, params, HttpBearerTokenSecret, HttpBearerTokenSecretKey, defaultBearerTokenSecretKey); ref != nil {
+		return ref
+	}
+	if params[HttpBasicAuthSecret] != "" {
+		return nil
+	}
+	conf := framework.GetResolverConfigFromContext(ctx)
+	if !isCredentialHost(conf, params[UrlParam]) {
+		return nil
+	}
+	return configSecretRef(conf, BearerTokenSecretNameKey, BearerTokenSecretKeyKey, defaultBearerTokenSecretKey)
+}
+
+func getBearerToken(ctx context.Context, ref secretRef, kubeclient kubernetes.Interface, logger *zap.SugaredLogger) (string, error) {
+	token, err := getSecretValue(ctx, ref, "bearer token", kubeclient, logger)
+	if err != nil {
+		return "", err
+	}
+	return "Bearer " + strings.TrimSpace(string(token)), nil
+}
+
+// makeTLSConfig returns the TLS configuration trusting the configured CA
+// bundles and presenting the configured client certificate, or nil if none
+// are configured.
+func makeTLSConfig(ctx context.Context, params map[string]string, kubeclient kubernetes.Interface, logger *zap.SugaredLogger) (*tls.Config, error) {
+	conf := framework.GetResolverConfigFromContext(ctx)
+
+	var caBundles []secretRef
+	for _, ref := range []*secretRef{
+		configSecretRef(conf, CABundleSecretNameKey, CABundleSecretKeyKey, defaultCABundleSecretKey),
+		paramSecretRef(ctx, params, HttpCABundleSecret, HttpCABundleSecretKey, defaultCABundleSecretKey),
+	} {
+		if ref != nil {
+			caBundles = append(caBundles, *ref)
+		}
+	}
+
+	clientCert := paramSecretRef(ctx, params, HttpClientCertSecret, "", corev1.TLSCertKey)
+	if clientCert == nil && isCredentialHost(conf, params[UrlParam]) {
+		clientCert = configSecretRef(conf, ClientCertSecretNameKey, "", corev1.TLSCertKey)
+	}
+
+	if len(caBundles) == 0 && clientCert == nil {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if len(caBundles) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		for _, ref := range caBundles {
+			bundle, err := getSecretValue(ctx, ref, "CA bundle", kubeclient, logger)
+			if err != nil {
+				return nil, err
+			}
+			if !pool.AppendCertsFromPEM(bundle) {
+				return nil, fmt.Errorf("invalid CA bundle, key %s in secret %s in namespace %s does not contain any PEM encoded certificates", ref.key, ref.name, ref.namespace)
+			}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if clientCert != nil {
+		secret, err := getSecret(ctx, *clientCert, "client certificate", kubeclient, logger)
+		if err != nil {
+			return nil, err
+		}
+		cert, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate in secret %s in namespace %s: %w", clientCert.name, clientCert.namespace, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
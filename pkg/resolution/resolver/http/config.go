@@ -20,4 +20,26 @@ const (
 	// TimeoutKey is the configuration field name for controlling
 	// the maximum duration of a resolution request for a file from http.
 	TimeoutKey = "fetch-timeout"
+
+	// BearerTokenSecretNameKey is the configuration field name for the secret
+	// in the resolvers namespace holding a bearer token sent to CredentialHostsKey.
+	BearerTokenSecretNameKey = "bearer-token-secret-name"
+	// BearerTokenSecretKeyKey is the configuration field name for the key
+	// holding the token in the BearerTokenSecretNameKey secret.
+	BearerTokenSecretKeyKey = "bearer-token-secret-key"
+	// ClientCertSecretNameKey is the configuration field name for the
+	// kubernetes.io/tls secret in the resolvers namespace holding the client
+	// certificate presented to CredentialHostsKey.
+	ClientCertSecretNameKey = "client-cert-secret-name"
+	// CredentialHostsKey is the configuration field name for the comma
+	// separated list of hosts the configured bearer token and client
+	// certificate are sent to.
+	CredentialHostsKey = "credential-hosts"
+	// CABundleSecretNameKey is the configuration field name for the secret in
+	// the resolvers namespace holding PEM encoded CA certificates to trust in
+	// addition to the system ones.
+	CABundleSecretNameKey = "ca-bundle-secret-name"
+	// CABundleSecretKeyKey is the configuration field name for the key
+	// holding the certificates in the CABundleSecretNameKey secret.
+	CABundleSecretKeyKey = "ca-bundle-secret-key"
 )
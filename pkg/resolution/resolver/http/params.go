@@ -27,4 +27,21 @@ const (
 
 	// HttpBasicAuthSecretKey is the key in the httpBasicAuthSecret secret to use for basic auth
 	HttpBasicAuthSecretKey string = "http-password-secret-key"
+
+	// HttpBearerTokenSecret is the reference to a secret in the PipelineRun or TaskRun namespace to use for bearer token auth
+	HttpBearerTokenSecret string = "http-bearer-token-secret"
+
+	// HttpBearerTokenSecretKey is the key in the HttpBearerTokenSecret secret to use for bearer token auth
+	HttpBearerTokenSecretKey string = "http-bearer-token-secret-key"
+
+	// HttpClientCertSecret is the reference to a kubernetes.io/tls secret in the PipelineRun or TaskRun namespace
+	// holding the client certificate and key to present to the server
+	HttpClientCertSecret string = "http-client-cert-secret"
+
+	// HttpCABundleSecret is the reference to a secret in the PipelineRun or TaskRun namespace holding PEM encoded
+	// CA certificates to trust in addition to the system ones
+	HttpCABundleSecret string = "http-ca-bundle-secret"
+
+	// HttpCABundleSecretKey is the key in the HttpCABundleSecret secret holding the CA certificates
+	HttpCABundleSecretKey string = "http-ca-bundle-secret-key"
 )
@@ -33,8 +33,6 @@ import (
 	common "github.com/tektoncd/pipeline/pkg/resolution/common"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
 	"go.uber.org/zap"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/logging"
@@ -67,6 +65,12 @@ const (
 
 	// sha256Algo is the prefix name for the sha256sum value
 	sha256Algo = "sha256"
+
+	// etagAnnotation is the annotation holding the ETag of the resolved content
+	etagAnnotation = "resolution.tekton.dev/http-etag"
+
+	// lastModifiedAnnotation is the annotation holding the Last-Modified date of the resolved content
+	lastModifiedAnnotation = "resolution.tekton.dev/http-last-modified"
 )
 
 // Resolver implements a framework.Resolver that can fetch files from an HTTP URL
@@ -126,8 +130,10 @@ func IsDisabled(ctx context.Context) bool {
 
 // resolvedHttpResource wraps the data we want to return to Pipelines
 type resolvedHttpResource struct {
-	URL     string
-	Content []byte
+	URL          string
+	Content      []byte
+	ETag         string
+	LastModified string
 }
 
 var _ framework.ResolvedResource = &resolvedHttpResource{}
@@ -137,9 +143,20 @@ func (rr *resolvedHttpResource) Data() []byte {
 	return rr.Content
 }
 
-// Annotations returns any metadata needed alongside the data. None atm.
-func (*resolvedHttpResource) Annotations() map[string]string {
-	return nil
+// Annotations returns the validators of the content sent by the server, if
+// any, which are used to revalidate cached content.
+func (rr *resolvedHttpResource) Annotations() map[string]string {
+	if rr.ETag == "" && rr.LastModified == "" {
+		return nil
+	}
+	annotations := map[string]string{}
+	if rr.ETag != "" {
+		annotations[etagAnnotation] = rr.ETag
+	}
+	if rr.LastModified != "" {
+		annotations[lastModifiedAnnotation] = rr.LastModified
+	}
+	return annotations
 }
 
 // RefSource is the source reference of the remote data that records where the remote
@@ -195,6 +212,37 @@ func PopulateDefaultParams(ctx context.Context, params []pipelinev1.Param) (map[
 		}
 	}
 
+	if secret, ok := paramsMap[HttpBearerTokenSecret]; ok {
+		if secret == "" {
+			return nil, fmt.Errorf("value %s cannot be empty", HttpBearerTokenSecret)
+		}
+		if _, ok := paramsMap[HttpBasicAuthSecret]; ok {
+			return nil, fmt.Errorf("cannot specify both %s and %s", HttpBearerTokenSecret, HttpBasicAuthSecret)
+		}
+	}
+
+	for _, p := range []struct{ key, secret string }{
+		{key: HttpBearerTokenSecretKey, secret: HttpBearerTokenSecret},
+		{key: HttpCABundleSecretKey, secret: HttpCABundleSecret},
+	} {
+		if _, ok := paramsMap[p.key]; ok && paramsMap[p.secret] == "" {
+			return nil, fmt.Errorf("missing required param %s when using %s", p.secret, p.key)
+		}
+	}
+
+	for _, param := range []string{HttpClientCertSecret, HttpCABundleSecret} {
+		secret, ok := paramsMap[param]
+		if !ok {
+			continue
+		}
+		if secret == "" {
+			return nil, fmt.Errorf("value %s cannot be empty", param)
+		}
+		if u, err := url.Parse(paramsMap[UrlParam]); err != nil || u.Scheme != "https" {
+			return nil, fmt.Errorf("param %s can only be used with an https url", param)
+		}
+	}
+
 	if len(missingParams) > 0 {
 		return nil, fmt.Errorf("missing required http resolver params: %s", strings.Join(missingParams, ", "))
 	}
@@ -202,7 +250,7 @@ func PopulateDefaultParams(ctx context.Context, params []pipelinev1.Param) (map[
 	return paramsMap, nil
 }
 
-func makeHttpClient(ctx context.Context) (*http.Client, error) {
+func makeHttpClient(ctx context.Context, params map[string]string, kubeclient kubernetes.Interface, logger *zap.SugaredLogger) (*http.Client, error) {
 	conf := framework.GetResolverConfigFromContext(ctx)
 	timeout, _ := time.ParseDuration(defaultHttpTimeoutValue)
 	if v, ok := conf[TimeoutKey]; ok {
@@ -212,9 +260,20 @@ func makeHttpClient(ctx context.Context) (*http.Client, error) {
 			return nil, fmt.Errorf("error parsing timeout value %s: %w", v, err)
 		}
 	}
-	return &http.Client{
+	client := &http.Client{
 		Timeout: timeout,
-	}, nil
+	}
+
+	tlsConfig, err := makeTLSConfig(ctx, params, kubeclient, logger)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		client.Transport = transport
+	}
+	return client, nil
 }
 
 // compareSHA compares two hexadecimal SHA strings in constant time.
@@ -264,87 +323,104 @@ func validateDigest(digest string, body []byte, logger *zap.SugaredLogger) error
 }
 
 func FetchHttpResource(ctx context.Context, params map[string]string, kubeclient kubernetes.Interface, logger *zap.SugaredLogger) (framework.ResolvedResource, error) {
+	resource, _, err := fetchHttpResource(ctx, params, kubeclient, logger, nil)
+	return resource, err
+}
+
+// RevalidateHttpResource fetches the resource like FetchHttpResource, but
+// sends a conditional request using the ETag and Last-Modified validators of
+// stale, a previously resolved version of the resource. It returns false and
+// no resource if the server responds that stale is not modified.
+func RevalidateHttpResource(ctx context.Context, params map[string]string, kubeclient kubernetes.Interface, logger *zap.SugaredLogger, stale framework.ResolvedResource) (framework.ResolvedResource, bool, error) {
+	return fetchHttpResource(ctx, params, kubeclient, logger, stale)
+}
+
+func fetchHttpResource(ctx context.Context, params map[string]string, kubeclient kubernetes.Interface, logger *zap.SugaredLogger, stale framework.ResolvedResource) (framework.ResolvedResource, bool, error) {
 	var targetURL string
 	var ok bool
 
-	httpClient, err := makeHttpClient(ctx)
+	httpClient, err := makeHttpClient(ctx, params, kubeclient, logger)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	if targetURL, ok = params[UrlParam]; !ok {
-		return nil, fmt.Errorf("missing required params: %s", UrlParam)
+		return nil, false, fmt.Errorf("missing required params: %s", UrlParam)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, targetURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("constructing request: %w", err)
+		return nil, false, fmt.Errorf("constructing request: %w", err)
 	}
 
 	// NOTE(chmouel): We already made sure that username and secret was specified by the user
 	if secret, ok := params[HttpBasicAuthSecret]; ok && secret != "" {
 		if encodedSecret, err := getBasicAuthSecret(ctx, params, kubeclient, logger); err != nil {
-			return nil, err
+			return nil, false, err
 		} else {
 			req.Header.Set("Authorization", encodedSecret)
 		}
+	} else if ref := bearerTokenSecretRef(ctx, params); ref != nil {
+		token, err := getBearerToken(ctx, *ref, kubeclient, logger)
+		if err != nil {
+			return nil, false, err
+		}
+		req.Header.Set("Authorization", token)
+	}
+
+	if stale != nil {
+		if etag := stale.Annotations()[etagAnnotation]; etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := stale.Annotations()[lastModifiedAnnotation]; lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
 	}
 
 	// #nosec G704 -- URL cannot be constant in this case.
 	resp, err := httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching URL: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("requested URL '%s' is not found", targetURL)
+		return nil, false, fmt.Errorf("error fetching URL: %w", err)
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
+	if resp.StatusCode == http.StatusNotModified && stale != nil {
+		logger.Infof("Content of %s is not modified", targetURL)
+		return nil, false, nil
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, false, common.NewError(common.ReasonResourceNotFound, fmt.Errorf("requested URL '%s' is not found", targetURL))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("requested URL '%s' is not found", targetURL)
+	}
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %w", err)
+		return nil, false, fmt.Errorf("error reading response body: %w", err)
 	}
 
 	digest, ok := params[digestParam]
 	if ok {
 		err = validateDigest(digest, body, logger)
 		if err != nil {
-			return nil, fmt.Errorf("error validating digest: %w", err)
+			return nil, false, fmt.Errorf("error validating digest: %w", err)
 		}
 	}
 
 	return &resolvedHttpResource{
-		Content: body,
-		URL:     targetURL,
-	}, nil
+		Content:      body,
+		URL:          targetURL,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}, true, nil
 }
 
 func getBasicAuthSecret(ctx context.Context, params map[string]string, kubeclient kubernetes.Interface, logger *zap.SugaredLogger) (string, error) {
-	secretName := params[HttpBasicAuthSecret]
 	userName := params[HttpBasicAuthUsername]
-	tokenSecretKey := defaultBasicAuthSecretKey
-	if v, ok := params[HttpBasicAuthSecretKey]; ok {
-		if v != "" {
-			tokenSecretKey = v
-		}
-	}
-	secretNS := common.RequestNamespace(ctx)
-	secret, err := kubeclient.CoreV1().Secrets(secretNS).Get(ctx, secretName, metav1.GetOptions{})
+	ref := paramSecretRef(ctx, params, HttpBasicAuthSecret, HttpBasicAuthSecretKey, defaultBasicAuthSecretKey)
+	secretVal, err := getSecretValue(ctx, *ref, "API token", kubeclient, logger)
 	if err != nil {
-		if apierrors.IsNotFound(err) {
-			notFoundErr := fmt.Errorf("cannot get API token, secret %s not found in namespace %s", secretName, secretNS)
-			logger.Info(notFoundErr)
-			return "", notFoundErr
-		}
-		wrappedErr := fmt.Errorf("error reading API token from secret %s in namespace %s: %w", secretName, secretNS, err)
-		logger.Info(wrappedErr)
-		return "", wrappedErr
-	}
-	secretVal, ok := secret.Data[tokenSecretKey]
-	if !ok {
-		err := fmt.Errorf("cannot get API token, key %s not found in secret %s in namespace %s", tokenSecretKey, secretName, secretNS)
-		logger.Info(err)
 		return "", err
 	}
 	return "Basic " + base64.StdEncoding.EncodeToString(
@@ -18,14 +18,23 @@ package http
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/sha256"
 	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"regexp"
 	"strings"
 	"testing"
@@ -44,6 +53,7 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/system"
 	_ "knative.dev/pkg/system/testing"
@@ -137,7 +147,7 @@ func TestMakeHTTPClient(t *testing.T) {
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			client, err := makeHttpClient(contextWithConfig(tc.duration))
+			client, err := makeHttpClient(contextWithConfig(tc.duration), map[string]string{}, nil, nil)
 			if tc.expectedErr != nil {
 				checkExpectedErr(t, tc.expectedErr, err)
 				return
@@ -650,3 +660,321 @@ func TestValidateDigest(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateParamsCredentials(t *testing.T) {
+	testCases := []struct {
+		name        string
+		params      map[string]string
+		expectedErr error
+	}{
+		{
+			name:   "valid/bearer token",
+			params: map[string]string{UrlParam: "https://foo/bar", HttpBearerTokenSecret: "token", HttpBearerTokenSecretKey: "key"},
+		}, {
+			name:   "valid/client cert and ca bundle",
+			params: map[string]string{UrlParam: "https://foo/bar", HttpClientCertSecret: "cert", HttpCABundleSecret: "ca", HttpCABundleSecretKey: "bundle.pem"},
+		}, {
+			name:        "invalid/empty bearer token secret",
+			params:      map[string]string{UrlParam: "https://foo/bar", HttpBearerTokenSecret: ""},
+			expectedErr: errors.New(`value http-bearer-token-secret cannot be empty`),
+		}, {
+			name:        "invalid/bearer token and basic auth",
+			params:      map[string]string{UrlParam: "https://foo/bar", HttpBearerTokenSecret: "token", HttpBasicAuthUsername: "user", HttpBasicAuthSecret: "password"},
+			expectedErr: errors.New(`cannot specify both http-bearer-token-secret and http-password-secret`),
+		}, {
+			name:        "invalid/bearer token key without secret",
+			params:      map[string]string{UrlParam: "https://foo/bar", HttpBearerTokenSecretKey: "key"},
+			expectedErr: errors.New(`missing required param http-bearer-token-secret when using http-bearer-token-secret-key`),
+		}, {
+			name:        "invalid/ca bundle key without secret",
+			params:      map[string]string{UrlParam: "https://foo/bar", HttpCABundleSecretKey: "bundle.pem"},
+			expectedErr: errors.New(`missing required param http-ca-bundle-secret when using http-ca-bundle-secret-key`),
+		}, {
+			name:        "invalid/empty client cert secret",
+			params:      map[string]string{UrlParam: "https://foo/bar", HttpClientCertSecret: ""},
+			expectedErr: errors.New(`value http-client-cert-secret cannot be empty`),
+		}, {
+			name:        "invalid/client cert with http url",
+			params:      map[string]string{UrlParam: "http://foo/bar", HttpClientCertSecret: "cert"},
+			expectedErr: errors.New(`param http-client-cert-secret can only be used with an https url`),
+		}, {
+			name:        "invalid/ca bundle with http url",
+			params:      map[string]string{UrlParam: "http://foo/bar", HttpCABundleSecret: "ca"},
+			expectedErr: errors.New(`param http-ca-bundle-secret can only be used with an https url`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := Resolver{}
+			err := resolver.ValidateParams(contextWithConfig(defaultHttpTimeoutValue), toParams(tc.params))
+			if tc.expectedErr != nil {
+				checkExpectedErr(t, tc.expectedErr, err)
+			} else if err != nil {
+				t.Fatalf("unexpected error validating params: %v", err)
+			}
+		})
+	}
+}
+
+func TestFetchHttpResourceBearerToken(t *testing.T) {
+	kubeclient := fakek8s.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "request-token", Namespace: "foo"},
+			Data:       map[string][]byte{"token": []byte("request-token\n"), "custom": []byte("custom-token")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "config-token", Namespace: system.Namespace()},
+			Data:       map[string][]byte{"token": []byte("config-token")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "password", Namespace: "foo"},
+			Data:       map[string][]byte{"password": []byte("secret")},
+		},
+	)
+
+	var gotAuth string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		fmt.Fprint(w, sampleTask)
+	}))
+	defer svr.Close()
+	svrURL, _ := url.Parse(svr.URL)
+
+	tests := []struct {
+		name         string
+		params       map[string]string
+		conf         map[string]string
+		expectedAuth string
+		expectedErr  string
+	}{
+		{
+			name:         "token from params",
+			params:       map[string]string{HttpBearerTokenSecret: "request-token"},
+			expectedAuth: "Bearer request-token",
+		}, {
+			name:         "token from params with custom key",
+			params:       map[string]string{HttpBearerTokenSecret: "request-token", HttpBearerTokenSecretKey: "custom"},
+			expectedAuth: "Bearer custom-token",
+		}, {
+			name:         "token from config for a credential host",
+			conf:         map[string]string{BearerTokenSecretNameKey: "config-token", CredentialHostsKey: "example.com, " + svrURL.Host},
+			expectedAuth: "Bearer config-token",
+		}, {
+			name: "token from config for another host",
+			conf: map[string]string{BearerTokenSecretNameKey: "config-token", CredentialHostsKey: "example.com"},
+		}, {
+			name:         "params take precedence over config",
+			params:       map[string]string{HttpBearerTokenSecret: "request-token"},
+			conf:         map[string]string{BearerTokenSecretNameKey: "config-token", CredentialHostsKey: svrURL.Hostname()},
+			expectedAuth: "Bearer request-token",
+		}, {
+			name:         "basic auth takes precedence over config",
+			params:       map[string]string{HttpBasicAuthUsername: "user", HttpBasicAuthSecret: "password"},
+			conf:         map[string]string{BearerTokenSecretNameKey: "config-token", CredentialHostsKey: svrURL.Host},
+			expectedAuth: "Basic " + base64.StdEncoding.EncodeToString([]byte("user:secret")),
+		}, {
+			name:        "missing token secret",
+			params:      map[string]string{HttpBearerTokenSecret: "does-not-exist"},
+			expectedErr: "cannot get bearer token, secret does-not-exist not found in namespace foo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotAuth = ""
+			ctx := common.InjectRequestNamespace(framework.InjectResolverConfigToContext(t.Context(), tc.conf), "foo")
+			params := map[string]string{UrlParam: svr.URL}
+			for k, v := range tc.params {
+				params[k] = v
+			}
+
+			_, err := FetchHttpResource(ctx, params, kubeclient, logging.FromContext(ctx))
+			if tc.expectedErr != "" {
+				checkExpectedErr(t, errors.New(tc.expectedErr), err)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotAuth != tc.expectedAuth {
+				t.Errorf("expected Authorization header %q, got %q", tc.expectedAuth, gotAuth)
+			}
+		})
+	}
+}
+
+// createClientCert returns a self-signed client certificate and its key in
+// PEM format.
+func createClientCert(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("couldn't generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "tekton"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("couldn't create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("couldn't marshal key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestFetchHttpResourceTLS(t *testing.T) {
+	clientCert, clientKey := createClientCert(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(clientCert)
+
+	svr := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, sampleTask)
+	}))
+	svr.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs, MinVersion: tls.VersionTLS12}
+	svr.StartTLS()
+	defer svr.Close()
+	svrURL, _ := url.Parse(svr.URL)
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: svr.Certificate().Raw})
+
+	kubeclient := fakek8s.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "client-cert", Namespace: "foo"},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: clientCert, corev1.TLSPrivateKeyKey: clientKey},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "client-cert", Namespace: system.Namespace()},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: clientCert, corev1.TLSPrivateKeyKey: clientKey},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: "foo"},
+			Data:       map[string][]byte{"bundle.pem": serverCA},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: system.Namespace()},
+			Data:       map[string][]byte{"ca.crt": serverCA},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "not-a-ca", Namespace: "foo"},
+			Data:       map[string][]byte{"ca.crt": []byte("not a certificate")},
+		},
+	)
+
+	tests := []struct {
+		name        string
+		params      map[string]string
+		conf        map[string]string
+		expectedErr string
+	}{
+		{
+			name:   "client cert and ca bundle from params",
+			params: map[string]string{HttpClientCertSecret: "client-cert", HttpCABundleSecret: "ca", HttpCABundleSecretKey: "bundle.pem"},
+		}, {
+			name: "client cert and ca bundle from config",
+			conf: map[string]string{ClientCertSecretNameKey: "client-cert", CABundleSecretNameKey: "ca", CredentialHostsKey: svrURL.Host},
+		}, {
+			name:        "client cert from config for another host",
+			conf:        map[string]string{ClientCertSecretNameKey: "client-cert", CABundleSecretNameKey: "ca", CredentialHostsKey: "example.com"},
+			expectedErr: "error fetching URL",
+		}, {
+			name:        "untrusted server",
+			params:      map[string]string{HttpClientCertSecret: "client-cert"},
+			expectedErr: "certificate signed by unknown authority",
+		}, {
+			name:        "invalid ca bundle",
+			params:      map[string]string{HttpCABundleSecret: "not-a-ca"},
+			expectedErr: "invalid CA bundle, key ca.crt in secret not-a-ca in namespace foo does not contain any PEM encoded certificates",
+		}, {
+			name:        "invalid client cert",
+			params:      map[string]string{HttpClientCertSecret: "ca", HttpCABundleSecret: "ca", HttpCABundleSecretKey: "bundle.pem"},
+			expectedErr: "invalid client certificate in secret ca in namespace foo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := common.InjectRequestNamespace(framework.InjectResolverConfigToContext(t.Context(), tc.conf), "foo")
+			params := map[string]string{UrlParam: svr.URL}
+			for k, v := range tc.params {
+				params[k] = v
+			}
+
+			resource, err := FetchHttpResource(ctx, params, kubeclient, logging.FromContext(ctx))
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(resource.Data()) != sampleTask {
+				t.Errorf("expected %q, got %q", sampleTask, resource.Data())
+			}
+		})
+	}
+}
+
+func TestRevalidateHttpResource(t *testing.T) {
+	const etag = `"v1"`
+	const lastModified = "Wed, 21 Oct 2015 07:28:00 GMT"
+	var gotIfNoneMatch, gotIfModifiedSince string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotIfNoneMatch, gotIfModifiedSince = r.Header.Get("If-None-Match"), r.Header.Get("If-Modified-Since")
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", lastModified)
+		if gotIfNoneMatch == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, sampleTask)
+	}))
+	defer svr.Close()
+
+	ctx := contextWithConfig(defaultHttpTimeoutValue)
+	logger := logging.FromContext(ctx)
+	params := map[string]string{UrlParam: svr.URL}
+
+	resource, err := FetchHttpResource(ctx, params, nil, logger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedAnnotations := map[string]string{etagAnnotation: etag, lastModifiedAnnotation: lastModified}
+	if d := cmp.Diff(expectedAnnotations, resource.Annotations()); d != "" {
+		t.Errorf("unexpected annotations: %s", diff.PrintWantGot(d))
+	}
+	if gotIfNoneMatch != "" || gotIfModifiedSince != "" {
+		t.Errorf("expected an unconditional request, got If-None-Match %q and If-Modified-Since %q", gotIfNoneMatch, gotIfModifiedSince)
+	}
+
+	revalidated, modified, err := RevalidateHttpResource(ctx, params, nil, logger, resource)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if modified || revalidated != nil {
+		t.Errorf("expected the resource not to be modified, got %v", revalidated)
+	}
+	if gotIfNoneMatch != etag || gotIfModifiedSince != lastModified {
+		t.Errorf("expected If-None-Match %q and If-Modified-Since %q, got %q and %q", etag, lastModified, gotIfNoneMatch, gotIfModifiedSince)
+	}
+
+	changed := &resolvedHttpResource{Content: []byte("old"), ETag: `"v0"`}
+	revalidated, modified, err = RevalidateHttpResource(ctx, params, nil, logger, changed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !modified || string(revalidated.Data()) != sampleTask {
+		t.Errorf("expected the modified resource to be returned, got %v", revalidated)
+	}
+}
@@ -48,3 +48,7 @@ const ParamCatalog = "catalog"
 
 // ParamType is the parameter defining what the hub type to pull the resource from.
 const ParamType = "type"
+
+// ParamDigest is the parameter defining the sha256 digest the content of the
+// resolved version must match, in the format "sha256:<hex>".
+const ParamDigest = "digest"
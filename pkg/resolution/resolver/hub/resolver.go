@@ -30,6 +30,7 @@ import (
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	common "github.com/tektoncd/pipeline/pkg/resolution/common"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"knative.dev/pkg/logging"
 )
 
 const (
@@ -43,6 +44,14 @@ const (
 	// TektonHubType is the value to use setting the type field to tekton
 	TektonHubType string = "tekton"
 
+	// ResolvedVersionAnnotation is the annotation holding the concrete version
+	// of the resolved resource.
+	ResolvedVersionAnnotation string = "resolution.tekton.dev/hub-version"
+
+	// VersionConstraintAnnotation is the annotation holding the version range
+	// the concrete version was chosen from.
+	VersionConstraintAnnotation string = "resolution.tekton.dev/hub-version-constraint"
+
 	disabledError = "cannot handle resolution request, enable-hub-resolver feature flag not true"
 )
 
@@ -135,12 +144,17 @@ func Resolve(ctx context.Context, params []pipelinev1.Param, tektonHubURL, artif
 		return nil, fmt.Errorf("failed to validate params: %w", err)
 	}
 
-	if constraint, err := goversion.NewConstraint(paramsMap[ParamVersion]); err == nil {
-		chosen, err := resolveVersionConstraint(ctx, paramsMap, constraint, artifactHubURL, tektonHubURL)
+	requested := paramsMap[ParamVersion]
+	var constraint string
+	if c, err := versionConstraint(requested); err == nil {
+		chosen, err := resolveVersionConstraint(ctx, paramsMap, c, artifactHubURL, tektonHubURL)
 		if err != nil {
 			return nil, err
 		}
-		paramsMap[ParamVersion] = chosen.String()
+		paramsMap[ParamVersion] = chosen.Original()
+		if !isExactVersion(requested) {
+			constraint = requested
+		}
 	}
 
 	resVer, err := resolveVersion(paramsMap[ParamVersion], paramsMap[ParamType])
@@ -150,6 +164,7 @@ func Resolve(ctx context.Context, params []pipelinev1.Param, tektonHubURL, artif
 	paramsMap[ParamVersion] = resVer
 
 	// call hub API
+	var rr *ResolvedHubResource
 	switch paramsMap[ParamType] {
 	case ArtifactHubType:
 		url := fmt.Sprintf(fmt.Sprintf("%s/%s", artifactHubURL, ArtifactHubYamlEndpoint),
@@ -158,10 +173,10 @@ func Resolve(ctx context.Context, params []pipelinev1.Param, tektonHubURL, artif
 		if err := fetchHubResource(ctx, url, &resp); err != nil {
 			return nil, fmt.Errorf("fail to fetch Artifact Hub resource: %w", err)
 		}
-		return &ResolvedHubResource{
+		rr = &ResolvedHubResource{
 			URL:     url,
 			Content: []byte(resp.Data.YAML),
-		}, nil
+		}
 	case TektonHubType:
 		url := fmt.Sprintf(fmt.Sprintf("%s/%s", tektonHubURL, TektonHubYamlEndpoint),
 			paramsMap[ParamCatalog], paramsMap[ParamKind], paramsMap[ParamName], paramsMap[ParamVersion])
@@ -169,19 +184,33 @@ func Resolve(ctx context.Context, params []pipelinev1.Param, tektonHubURL, artif
 		if err := fetchHubResource(ctx, url, &resp); err != nil {
 			return nil, fmt.Errorf("fail to fetch Tekton Hub resource: %w", err)
 		}
-		return &ResolvedHubResource{
+		rr = &ResolvedHubResource{
 			URL:     url,
 			Content: []byte(resp.Data.YAML),
-		}, nil
+		}
+	default:
+		return nil, fmt.Errorf("hub resolver type: %s is not supported", paramsMap[ParamType])
 	}
+	rr.Version = paramsMap[ParamVersion]
+	rr.VersionConstraint = constraint
 
-	return nil, fmt.Errorf("hub resolver type: %s is not supported", paramsMap[ParamType])
+	if digest, ok := paramsMap[ParamDigest]; ok {
+		if actual := "sha256:" + rr.RefSource().Digest["sha256"]; actual != strings.ToLower(digest) {
+			return nil, fmt.Errorf("content of %s %s version %s does not match digest %s, got %s", paramsMap[ParamKind], paramsMap[ParamName], rr.Version, digest, actual)
+		}
+	}
+	return rr, nil
 }
 
 // ResolvedHubResource wraps the data we want to return to Pipelines
 type ResolvedHubResource struct {
 	URL     string
 	Content []byte
+	// Version is the concrete version that was fetched from the hub.
+	Version string
+	// VersionConstraint is the requested version range Version was chosen
+	// from, if the request did not specify an exact version.
+	VersionConstraint string
 }
 
 var _ framework.ResolvedResource = &ResolvedHubResource{}
@@ -191,13 +220,24 @@ func (rr *ResolvedHubResource) Data() []byte {
 	return rr.Content
 }
 
-// Annotations returns any metadata needed alongside the data. None atm.
-func (*ResolvedHubResource) Annotations() map[string]string {
-	return nil
+// Annotations returns the concrete version that was resolved and, if it was
+// chosen from a range, the requested range.
+func (rr *ResolvedHubResource) Annotations() map[string]string {
+	if rr.Version == "" {
+		return nil
+	}
+	annotations := map[string]string{
+		ResolvedVersionAnnotation: rr.Version,
+	}
+	if rr.VersionConstraint != "" {
+		annotations[VersionConstraintAnnotation] = rr.VersionConstraint
+	}
+	return annotations
 }
 
 // RefSource is the source reference of the remote data that records where the remote
-// file came from including the url, digest and the entrypoint.
+// file came from including the url and digest. The url includes the concrete version
+// that was resolved.
 func (rr *ResolvedHubResource) RefSource() *pipelinev1.RefSource {
 	h := sha256.New()
 	h.Write(rr.Content)
@@ -228,6 +268,9 @@ func fetchHubResource(ctx context.Context, apiEndpoint string, v interface{}) er
 	if err != nil {
 		return fmt.Errorf("requesting resource from Hub: %w", err)
 	}
+	if resp.StatusCode == http.StatusNotFound {
+		return common.NewError(common.ReasonResourceNotFound, fmt.Errorf("requested resource '%s' not found on hub", apiEndpoint))
+	}
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("requested resource '%s' not found on hub", apiEndpoint)
 	}
@@ -381,12 +424,47 @@ func validateParams(ctx context.Context, paramsMap map[string]string, tektonHubU
 		return fmt.Errorf("missing required hub resolver params: %s", strings.Join(missingParams, ", "))
 	}
 
+	if digest, ok := paramsMap[ParamDigest]; ok {
+		value, found := strings.CutPrefix(strings.ToLower(digest), "sha256:")
+		if _, err := hex.DecodeString(value); !found || err != nil || len(value) != 64 {
+			return fmt.Errorf("invalid digest %s, must be in the format sha256:<hex>", digest)
+		}
+	}
+
 	return nil
 }
 
+// resolveVersionConstraint returns the greatest version of the requested
+// resource that satisfies constraint. Prereleases are only chosen by
+// constraints that explicitly reference a prerelease.
 func resolveVersionConstraint(ctx context.Context, paramsMap map[string]string, constraint goversion.Constraints, artifactHubURL, tektonHubURL string) (*goversion.Version, error) {
+	versions, err := listVersions(ctx, paramsMap, artifactHubURL, tektonHubURL)
+	if err != nil {
+		return nil, err
+	}
 	var ret *goversion.Version
-	if paramsMap[ParamType] == ArtifactHubType {
+	for _, vers := range versions {
+		checkV, err := goversion.NewVersion(vers)
+		if err != nil {
+			return nil, fmt.Errorf("fail to parse version %s from %s: %w", vers, paramsMap[ParamType], err)
+		}
+		if constraint.Check(checkV) && (ret == nil || checkV.GreaterThan(ret)) {
+			ret = checkV
+		}
+	}
+	if ret == nil {
+		return nil, fmt.Errorf("no version found for constraint %s", paramsMap[ParamVersion])
+	}
+	logging.FromContext(ctx).Infof("Resolved version %s of %s %s for constraint %q", ret.Original(), paramsMap[ParamKind], paramsMap[ParamName], paramsMap[ParamVersion])
+	return ret, nil
+}
+
+// listVersions returns the versions of the requested resource available on
+// the hub, skipping the ones Artifact Hub flags as prereleases.
+func listVersions(ctx context.Context, paramsMap map[string]string, artifactHubURL, tektonHubURL string) ([]string, error) {
+	var versions []string
+	switch paramsMap[ParamType] {
+	case ArtifactHubType:
 		allVersionsURL := fmt.Sprintf("%s/%s", artifactHubURL, fmt.Sprintf(
 			ArtifactHubListTasksEndpoint,
 			paramsMap[ParamKind], paramsMap[ParamCatalog], paramsMap[ParamName]))
@@ -395,25 +473,11 @@ func resolveVersionConstraint(ctx context.Context, paramsMap map[string]string,
 			return nil, fmt.Errorf("fail to fetch Artifact Hub resource: %w", err)
 		}
 		for _, vers := range resp.AvailableVersions {
-			if vers.Prerelease {
-				continue
-			}
-			checkV, err := goversion.NewVersion(vers.Version)
-			if err != nil {
-				return nil, fmt.Errorf("fail to parse version %s from %s: %w", ArtifactHubType, vers.Version, err)
-			}
-			if checkV == nil {
-				continue
-			}
-			if constraint.Check(checkV) {
-				if ret != nil && ret.GreaterThan(checkV) {
-					continue
-				}
-				// TODO(chmouel): log constraint result in controller
-				ret = checkV
+			if !vers.Prerelease {
+				versions = append(versions, vers.Version)
 			}
 		}
-	} else if paramsMap[ParamType] == TektonHubType {
+	case TektonHubType:
 		allVersionsURL := fmt.Sprintf("%s/%s", tektonHubURL,
 			fmt.Sprintf(TektonHubListTasksEndpoint,
 				paramsMap[ParamCatalog], paramsMap[ParamKind], paramsMap[ParamName]))
@@ -422,26 +486,10 @@ func resolveVersionConstraint(ctx context.Context, paramsMap map[string]string,
 			return nil, fmt.Errorf("fail to fetch Tekton Hub resource: %w", err)
 		}
 		for _, vers := range resp.Data.Versions {
-			checkV, err := goversion.NewVersion(vers.Version)
-			if err != nil {
-				return nil, fmt.Errorf("fail to parse version %s from %s: %w", TektonHubType, vers, err)
-			}
-			if checkV == nil {
-				continue
-			}
-			if constraint.Check(checkV) {
-				if ret != nil && ret.GreaterThan(checkV) {
-					continue
-				}
-				// TODO(chmouel): log constraint result in controller
-				ret = checkV
-			}
+			versions = append(versions, vers.Version)
 		}
 	}
-	if ret == nil {
-		return nil, fmt.Errorf("no version found for constraint %s", paramsMap[ParamVersion])
-	}
-	return ret, nil
+	return versions, nil
 }
 
 func isSupportedKind(kindValue string) bool {
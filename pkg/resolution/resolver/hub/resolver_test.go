@@ -53,6 +53,7 @@ func TestValidateParams(t *testing.T) {
 		catalog      string
 		resourceName string
 		hubType      string
+		digest       string
 		expectedErr  error
 	}{
 		{
@@ -80,6 +81,25 @@ func TestValidateParams(t *testing.T) {
 			hubType:      TektonHubType,
 			expectedErr:  errors.New("failed to validate params: please configure TEKTON_HUB_API env variable to use tekton type"),
 		},
+		{
+			testName:     "digest validation",
+			kind:         "task",
+			resourceName: "foo",
+			version:      "0.9.1",
+			catalog:      "baz",
+			hubType:      ArtifactHubType,
+			digest:       "sha256:290f493c44f5d63d06b374d0a5abd292fae38b92cab2fae5efefe1b0e9347f56",
+		},
+		{
+			testName:     "invalid digest",
+			kind:         "task",
+			resourceName: "foo",
+			version:      "0.9.1",
+			catalog:      "baz",
+			hubType:      ArtifactHubType,
+			digest:       "sha512:290f",
+			expectedErr:  errors.New("failed to validate params: invalid digest sha512:290f, must be in the format sha256:<hex>"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -92,6 +112,9 @@ func TestValidateParams(t *testing.T) {
 				ParamCatalog: tc.catalog,
 				ParamType:    tc.hubType,
 			}
+			if tc.digest != "" {
+				params[ParamDigest] = tc.digest
+			}
 
 			err := resolver.ValidateParams(contextWithConfig(), toParams(params))
 			if tc.expectedErr != nil {
@@ -203,6 +226,8 @@ func TestResolveConstraint(t *testing.T) {
 		resultList          any
 		expectedRes         string
 		expectedTaskVersion string
+		expectedAnnotations map[string]string
+		digest              string
 		expectedErr         error
 	}{
 		{
@@ -329,6 +354,124 @@ func TestResolveConstraint(t *testing.T) {
 				},
 			},
 			expectedErr: errors.New("no version found for constraint >= 0.2.0"),
+		}, {
+			name:        "good/artifact hub/latest patch release",
+			kind:        "task",
+			version:     "0.9.x",
+			catalog:     "Tekton",
+			taskName:    "something",
+			hubType:     ArtifactHubType,
+			expectedRes: "some content",
+			resultTask: &artifactHubResponse{
+				Data: artifactHubDataResponse{
+					YAML: "some content",
+				},
+			},
+			resultList: &artifactHubListResult{
+				AvailableVersions: []artifactHubavailableVersionsResults{
+					{Version: "0.8.4"},
+					{Version: "0.9.0"},
+					{Version: "0.9.2"},
+					{Version: "0.9.3-rc.1", Prerelease: true},
+					{Version: "0.10.0"},
+				},
+			},
+			expectedTaskVersion: "0.9.2",
+			expectedAnnotations: map[string]string{
+				ResolvedVersionAnnotation:   "0.9.2",
+				VersionConstraintAnnotation: "0.9.x",
+			},
+		}, {
+			name:        "good/tekton hub/latest patch release",
+			kind:        "task",
+			version:     "~0.9",
+			catalog:     "Tekton",
+			taskName:    "something",
+			hubType:     TektonHubType,
+			expectedRes: "some content",
+			resultTask: &tektonHubResponse{
+				Data: tektonHubDataResponse{
+					YAML: "some content",
+				},
+			},
+			resultList: &tektonHubListResult{
+				Data: tektonHubListDataResult{
+					Versions: []tektonHubListResultVersion{
+						{Version: "0.8"},
+						{Version: "0.9"},
+						{Version: "0.10"},
+					},
+				},
+			},
+			expectedTaskVersion: "0.9",
+			expectedAnnotations: map[string]string{
+				ResolvedVersionAnnotation:   "0.9",
+				VersionConstraintAnnotation: "~0.9",
+			},
+		}, {
+			name:        "good/tekton hub/latest release",
+			kind:        "task",
+			version:     "latest",
+			catalog:     "Tekton",
+			taskName:    "something",
+			hubType:     TektonHubType,
+			expectedRes: "some content",
+			resultTask: &tektonHubResponse{
+				Data: tektonHubDataResponse{
+					YAML: "some content",
+				},
+			},
+			resultList: &tektonHubListResult{
+				Data: tektonHubListDataResult{
+					Versions: []tektonHubListResultVersion{
+						{Version: "0.10"},
+						{Version: "0.9"},
+					},
+				},
+			},
+			expectedTaskVersion: "0.10",
+		}, {
+			name:        "good/artifact hub/exact version pinned by digest",
+			kind:        "task",
+			version:     "0.9",
+			catalog:     "Tekton",
+			taskName:    "something",
+			hubType:     ArtifactHubType,
+			expectedRes: "some content",
+			resultTask: &artifactHubResponse{
+				Data: artifactHubDataResponse{
+					YAML: "some content",
+				},
+			},
+			resultList: &artifactHubListResult{
+				AvailableVersions: []artifactHubavailableVersionsResults{
+					{Version: "0.9.0"},
+				},
+			},
+			digest:              "sha256:290f493c44f5d63d06b374d0a5abd292fae38b92cab2fae5efefe1b0e9347f56",
+			expectedTaskVersion: "0.9.0",
+			expectedAnnotations: map[string]string{
+				ResolvedVersionAnnotation: "0.9.0",
+			},
+		}, {
+			name:     "bad/artifact hub/digest mismatch",
+			kind:     "task",
+			version:  "0.9",
+			catalog:  "Tekton",
+			taskName: "something",
+			hubType:  ArtifactHubType,
+			resultTask: &artifactHubResponse{
+				Data: artifactHubDataResponse{
+					YAML: "other content",
+				},
+			},
+			resultList: &artifactHubListResult{
+				AvailableVersions: []artifactHubavailableVersionsResults{
+					{Version: "0.9.0"},
+				},
+			},
+			digest:      "sha256:290f493c44f5d63d06b374d0a5abd292fae38b92cab2fae5efefe1b0e9347f56",
+			expectedErr: errors.New("content of task something version 0.9.0 does not match digest sha256:290f493c44f5d63d06b374d0a5abd292fae38b92cab2fae5efefe1b0e9347f56, got sha256:923b805711041e23a99f07e146591c500261d1c289f62a9d39f8581ceb8a10ca"),
 		},
 	}
 	for _, tt := range tests {
@@ -369,6 +512,9 @@ func TestResolveConstraint(t *testing.T) {
 				ParamCatalog: tt.catalog,
 				ParamType:    tt.hubType,
 			}
+			if tt.digest != "" {
+				params[ParamDigest] = tt.digest
+			}
 			output, err := resolver.Resolve(contextWithConfig(), toParams(params))
 			if tt.expectedErr != nil {
 				checkExpectedErr(t, tt.expectedErr, err)
@@ -379,6 +525,23 @@ func TestResolveConstraint(t *testing.T) {
 				if d := cmp.Diff(tt.expectedRes, string(output.Data())); d != "" {
 					t.Errorf("unexpected resource from Resolve: %s", diff.PrintWantGot(d))
 				}
+				if tt.expectedAnnotations != nil {
+					if d := cmp.Diff(tt.expectedAnnotations, output.Annotations()); d != "" {
+						t.Errorf("unexpected annotations from Resolve: %s", diff.PrintWantGot(d))
+					}
+				}
+				if tt.expectedTaskVersion != "" {
+					if d := cmp.Diff(tt.expectedTaskVersion, output.Annotations()[ResolvedVersionAnnotation]); d != "" {
+						t.Errorf("unexpected resolved version annotation: %s", diff.PrintWantGot(d))
+					}
+					refSource := output.RefSource()
+					if !strings.Contains(refSource.URI+"/", "/"+tt.expectedTaskVersion+"/") {
+						t.Errorf("expected RefSource URI %s to include version %s", refSource.URI, tt.expectedTaskVersion)
+					}
+					if _, ok := refSource.Digest["sha256"]; !ok || len(refSource.Digest) != 1 {
+						t.Errorf("expected RefSource digest to only hold the sha256 digest, got %v", refSource.Digest)
+					}
+				}
 			}
 		})
 	}
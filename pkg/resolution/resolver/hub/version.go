@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hub
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	goversion "github.com/hashicorp/go-version"
+)
+
+// versionConstraint parses version as a comma separated list of go-version
+// constraints. Each term may also use one of the range shorthands common to
+// other package managers, so that the same ranges select the same versions
+// from both hub types:
+//   - "latest" or "*" matches any release
+//   - "0.9.x" or "0.9.*" matches any release of 0.9
+//   - "~0.9.1" matches releases of 0.9 from 0.9.1, "~0.9" from 0.9.0
+//   - "^0.9.1" matches releases compatible with 0.9.1, that is from 0.9.1
+//     and before the next version that increments the left-most non-zero
+//     component, 0.10.0
+func versionConstraint(version string) (goversion.Constraints, error) {
+	terms := strings.Split(version, ",")
+	for i, term := range terms {
+		term = strings.TrimSpace(term)
+		var (
+			expanded string
+			err      error
+		)
+		switch {
+		case term == "latest" || term == "*":
+			expanded = ">= 0.0.0"
+		case strings.HasPrefix(term, "^"):
+			expanded, err = caretRange(strings.TrimPrefix(term, "^"))
+		case strings.HasPrefix(term, "~") && !strings.HasPrefix(term, "~>"):
+			expanded, err = tildeRange(strings.TrimPrefix(term, "~"))
+		case hasWildcardSegment(term):
+			expanded, err = wildcardRange(term)
+		default:
+			expanded = term
+		}
+		if err != nil {
+			return nil, err
+		}
+		terms[i] = expanded
+	}
+	return goversion.NewConstraint(strings.Join(terms, ", "))
+}
+
+// parseSegments parses a version of one to three numeric segments.
+func parseSegments(version string) ([]int, error) {
+	parts := strings.Split(strings.TrimSpace(version), ".")
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("invalid version %q in range", version)
+	}
+	segments := make([]int, 0, len(parts))
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid version %q in range", version)
+		}
+		segments = append(segments, n)
+	}
+	return segments, nil
+}
+
+// versionRange returns a constraint matching versions from lower, padded
+// with zeros, up to but excluding upper.
+func versionRange(lower []int, upper [3]int) string {
+	padded := [3]int{}
+	copy(padded[:], lower)
+	return fmt.Sprintf(">= %d.%d.%d, < %d.%d.%d", padded[0], padded[1], padded[2], upper[0], upper[1], upper[2])
+}
+
+func caretRange(version string) (string, error) {
+	s, err := parseSegments(version)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case s[0] > 0 || len(s) == 1:
+		return versionRange(s, [3]int{s[0] + 1, 0, 0}), nil
+	case s[1] > 0 || len(s) == 2:
+		return versionRange(s, [3]int{0, s[1] + 1, 0}), nil
+	default:
+		return versionRange(s, [3]int{0, 0, s[2] + 1}), nil
+	}
+}
+
+func tildeRange(version string) (string, error) {
+	s, err := parseSegments(version)
+	if err != nil {
+		return "", err
+	}
+	if len(s) == 1 {
+		return versionRange(s, [3]int{s[0] + 1, 0, 0}), nil
+	}
+	return versionRange(s, [3]int{s[0], s[1] + 1, 0}), nil
+}
+
+func wildcardRange(version string) (string, error) {
+	parts := strings.Split(version, ".")
+	fixed := 0
+	for fixed < len(parts) && !isWildcard(parts[fixed]) {
+		fixed++
+	}
+	for _, part := range parts[fixed:] {
+		if !isWildcard(part) {
+			return "", fmt.Errorf("invalid version %q in range", version)
+		}
+	}
+	if fixed == 0 {
+		return ">= 0.0.0", nil
+	}
+	if fixed > 2 {
+		return "", fmt.Errorf("invalid version %q in range", version)
+	}
+	s, err := parseSegments(strings.Join(parts[:fixed], "."))
+	if err != nil {
+		return "", err
+	}
+	if fixed == 1 {
+		return versionRange(s, [3]int{s[0] + 1, 0, 0}), nil
+	}
+	return versionRange(s, [3]int{s[0], s[1] + 1, 0}), nil
+}
+
+// hasWildcardSegment returns true if any dot-separated segment of version
+// is a wildcard, unlike versions that merely contain an x, such as
+// "1.0.0+linux-x86".
+func hasWildcardSegment(version string) bool {
+	for _, part := range strings.Split(version, ".") {
+		if isWildcard(strings.TrimSpace(part)) {
+			return true
+		}
+	}
+	return false
+}
+
+func isWildcard(part string) bool {
+	return part == "x" || part == "X" || part == "*"
+}
+
+// isExactVersion returns true if version names a single version rather than
+// a range of versions.
+func isExactVersion(version string) bool {
+	_, err := goversion.NewVersion(version)
+	return err == nil
+}
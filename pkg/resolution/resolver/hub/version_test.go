@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hub
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	goversion "github.com/hashicorp/go-version"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestVersionConstraint(t *testing.T) {
+	available := []string{"0.0.3", "0.0.4", "0.8", "0.9.0", "0.9.2", "0.10.0", "1.0.0", "1.2.1", "1.3.0-rc.1", "2.0.0"}
+
+	for _, tc := range []struct {
+		constraint  string
+		expected    []string
+		expectedErr bool
+	}{
+		{constraint: "latest", expected: []string{"0.0.3", "0.0.4", "0.8", "0.9.0", "0.9.2", "0.10.0", "1.0.0", "1.2.1", "2.0.0"}},
+		{constraint: "*", expected: []string{"0.0.3", "0.0.4", "0.8", "0.9.0", "0.9.2", "0.10.0", "1.0.0", "1.2.1", "2.0.0"}},
+		{constraint: "0.9", expected: []string{"0.9.0"}},
+		{constraint: "0.9.x", expected: []string{"0.9.0", "0.9.2"}},
+		{constraint: "0.9.*", expected: []string{"0.9.0", "0.9.2"}},
+		{constraint: "1.x", expected: []string{"1.0.0", "1.2.1"}},
+		{constraint: "1.X.*", expected: []string{"1.0.0", "1.2.1"}},
+		{constraint: "1.0.0+build.x86", expected: []string{"1.0.0"}},
+		{constraint: "~0.9", expected: []string{"0.9.0", "0.9.2"}},
+		{constraint: "~0.9.1", expected: []string{"0.9.2"}},
+		{constraint: "~1", expected: []string{"1.0.0", "1.2.1"}},
+		{constraint: "^0.9.0", expected: []string{"0.9.0", "0.9.2"}},
+		{constraint: "^1.0", expected: []string{"1.0.0", "1.2.1"}},
+		{constraint: "^0.0.3", expected: []string{"0.0.3"}},
+		{constraint: "^0.8, != 0.9.0", expected: []string{"0.8"}},
+		{constraint: ">= 0.9, < 1.0", expected: []string{"0.9.0", "0.9.2", "0.10.0"}},
+		{constraint: "~> 0.9.0", expected: []string{"0.9.0", "0.9.2"}},
+		{constraint: "1.3.0-rc.1", expected: []string{"1.3.0-rc.1"}},
+		{constraint: "0.x.1", expectedErr: true},
+		{constraint: "^main", expectedErr: true},
+		{constraint: "main", expectedErr: true},
+	} {
+		t.Run(tc.constraint, func(t *testing.T) {
+			c, err := versionConstraint(tc.constraint)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatalf("expected an error, got constraint %s", c)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var matched []string
+			for _, v := range available {
+				if c.Check(goversion.Must(goversion.NewVersion(v))) {
+					matched = append(matched, v)
+				}
+			}
+			if d := cmp.Diff(tc.expected, matched); d != "" {
+				t.Errorf("unexpected versions matching %s: %s", c, diff.PrintWantGot(d))
+			}
+		})
+	}
+}
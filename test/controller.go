@@ -342,7 +342,7 @@ func PrependResourceVersionReactor(f *ktesting.Fake) {
 
 // EnsureConfigurationConfigMapsExist makes sure all the configmaps exists.
 func EnsureConfigurationConfigMapsExist(d *Data) {
-	var defaultsExists, featureFlagsExists, metricsExists, spireconfigExists, eventsExists, tracingExists, backoffExists bool
+	var defaultsExists, featureFlagsExists, metricsExists, spireconfigExists, eventsExists, tracingExists, backoffExists, schedulingExists bool
 	for _, cm := range d.ConfigMaps {
 		if cm.Name == config.GetDefaultsConfigName() {
 			defaultsExists = true
@@ -365,6 +365,9 @@ func EnsureConfigurationConfigMapsExist(d *Data) {
 		if cm.Name == config.GetWaitExponentialBackoffConfigName() {
 			backoffExists = true
 		}
+		if cm.Name == config.GetSchedulingConfigName() {
+			schedulingExists = true
+		}
 	}
 	if !defaultsExists {
 		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
@@ -408,4 +411,10 @@ func EnsureConfigurationConfigMapsExist(d *Data) {
 			Data:       map[string]string{},
 		})
 	}
+	if !schedulingExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetSchedulingConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
 }
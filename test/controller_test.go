@@ -163,6 +163,10 @@ func TestEnsureConfigurationConfigMapsExist(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{Name: config.GetWaitExponentialBackoffConfigName(), Namespace: system.Namespace()},
 		Data:       map[string]string{},
 	})
+	expected.ConfigMaps = append(expected.ConfigMaps, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetSchedulingConfigName(), Namespace: system.Namespace()},
+		Data:       map[string]string{},
+	})
 
 	EnsureConfigurationConfigMapsExist(&d)
 	if d := cmp.Diff(expected, d); d != "" {